@@ -0,0 +1,374 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/api/v2/service"
+	gw "github.com/MinterTeam/node-grpc-gateway/api_pb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// extHandlerFunc serves a method which is not described by the gRPC gateway protocol
+type extHandlerFunc func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error)
+
+// registerExtHandlers registers HTTP handlers of methods which are not described by the gRPC gateway protocol
+func registerExtHandlers(gwmux *runtime.ServeMux, srv *service.Service) error {
+	handlers := []struct {
+		method  string
+		pattern string
+		handler extHandlerFunc
+	}{
+		{"GET", "/simulate_transaction/{tx}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			return srv.SimulateTransaction(ctx, &service.SimulateTransactionRequest{Tx: pathParams["tx"], From: r.URL.Query().Get("from")})
+		}},
+		{"POST", "/simulate_transaction", func(ctx context.Context, r *http.Request, _ map[string]string) (interface{}, error) {
+			req := &service.SimulateTransactionRequest{}
+			if err := decodeExtRequest(r, req); err != nil {
+				return nil, err
+			}
+			return srv.SimulateTransaction(ctx, req)
+		}},
+		{"GET", "/decode_transaction/{tx}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			return srv.DecodeTransaction(ctx, &service.DecodeTransactionRequest{Tx: pathParams["tx"]})
+		}},
+		{"GET", "/address_history/{address}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			query := r.URL.Query()
+			req := &service.AddressHistoryRequest{
+				Address: pathParams["address"],
+				Cursor:  query.Get("cursor"),
+				Order:   query.Get("order"),
+			}
+			if limit := query.Get("limit"); limit != "" {
+				value, err := strconv.Atoi(limit)
+				if err != nil {
+					return nil, status.Error(codes.InvalidArgument, err.Error())
+				}
+				req.Limit = value
+			}
+			for _, txType := range query["types"] {
+				value, err := strconv.ParseUint(txType, 0, 8)
+				if err != nil {
+					return nil, status.Error(codes.InvalidArgument, err.Error())
+				}
+				req.Types = append(req.Types, value)
+			}
+			return srv.AddressHistory(ctx, req)
+		}},
+		{"GET", "/swap_pool_candles/{coin0}/{coin1}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			query := r.URL.Query()
+			req := &service.SwapPoolCandlesRequest{Interval: query.Get("interval")}
+			var err error
+			if req.Coin0, err = parseExtUint(pathParams["coin0"]); err != nil {
+				return nil, err
+			}
+			if req.Coin1, err = parseExtUint(pathParams["coin1"]); err != nil {
+				return nil, err
+			}
+			if req.From, err = parseExtInt(query.Get("from")); err != nil {
+				return nil, err
+			}
+			if req.To, err = parseExtInt(query.Get("to")); err != nil {
+				return nil, err
+			}
+			return srv.SwapPoolCandles(ctx, req)
+		}},
+		{"GET", "/swap_pool_stats/{coin0}/{coin1}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			req := &service.SwapPoolStatsRequest{}
+			var err error
+			if req.Coin0, err = parseExtUint(pathParams["coin0"]); err != nil {
+				return nil, err
+			}
+			if req.Coin1, err = parseExtUint(pathParams["coin1"]); err != nil {
+				return nil, err
+			}
+			return srv.SwapPoolStats(ctx, req)
+		}},
+		{"GET", "/swap_pool_depth/{coin0}/{coin1}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			query := r.URL.Query()
+			req := &service.SwapPoolDepthRequest{Tick: query.Get("tick")}
+			var err error
+			if req.Coin0, err = parseExtUint(pathParams["coin0"]); err != nil {
+				return nil, err
+			}
+			if req.Coin1, err = parseExtUint(pathParams["coin1"]); err != nil {
+				return nil, err
+			}
+			if req.Height, err = parseExtUint(query.Get("height")); err != nil {
+				return nil, err
+			}
+			levels, err := parseExtUint(query.Get("levels"))
+			if err != nil {
+				return nil, err
+			}
+			req.Levels = int(levels)
+			return srv.SwapPoolDepth(ctx, req)
+		}},
+		{"GET", "/orders_history/{address}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			return srv.OrdersHistory(ctx, &service.OrdersHistoryRequest{Address: pathParams["address"], Status: r.URL.Query().Get("status")})
+		}},
+		{"GET", "/coin_holders/{coin}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			req := &service.CoinHoldersRequest{}
+			var err error
+			if req.Coin, err = parseExtUint(pathParams["coin"]); err != nil {
+				return nil, err
+			}
+			limit, err := parseExtUint(r.URL.Query().Get("limit"))
+			if err != nil {
+				return nil, err
+			}
+			req.Limit = int(limit)
+			return srv.CoinHolders(ctx, req)
+		}},
+		{"GET", "/reward_history", func(ctx context.Context, r *http.Request, _ map[string]string) (interface{}, error) {
+			query := r.URL.Query()
+			req := &service.RewardHistoryRequest{
+				Address:   query.Get("address"),
+				PublicKey: query.Get("public_key"),
+				Group:     query.Get("group"),
+			}
+			var err error
+			if req.FromHeight, err = parseExtUint(query.Get("from_height")); err != nil {
+				return nil, err
+			}
+			if req.ToHeight, err = parseExtUint(query.Get("to_height")); err != nil {
+				return nil, err
+			}
+			return srv.RewardHistory(ctx, req)
+		}},
+		{"GET", "/htlc/{hash_lock}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			height, err := parseExtUint(r.URL.Query().Get("height"))
+			if err != nil {
+				return nil, err
+			}
+			return srv.HTLCs(ctx, &service.HTLCsRequest{HashLock: pathParams["hash_lock"], Height: height})
+		}},
+		{"GET", "/htlcs/{address}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			height, err := parseExtUint(r.URL.Query().Get("height"))
+			if err != nil {
+				return nil, err
+			}
+			return srv.HTLCs(ctx, &service.HTLCsRequest{Address: pathParams["address"], Height: height})
+		}},
+		{"GET", "/address_vesting/{address}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			height, err := parseExtUint(r.URL.Query().Get("height"))
+			if err != nil {
+				return nil, err
+			}
+			return srv.AddressVesting(ctx, &service.AddressVestingRequest{Address: pathParams["address"], Height: height})
+		}},
+		{"GET", "/allowances/{address}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			height, err := parseExtUint(r.URL.Query().Get("height"))
+			if err != nil {
+				return nil, err
+			}
+			return srv.Allowances(ctx, &service.AllowancesRequest{Owner: pathParams["address"], Spender: r.URL.Query().Get("spender"), Height: height})
+		}},
+		{"GET", "/recurring_payments/{address}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			height, err := parseExtUint(r.URL.Query().Get("height"))
+			if err != nil {
+				return nil, err
+			}
+			return srv.RecurringPayments(ctx, &service.RecurringPaymentsRequest{Payer: pathParams["address"], Height: height})
+		}},
+		{"GET", "/multisig_proposals/{address}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			height, err := parseExtUint(r.URL.Query().Get("height"))
+			if err != nil {
+				return nil, err
+			}
+			return srv.MultisigProposals(ctx, &service.MultisigProposalsRequest{Multisig: pathParams["address"], Height: height})
+		}},
+		{"POST", "/multisig_txs", func(ctx context.Context, r *http.Request, _ map[string]string) (interface{}, error) {
+			req := &service.SubmitMultisigTxRequest{}
+			if err := decodeExtRequest(r, req); err != nil {
+				return nil, err
+			}
+			return srv.SubmitMultisigTx(ctx, req)
+		}},
+		{"POST", "/multisig_txs/{hash}/signatures", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			req := &service.AddMultisigSignatureRequest{}
+			if err := decodeExtRequest(r, req); err != nil {
+				return nil, err
+			}
+			req.Hash = pathParams["hash"]
+			return srv.AddMultisigSignature(ctx, req)
+		}},
+		{"GET", "/multisig_txs/{address}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			return srv.MultisigTxs(ctx, &service.MultisigTxsRequest{Multisig: pathParams["address"]})
+		}},
+		{"GET", "/network_params", func(ctx context.Context, r *http.Request, _ map[string]string) (interface{}, error) {
+			height, err := parseExtUint(r.URL.Query().Get("height"))
+			if err != nil {
+				return nil, err
+			}
+			return srv.NetworkParams(ctx, &service.NetworkParamsRequest{Height: height})
+		}},
+		{"GET", "/params_proposals", func(ctx context.Context, r *http.Request, _ map[string]string) (interface{}, error) {
+			height, err := parseExtUint(r.URL.Query().Get("height"))
+			if err != nil {
+				return nil, err
+			}
+			return srv.ParamsProposals(ctx, &service.ParamsProposalsRequest{Height: height})
+		}},
+		{"GET", "/check_info/{check}", func(ctx context.Context, r *http.Request, pathParams map[string]string) (interface{}, error) {
+			height, err := parseExtUint(r.URL.Query().Get("height"))
+			if err != nil {
+				return nil, err
+			}
+			return srv.CheckInfo(ctx, &service.CheckInfoRequest{Check: pathParams["check"], Height: height})
+		}},
+	}
+
+	for _, h := range handlers {
+		if err := gwmux.HandlePath(h.method, h.pattern, serveExt(gwmux, srv, h.handler)); err != nil {
+			return err
+		}
+	}
+
+	streamHandlers := []struct {
+		method  string
+		pattern string
+		handler extStreamHandlerFunc
+	}{
+		{"GET", "/events_stream", func(stream *extStream, r *http.Request, _ map[string]string) error {
+			query := r.URL.Query()
+			req := &service.EventsStreamRequest{
+				Types:      query["types"],
+				Addresses:  query["addresses"],
+				PublicKeys: query["public_keys"],
+			}
+			if fromHeight := query.Get("from_height"); fromHeight != "" {
+				height, err := strconv.ParseUint(fromHeight, 10, 64)
+				if err != nil {
+					return status.Error(codes.InvalidArgument, err.Error())
+				}
+				req.FromHeight = height
+			}
+			return srv.EventsStream(req, &eventsStream{stream})
+		}},
+	}
+
+	for _, h := range streamHandlers {
+		if err := gwmux.HandlePath(h.method, h.pattern, serveExtStream(h.handler)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseExtUint parses an optional unsigned integer parameter, empty value is zero
+func parseExtUint(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return result, nil
+}
+
+// parseExtInt parses an optional integer parameter, empty value is zero
+func parseExtInt(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return result, nil
+}
+
+func decodeExtRequest(r *http.Request, req interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+func serveExt(gwmux *runtime.ServeMux, srv *service.Service, handler extHandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithTimeout(r.Context(), srv.TimeoutDuration())
+		defer cancel()
+
+		_, marshaler := runtime.MarshalerForRequest(gwmux, r)
+
+		resp, err := handler(ctx, r, pathParams)
+		if err != nil {
+			httpError(ctx, gwmux, marshaler, w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			grpclog.Infof("Failed to write response: %v", err)
+		}
+	}
+}
+
+// extStreamHandlerFunc serves a server-streaming method which is not described by the gRPC gateway protocol
+type extStreamHandlerFunc func(stream *extStream, r *http.Request, pathParams map[string]string) error
+
+// extStream writes messages as newline-delimited JSON in the format of the gRPC gateway server streams,
+// the WebSocket proxy of API v2 sends every line as a message like for the streams of the gateway
+type extStream struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	encoder *json.Encoder
+}
+
+func (s *extStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *extStream) send(msg interface{}) error {
+	if err := s.encoder.Encode(map[string]interface{}{"result": msg}); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+type eventsStream struct {
+	*extStream
+}
+
+func (s *eventsStream) Send(msg *service.EventsStreamResponse) error {
+	return s.send(msg)
+}
+
+func serveExtStream(handler extStreamHandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Transfer-Encoding", "chunked")
+
+		stream := &extStream{ctx: r.Context(), w: w, encoder: json.NewEncoder(w)}
+		err := handler(stream, r, pathParams)
+		if err == nil || r.Context().Err() != nil {
+			return
+		}
+
+		s, ok := status.FromError(err)
+		if !ok {
+			s = status.New(codes.Unknown, err.Error())
+		}
+		codeString, data := parseStatus(s)
+		delete(data, "code")
+		if err := stream.encoder.Encode(map[string]interface{}{"error": &gw.ErrorBody_Error{
+			Code:    codeString,
+			Message: s.Message(),
+			Data:    data,
+		}}); err != nil {
+			grpclog.Infof("Failed to write response: %v", err)
+		}
+	}
+}
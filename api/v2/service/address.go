@@ -44,7 +44,7 @@ func (s *Service) Address(ctx context.Context, req *pb.AddressRequest) (*pb.Addr
 		return nil, timeoutStatus.Err()
 	}
 
-	balances := cState.Accounts().GetBalances(address)
+	balances := cState.AccountsWithLocked().GetBalances(address)
 	var res pb.AddressResponse
 
 	totalStakesGroupByCoin := map[types.CoinID]*big.Int{}
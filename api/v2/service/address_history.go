@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/activity"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	addressHistoryDefaultLimit = 50
+	addressHistoryMaxLimit     = 1000
+)
+
+// AddressHistoryRequest is a request of AddressHistory.
+// Cursor is the next_cursor of the previous page, Order is "asc" (default) or "desc", empty Types matches any transaction type.
+type AddressHistoryRequest struct {
+	Address string   `json:"address"`
+	Cursor  string   `json:"cursor"`
+	Limit   int      `json:"limit"`
+	Order   string   `json:"order"`
+	Types   []uint64 `json:"types"`
+}
+
+// AddressHistoryResponse is a page of transactions involving the address.
+type AddressHistoryResponse struct {
+	Transactions []*AddressHistoryTransaction `json:"transactions"`
+	NextCursor   string                       `json:"next_cursor,omitempty"`
+}
+
+// AddressHistoryTransaction is a transaction involving the address.
+type AddressHistoryTransaction struct {
+	Hash   string `json:"hash"`
+	Height uint64 `json:"height"`
+	Index  uint64 `json:"index"`
+	Type   uint64 `json:"type"`
+	Code   uint64 `json:"code"`
+}
+
+// AddressHistory returns transactions involving the address: sent, signed, received by it or filling its orders.
+func (s *Service) AddressHistory(ctx context.Context, req *AddressHistoryRequest) (*AddressHistoryResponse, error) {
+	index := s.blockchain.AddressIndex()
+	if index == nil {
+		return nil, status.Error(codes.Unavailable, "address index is disabled")
+	}
+
+	if !strings.HasPrefix(strings.Title(req.Address), "Mx") || len(req.Address) != 42 {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+	address := types.HexToAddress(req.Address)
+
+	var cursor *activity.Cursor
+	if req.Cursor != "" {
+		var err error
+		cursor, err = activity.ParseCursor(req.Cursor)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor: %s", err)
+		}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = addressHistoryDefaultLimit
+	}
+	if limit > addressHistoryMaxLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit should not exceed %d", addressHistoryMaxLimit)
+	}
+
+	var desc bool
+	switch req.Order {
+	case "", "asc":
+	case "desc":
+		desc = true
+	default:
+		return nil, status.Error(codes.InvalidArgument, "order should be asc or desc")
+	}
+
+	txTypes := make([]byte, 0, len(req.Types))
+	for _, txType := range req.Types {
+		if txType > 0xff {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid type %d", txType)
+		}
+		txTypes = append(txTypes, byte(txType))
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	txs, next, err := index.History(address, cursor, limit, desc, txTypes)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &AddressHistoryResponse{Transactions: make([]*AddressHistoryTransaction, 0, len(txs))}
+	for _, tx := range txs {
+		response.Transactions = append(response.Transactions, &AddressHistoryTransaction{
+			Hash:   strings.Title(fmt.Sprintf("Mt%x", tx.Hash)),
+			Height: tx.Height,
+			Index:  uint64(tx.Index),
+			Type:   uint64(tx.Type),
+			Code:   uint64(tx.Code),
+		})
+	}
+	if next != nil {
+		response.NextCursor = next.String()
+	}
+
+	return response, nil
+}
@@ -47,7 +47,7 @@ func (s *Service) Addresses(ctx context.Context, req *pb.AddressesRequest) (*pb.
 		}
 		address := types.BytesToAddress(decodeString)
 
-		balances := cState.Accounts().GetBalances(address)
+		balances := cState.AccountsWithLocked().GetBalances(address)
 		var res pb.AddressesResponse_Result
 
 		totalStakesGroupByCoin := map[types.CoinID]*big.Int{}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AllowancesRequest is a request of allowances given by the owner, optionally only to the spender.
+type AllowancesRequest struct {
+	Owner   string `json:"owner"`
+	Spender string `json:"spender"`
+	Height  uint64 `json:"height"`
+}
+
+// AllowancesResponse contains allowances given by the owner.
+type AllowancesResponse struct {
+	Allowances []*Allowance `json:"allowances"`
+}
+
+// Allowance is the value of the coin which the spender can transfer from the balance of the owner.
+type Allowance struct {
+	Spender    string `json:"spender"`
+	Coin       uint64 `json:"coin"`
+	CoinSymbol string `json:"coin_symbol"`
+	Value      string `json:"value"`
+}
+
+// Allowances returns allowances given by the owner.
+func (s *Service) Allowances(ctx context.Context, req *AllowancesRequest) (*AllowancesResponse, error) {
+	owner, err := decodeAddress(req.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var spender *types.Address
+	if req.Spender != "" {
+		address, err := decodeAddress(req.Spender)
+		if err != nil {
+			return nil, err
+		}
+		spender = &address
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	res := &AllowancesResponse{Allowances: []*Allowance{}}
+	for _, allowance := range cState.Allowances().GetAllowances(owner) {
+		if spender != nil && allowance.Spender != *spender {
+			continue
+		}
+		res.Allowances = append(res.Allowances, &Allowance{
+			Spender:    allowance.Spender.String(),
+			Coin:       uint64(allowance.Coin),
+			CoinSymbol: cState.Coins().GetCoin(allowance.Coin).GetFullSymbol(),
+			Value:      allowance.Value.String(),
+		})
+	}
+
+	return res, nil
+}
+
+func decodeAddress(address string) (types.Address, error) {
+	if !strings.HasPrefix(strings.Title(address), "Mx") {
+		return types.Address{}, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(address[2:])
+	if err != nil || len(decodeString) != types.AddressLength {
+		return types.Address{}, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	return types.BytesToAddress(decodeString), nil
+}
@@ -202,7 +202,15 @@ func (s *Service) Block(ctx context.Context, req *pb.BlockRequest) (*pb.BlockRes
 					RemoveLimitOrder:        e.RemoveLimitOrder,
 				}
 			default:
-				return nil, status.Error(codes.Internal, "unknown event type")
+				// events without messages of the gateway are encoded with their types
+				data, err := tmjson.Marshal(event)
+				if err != nil {
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+				m, err = encodeToStruct(data)
+				if err != nil {
+					return nil, status.Error(codes.Internal, err.Error())
+				}
 			}
 
 			a, err := anypb.New(m)
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckInfoRequest is a request of CheckInfo with the check encoded as hex with the Mc prefix.
+type CheckInfoRequest struct {
+	Check  string `json:"check"`
+	Height uint64 `json:"height"`
+}
+
+// CheckInfoResponse contains fields of the check and its state.
+// Redeemable reports whether the check can be redeemed in the next block by the holder of its passphrase.
+type CheckInfoResponse struct {
+	Hash              string `json:"hash"`
+	Issuer            string `json:"issuer"`
+	Nonce             string `json:"nonce"`
+	ChainID           uint64 `json:"chain_id"`
+	DueBlock          uint64 `json:"due_block"`
+	Coin              uint64 `json:"coin"`
+	CoinSymbol        string `json:"coin_symbol"`
+	Value             string `json:"value"`
+	GasCoin           uint64 `json:"gas_coin"`
+	GasCoinSymbol     string `json:"gas_coin_symbol"`
+	Used              bool   `json:"used"`
+	Expired           bool   `json:"expired"`
+	IssuerBalance     string `json:"issuer_balance"`
+	SufficientBalance bool   `json:"sufficient_balance"`
+	Redeemable        bool   `json:"redeemable"`
+}
+
+// CheckInfo decodes the check and reports whether it's still redeemable.
+func (s *Service) CheckInfo(ctx context.Context, req *CheckInfoRequest) (*CheckInfoResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Check), "Mc") {
+		return nil, status.Error(codes.InvalidArgument, "invalid check")
+	}
+
+	rawCheck, err := hex.DecodeString(req.Check[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	decodedCheck, err := check.DecodeFromBytes(rawCheck)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	issuer, err := decodedCheck.Sender()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	hash := decodedCheck.Hash()
+	balance := cState.Accounts().GetBalance(issuer, decodedCheck.Coin)
+	res := &CheckInfoResponse{
+		Hash:              hex.EncodeToString(hash[:]),
+		Issuer:            issuer.String(),
+		Nonce:             hex.EncodeToString(decodedCheck.Nonce),
+		ChainID:           uint64(decodedCheck.ChainID),
+		DueBlock:          decodedCheck.DueBlock,
+		Coin:              uint64(decodedCheck.Coin),
+		Value:             decodedCheck.Value.String(),
+		GasCoin:           uint64(decodedCheck.GasCoin),
+		Used:              cState.Checks().IsCheckUsed(decodedCheck),
+		Expired:           decodedCheck.DueBlock < height+1,
+		IssuerBalance:     balance.String(),
+		SufficientBalance: balance.Cmp(decodedCheck.Value) >= 0,
+	}
+	if coin := cState.Coins().GetCoin(decodedCheck.Coin); coin != nil {
+		res.CoinSymbol = coin.GetFullSymbol()
+	}
+	if gasCoin := cState.Coins().GetCoin(decodedCheck.GasCoin); gasCoin != nil {
+		res.GasCoinSymbol = gasCoin.GetFullSymbol()
+	}
+	res.Redeemable = decodedCheck.ChainID == types.CurrentChainID && res.CoinSymbol != "" &&
+		!res.Used && !res.Expired && res.SufficientBalance
+
+	return res, nil
+}
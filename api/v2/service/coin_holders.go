@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	coinHoldersDefaultLimit = 100
+	coinHoldersMaxLimit     = 1000
+)
+
+// CoinHoldersRequest is a request of CoinHolders.
+type CoinHoldersRequest struct {
+	Coin  uint64 `json:"coin"`
+	Limit int    `json:"limit"`
+}
+
+// CoinHoldersResponse contains top holders, the number of holders and the distribution of the coin at the current height.
+type CoinHoldersResponse struct {
+	Height       uint64                     `json:"height"`
+	HoldersCount uint64                     `json:"holders_count"`
+	Holders      []*CoinHolder              `json:"holders"`
+	Distribution []*CoinHoldersDistribution `json:"distribution"`
+}
+
+// CoinHolder is the address holding the coin on the balance and in stakes.
+type CoinHolder struct {
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+	Stake   string `json:"stake"`
+	Total   string `json:"total"`
+}
+
+// CoinHoldersDistribution is the number of holders with total holdings in [from, to) and the sum of their holdings.
+type CoinHoldersDistribution struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Holders uint64 `json:"holders"`
+	Amount  string `json:"amount"`
+}
+
+// CoinHolders returns top holders of the coin by the sum of balances and stakes, the number of holders and their distribution.
+func (s *Service) CoinHolders(ctx context.Context, req *CoinHoldersRequest) (*CoinHoldersResponse, error) {
+	index := s.blockchain.Holders()
+	if index == nil {
+		return nil, status.Error(codes.Unavailable, "holders index is disabled")
+	}
+
+	coinID := types.CoinID(req.Coin)
+	if !s.blockchain.CurrentState().Coins().Exists(coinID) {
+		return nil, s.createError(status.New(codes.NotFound, "Coin not found"), transaction.EncodeError(code.NewCoinNotExists("", strconv.Itoa(int(req.Coin)))))
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = coinHoldersDefaultLimit
+	}
+	if limit > coinHoldersMaxLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit should not exceed %d", coinHoldersMaxLimit)
+	}
+
+	height, err := index.LastHeight()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	count, err := index.Count(coinID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	top, err := index.Top(coinID, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	distribution, err := index.Distribution(coinID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &CoinHoldersResponse{
+		Height:       height,
+		HoldersCount: count,
+		Holders:      make([]*CoinHolder, 0, len(top)),
+		Distribution: make([]*CoinHoldersDistribution, 0, len(distribution)),
+	}
+	for _, holder := range top {
+		response.Holders = append(response.Holders, &CoinHolder{
+			Address: holder.Address.String(),
+			Balance: holder.Balance.String(),
+			Stake:   holder.Stake.String(),
+			Total:   holder.Total().String(),
+		})
+	}
+	for _, bucket := range distribution {
+		response.Distribution = append(response.Distribution, &CoinHoldersDistribution{
+			From:    bucket.From().String(),
+			To:      bucket.To().String(),
+			Holders: bucket.Holders,
+			Amount:  bucket.Amount.String(),
+		})
+	}
+
+	return response, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
 	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
 	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	_struct "google.golang.org/protobuf/types/known/structpb"
@@ -389,6 +391,243 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			},
 			Value: d.Value.String(),
 		}
+	case transaction.TypeBatch:
+		d := data.(*transaction.BatchData)
+		txs, err := d.DecodedTxs()
+		if err != nil {
+			return nil, err
+		}
+		batch := make([]interface{}, 0, len(txs))
+		for _, tx := range txs {
+			txData, err := encodeToMap(tx, rCoins)
+			if err != nil {
+				return nil, err
+			}
+			batch = append(batch, map[string]interface{}{
+				"type": tx.TxType().UInt64(),
+				"data": txData,
+			})
+		}
+		m, err = _struct.NewStruct(map[string]interface{}{"txs": batch})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeCreateHTLC:
+		d := data.(*transaction.CreateHTLCData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"recipient": d.Recipient.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value":     d.Value.String(),
+			"hash_lock": hex.EncodeToString(d.HashLock[:]),
+			"timeout":   d.Timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeClaimHTLC:
+		d := data.(*transaction.ClaimHTLCData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"preimage": hex.EncodeToString(d.Preimage),
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeRefundHTLC:
+		d := data.(*transaction.RefundHTLCData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"hash_lock": hex.EncodeToString(d.HashLock[:]),
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeVesting:
+		d := data.(*transaction.VestingData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"to": d.To.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value":       d.Value.String(),
+			"cliff_block": d.CliffBlock,
+			"end_block":   d.EndBlock,
+			"steps":       d.Steps,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeApprove:
+		d := data.(*transaction.ApproveData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"spender": d.Spender.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value": d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeRevoke:
+		d := data.(*transaction.RevokeData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"spender": d.Spender.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeTransferFrom:
+		d := data.(*transaction.TransferFromData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"from": d.From.String(),
+			"to":   d.To.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value": d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeCreateRecurringPayment:
+		d := data.(*transaction.CreateRecurringPaymentData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"to": d.To.String(),
+			"coin": map[string]interface{}{
+				"id":     uint64(d.Coin),
+				"symbol": rCoins.GetCoin(d.Coin).GetFullSymbol(),
+			},
+			"value":  d.Value.String(),
+			"period": d.Period,
+			"times":  d.Times,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeCancelRecurringPayment:
+		d := data.(*transaction.CancelRecurringPaymentData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"id": d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeCreateMultisigProposal:
+		d := data.(*transaction.CreateMultisigProposalData)
+		tx, err := d.DecodedTx()
+		if err != nil {
+			return nil, err
+		}
+		txData, err := encodeToMap(tx, rCoins)
+		if err != nil {
+			return nil, err
+		}
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"multisig": d.Multisig.String(),
+			"gas_coin": map[string]interface{}{
+				"id":     uint64(d.GasCoin),
+				"symbol": rCoins.GetCoin(d.GasCoin).GetFullSymbol(),
+			},
+			"type": d.Type.UInt64(),
+			"data": txData,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeApproveMultisigProposal:
+		d := data.(*transaction.ApproveMultisigProposalData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"id": d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeExecuteMultisigProposal:
+		d := data.(*transaction.ExecuteMultisigProposalData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"id": d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeCancelMultisigProposal:
+		d := data.(*transaction.CancelMultisigProposalData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"id": d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeRedeemChecks:
+		d := data.(*transaction.RedeemChecksData)
+		checks := make([]interface{}, 0, len(d.Checks))
+		for _, item := range d.Checks {
+			checks = append(checks, map[string]interface{}{
+				"raw_check": base64.StdEncoding.EncodeToString(item.RawCheck),
+				"proof":     base64.StdEncoding.EncodeToString(item.Proof[:]),
+			})
+		}
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"checks": checks,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeCancelCheck:
+		d := data.(*transaction.CancelCheckData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"raw_check": base64.StdEncoding.EncodeToString(d.RawCheck),
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeProposeParams:
+		d := data.(*transaction.ProposeParamsData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"pub_key": d.PubKey.String(),
+			"height":  d.Height,
+			"name":    d.Name,
+			"value":   d.Value,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case transaction.TypeVoteParams:
+		d := data.(*transaction.VoteParamsData)
+		var err error
+		m, err = _struct.NewStruct(map[string]interface{}{
+			"pub_key": d.PubKey.String(),
+			"height":  d.Height,
+			"name":    d.Name,
+			"value":   d.Value,
+		})
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.New("unknown tx type")
 	}
@@ -401,6 +640,23 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 	return a, nil
 }
 
+// encodeToMap returns the encoded data of the nested transaction as a JSON object
+func encodeToMap(data transaction.Data, rCoins coins.RCoins) (map[string]interface{}, error) {
+	a, err := encode(data, data.TxType(), rCoins)
+	if err != nil {
+		return nil, err
+	}
+	b, err := protojson.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	var txData map[string]interface{}
+	if err := json.Unmarshal(b, &txData); err != nil {
+		return nil, err
+	}
+	return txData, nil
+}
+
 func priceCommissionData(d *transaction.VoteCommissionDataV3, coin *coins.Model) proto.Message {
 	return &pb.VoteCommissionData{
 		PubKey: d.PubKey.String(),
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// DecodeTransactionRequest is a request of DecodeTransaction with the hex encoded signed transaction.
+type DecodeTransactionRequest struct {
+	Tx string `json:"tx"`
+}
+
+// DecodeTransactionResponse contains fields of the signed transaction.
+// ValidUntil is zero for transactions which never expire, Expired reports whether the transaction can't be included in the next block.
+type DecodeTransactionResponse struct {
+	Hash          string          `json:"hash"`
+	From          string          `json:"from"`
+	FeePayer      string          `json:"fee_payer,omitempty"`
+	Nonce         uint64          `json:"nonce"`
+	ChainID       uint64          `json:"chain_id"`
+	GasPrice      uint64          `json:"gas_price"`
+	GasCoin       *SimulatedCoin  `json:"gas_coin"`
+	Gas           uint64          `json:"gas"`
+	Type          uint64          `json:"type"`
+	TypeHex       string          `json:"type_hex"`
+	Data          json.RawMessage `json:"data"`
+	Payload       []byte          `json:"payload"`
+	ServiceData   []byte          `json:"service_data"`
+	SignatureType uint64          `json:"signature_type"`
+	ValidUntil    uint64          `json:"valid_until"`
+	Expired       bool            `json:"expired"`
+}
+
+// DecodeTransaction decodes the signed transaction without sending it.
+func (s *Service) DecodeTransaction(ctx context.Context, req *DecodeTransactionRequest) (*DecodeTransactionResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Tx), "0x") {
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction")
+	}
+
+	rawTx, err := hex.DecodeString(req.Tx[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	decodedTx, err := s.decoderTx.DecodeFromBytes(rawTx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sender, err := decodedTx.Sender()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cState := s.blockchain.CurrentState()
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	dataStruct, err := encode(decodedTx.GetDecodedData(), decodedTx.Type, cState.Coins())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	data, err := protojson.Marshal(dataStruct)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	hash := sha256.Sum256(rawTx)
+	res := &DecodeTransactionResponse{
+		Hash:     "Mt" + hex.EncodeToString(hash[:]),
+		From:     sender.String(),
+		Nonce:    decodedTx.Nonce,
+		ChainID:  uint64(decodedTx.ChainID),
+		GasPrice: uint64(decodedTx.GasPrice),
+		GasCoin: &SimulatedCoin{
+			ID:     uint64(decodedTx.GasCoin),
+			Symbol: cState.Coins().GetCoin(decodedTx.GasCoin).GetFullSymbol(),
+		},
+		Gas:           uint64(decodedTx.Gas()),
+		Type:          decodedTx.Type.UInt64(),
+		TypeHex:       decodedTx.Type.String(),
+		Data:          data,
+		Payload:       decodedTx.Payload,
+		ServiceData:   decodedTx.ServiceData,
+		SignatureType: uint64(decodedTx.SignatureType),
+		ValidUntil:    decodedTx.ValidUntil,
+		Expired:       decodedTx.ValidUntil != 0 && s.blockchain.Height()+1 > decodedTx.ValidUntil,
+	}
+
+	if decodedTx.SignatureType == transaction.SigTypeSponsored {
+		feePayer, err := decodedTx.FeePayer()
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		res.FeePayer = feePayer.String()
+	}
+
+	return res, nil
+}
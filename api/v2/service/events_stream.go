@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const eventsStreamBuffer = 100
+
+// EventsStreamRequest is a request of EventsStream.
+// FromHeight is the first height to send stored events from, zero value means only new events.
+// Types, Addresses and PublicKeys are filters, empty filter matches any event.
+type EventsStreamRequest struct {
+	FromHeight uint64   `json:"from_height"`
+	Types      []string `json:"types"`
+	Addresses  []string `json:"addresses"`
+	PublicKeys []string `json:"public_keys"`
+}
+
+// EventsStreamResponse is the event committed at the height.
+type EventsStreamResponse struct {
+	Height uint64          `json:"height"`
+	Event  json.RawMessage `json:"event"`
+}
+
+// EventsStreamServer is the server API for EventsStream.
+type EventsStreamServer interface {
+	Send(*EventsStreamResponse) error
+	Context() context.Context
+}
+
+// EventsStream sends stored events starting from the given height and then new events as they are committed.
+// The method is not a part of the generated ApiService of node-grpc-gateway, it is served by API v2 as GET /v2/events_stream
+// over WebSocket through the same proxy as Subscribe, one message per event, or as newline-delimited JSON of a plain HTTP request.
+func (s *Service) EventsStream(req *EventsStreamRequest, stream EventsStreamServer) error {
+	eventsDB := s.blockchain.GetEventsDB()
+	subscriber, ok := eventsDB.(events.Subscriber)
+	if !ok {
+		return status.Error(codes.Unavailable, "events are not stored by the node")
+	}
+
+	filter, err := eventsStreamFilter(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(stream.Context(), s.minterCfg.WSConnectionDuration)
+	defer cancel()
+
+	next := uint32(req.FromHeight)
+	if next != 0 && uint64(next) < s.blockchain.InitialHeight() {
+		next = uint32(s.blockchain.InitialHeight())
+	}
+
+	for {
+		live, unsubscribe := subscriber.SubscribeEvents(eventsStreamBuffer)
+
+		if next != 0 {
+			next, err = s.sendStoredEvents(ctx, eventsDB, next, uint32(s.blockchain.Height()), filter, stream)
+			if err != nil {
+				unsubscribe()
+				return err
+			}
+		}
+
+		next, err = s.sendLiveEvents(ctx, eventsDB, live, next, filter, stream)
+		unsubscribe()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// sendStoredEvents sends events of heights from next to last and returns the first height which is not sent.
+// Heights below the last one without stored events are skipped, the last one may be not committed yet.
+func (s *Service) sendStoredEvents(ctx context.Context, eventsDB events.IEventsDB, next, last uint32, filter *events.Filter, stream EventsStreamServer) (uint32, error) {
+	for ; next <= last; next++ {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return next, timeoutStatus.Err()
+		}
+
+		heightEvents := eventsDB.LoadEvents(next)
+		if heightEvents == nil {
+			if next == last {
+				break
+			}
+			continue
+		}
+
+		if err := sendEvents(next, heightEvents, filter, stream); err != nil {
+			return next, err
+		}
+	}
+
+	return next, nil
+}
+
+// sendLiveEvents sends committed events until the subscription is closed and returns the first height which is not sent.
+func (s *Service) sendLiveEvents(ctx context.Context, eventsDB events.IEventsDB, live <-chan events.HeightEvents, next uint32, filter *events.Filter, stream EventsStreamServer) (uint32, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return next, status.FromContextError(ctx.Err()).Err()
+		case committed, ok := <-live:
+			if !ok {
+				return next, nil
+			}
+			if next == 0 {
+				next = committed.Height
+			}
+			if committed.Height < next {
+				continue
+			}
+
+			if committed.Height > next {
+				var err error
+				next, err = s.sendStoredEvents(ctx, eventsDB, next, committed.Height-1, filter, stream)
+				if err != nil {
+					return next, err
+				}
+			}
+
+			if err := sendEvents(committed.Height, committed.Events, filter, stream); err != nil {
+				return next, err
+			}
+			next = committed.Height + 1
+		}
+	}
+}
+
+func sendEvents(height uint32, heightEvents events.Events, filter *events.Filter, stream EventsStreamServer) error {
+	for _, event := range heightEvents {
+		if !filter.Match(event) {
+			continue
+		}
+
+		marshalJSON, err := tmjson.Marshal(event)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		if err := stream.Send(&EventsStreamResponse{Height: uint64(height), Event: marshalJSON}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func eventsStreamFilter(req *EventsStreamRequest) (*events.Filter, error) {
+	filter := &events.Filter{Types: req.Types}
+
+	for _, address := range req.Addresses {
+		if !strings.HasPrefix(strings.Title(address), "Mx") || len(address) != 42 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid address %s", address)
+		}
+		filter.Addresses = append(filter.Addresses, types.HexToAddress(address))
+	}
+
+	for _, publicKey := range req.PublicKeys {
+		if !strings.HasPrefix(publicKey, "Mp") || len(publicKey) != 66 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid public_key %s", publicKey)
+		}
+		filter.PubKeys = append(filter.PubKeys, types.HexToPubkey(publicKey))
+	}
+
+	return filter, nil
+}
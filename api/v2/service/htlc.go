@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/htlc"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HTLCsRequest is a request of HTLCs.
+// HashLock selects the contract by its hex encoded hash lock, otherwise Address selects contracts created by the address.
+type HTLCsRequest struct {
+	HashLock string `json:"hash_lock"`
+	Address  string `json:"address"`
+	Height   uint64 `json:"height"`
+}
+
+// HTLCsResponse contains hash time-locked contracts which are neither claimed nor refunded.
+type HTLCsResponse struct {
+	HTLCs []*HTLC `json:"htlcs"`
+}
+
+// HTLC is the hash time-locked contract, Expired contracts can only be refunded to the sender.
+type HTLC struct {
+	HashLock   string `json:"hash_lock"`
+	Sender     string `json:"sender"`
+	Recipient  string `json:"recipient"`
+	Coin       uint64 `json:"coin"`
+	CoinSymbol string `json:"coin_symbol"`
+	Value      string `json:"value"`
+	Timeout    uint64 `json:"timeout"`
+	Expired    bool   `json:"expired"`
+}
+
+// HTLCs returns the hash time-locked contract by its hash lock or contracts created by the address.
+func (s *Service) HTLCs(ctx context.Context, req *HTLCsRequest) (*HTLCsResponse, error) {
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	var contracts []*htlc.Model
+	if req.HashLock != "" {
+		b, err := hex.DecodeString(strings.TrimPrefix(req.HashLock, "0x"))
+		if err != nil || len(b) != types.HashLength {
+			return nil, status.Error(codes.InvalidArgument, "invalid hash lock")
+		}
+		var hashLock types.Hash
+		copy(hashLock[:], b)
+
+		contract := cState.HTLCs().GetHTLC(hashLock)
+		if contract == nil {
+			return nil, status.Error(codes.NotFound, "HTLC not found")
+		}
+		contracts = append(contracts, contract)
+	} else {
+		if !strings.HasPrefix(strings.Title(req.Address), "Mx") || len(req.Address) != 42 {
+			return nil, status.Error(codes.InvalidArgument, "invalid address")
+		}
+		contracts = cState.HTLCs().GetHTLCsBySender(types.HexToAddress(req.Address))
+	}
+
+	response := &HTLCsResponse{HTLCs: make([]*HTLC, 0, len(contracts))}
+	for _, contract := range contracts {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		hashLock := contract.HashLock()
+		response.HTLCs = append(response.HTLCs, &HTLC{
+			HashLock:   hex.EncodeToString(hashLock[:]),
+			Sender:     contract.Sender.String(),
+			Recipient:  contract.Recipient.String(),
+			Coin:       uint64(contract.Coin),
+			CoinSymbol: cState.Coins().GetCoin(contract.Coin).GetFullSymbol(),
+			Value:      contract.Value.String(),
+			Timeout:    contract.Timeout,
+			Expired:    height+1 >= contract.Timeout,
+		})
+	}
+
+	return response, nil
+}
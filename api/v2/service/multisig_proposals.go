@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// MultisigProposalsRequest is a request of transactions proposed on behalf of the multisig.
+type MultisigProposalsRequest struct {
+	Multisig string `json:"multisig"`
+	Height   uint64 `json:"height"`
+}
+
+// MultisigProposalsResponse contains not executed proposals of the multisig with its current threshold.
+type MultisigProposalsResponse struct {
+	Threshold uint64              `json:"threshold"`
+	Proposals []*MultisigProposal `json:"proposals"`
+}
+
+// MultisigProposal is the proposed transaction, it's executed when Weight of Approvals reaches the threshold.
+type MultisigProposal struct {
+	ID        uint64          `json:"id"`
+	Proposer  string          `json:"proposer"`
+	Height    uint64          `json:"height"`
+	GasCoin   uint64          `json:"gas_coin"`
+	Type      uint64          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	Approvals []string        `json:"approvals"`
+	Weight    uint64          `json:"weight"`
+}
+
+// MultisigProposals returns transactions proposed on behalf of the multisig.
+func (s *Service) MultisigProposals(ctx context.Context, req *MultisigProposalsRequest) (*MultisigProposalsResponse, error) {
+	multisig, err := decodeAddress(req.Multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	account := cState.Accounts().GetAccount(multisig)
+	if !account.IsMultisig() {
+		return nil, status.Error(codes.NotFound, "Multisig not found")
+	}
+	multisigData := account.Multisig()
+
+	res := &MultisigProposalsResponse{Threshold: uint64(multisigData.Threshold), Proposals: []*MultisigProposal{}}
+	for _, proposal := range cState.Proposals().GetProposals(multisig) {
+		data, err := transaction.CreateMultisigProposalData{Type: transaction.TxType(proposal.Type), Data: proposal.Data}.DecodedTx()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		encoded, err := encode(data, data.TxType(), cState.Coins())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		dataJSON, err := protojson.Marshal(encoded)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		item := &MultisigProposal{
+			ID:        proposal.ID,
+			Proposer:  proposal.Proposer.String(),
+			Height:    proposal.Height,
+			GasCoin:   uint64(proposal.GasCoin),
+			Type:      uint64(proposal.Type),
+			Data:      dataJSON,
+			Approvals: make([]string, 0, len(proposal.Approvals)),
+		}
+		for _, address := range proposal.Approvals {
+			item.Approvals = append(item.Approvals, address.String())
+			item.Weight += uint64(multisigData.GetWeight(address))
+		}
+		res.Proposals = append(res.Proposals, item)
+	}
+
+	return res, nil
+}
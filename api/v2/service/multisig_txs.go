@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/multisigpool"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SubmitMultisigTxRequest is a request to collect signatures for the multisig transaction.
+// Tx is the transaction of the multisig signature type without signatures.
+type SubmitMultisigTxRequest struct {
+	Tx string `json:"tx"`
+}
+
+// AddMultisigSignatureRequest is a request to add the signature of the member to the multisig transaction.
+// Signature is the RLP encoded signature of the Hash of the transaction.
+type AddMultisigSignatureRequest struct {
+	Hash      string `json:"hash"`
+	Signature string `json:"signature"`
+}
+
+// AddMultisigSignatureResponse is the transaction with the added signature.
+// The transaction is sent once the weight of signatures reaches the threshold, Code and Log are the result of its check.
+type AddMultisigSignatureResponse struct {
+	*MultisigTx
+	Sent   bool   `json:"sent"`
+	TxHash string `json:"tx_hash,omitempty"`
+	Code   uint32 `json:"code"`
+	Log    string `json:"log,omitempty"`
+}
+
+// MultisigTxsRequest is a request of transactions of the multisig collecting signatures.
+type MultisigTxsRequest struct {
+	Multisig string `json:"multisig"`
+}
+
+// MultisigTxsResponse contains transactions of the multisig collecting signatures.
+type MultisigTxsResponse struct {
+	Txs []*MultisigTx `json:"txs"`
+}
+
+// MultisigTx is the transaction collecting signatures until ExpireHeight, members sign its Hash.
+// Weight is the total weight of signatures by current weights of members.
+type MultisigTx struct {
+	Hash         string                 `json:"hash"`
+	Multisig     string                 `json:"multisig"`
+	Tx           string                 `json:"tx"`
+	ExpireHeight uint64                 `json:"expire_height"`
+	Signatures   []*MultisigTxSignature `json:"signatures"`
+	Weight       uint64                 `json:"weight"`
+	Threshold    uint64                 `json:"threshold"`
+}
+
+// MultisigTxSignature is the signature of the member.
+type MultisigTxSignature struct {
+	Signer string `json:"signer"`
+	Weight uint64 `json:"weight"`
+}
+
+// SubmitMultisigTx stores the multisig transaction to collect signatures of members for it.
+// The transaction collects signatures until its ValidUntil block but not longer than multisigpool.TxLifetime blocks.
+func (s *Service) SubmitMultisigTx(ctx context.Context, req *SubmitMultisigTxRequest) (*MultisigTx, error) {
+	pool := s.blockchain.MultisigPool()
+	if pool == nil {
+		return nil, status.Error(codes.Unavailable, "collecting of multisig signatures is disabled")
+	}
+
+	if !strings.HasPrefix(strings.Title(req.Tx), "0x") {
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction")
+	}
+	rawTx, err := hex.DecodeString(req.Tx[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tx, err := s.decoderTx.DecodeFromBytes(rawTx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if tx.SignatureType != transaction.SigTypeMulti {
+		return nil, status.Error(codes.InvalidArgument, "transaction should be of the multisig signature type")
+	}
+	signature := &transaction.SignatureMulti{}
+	if err := rlp.DecodeBytes(tx.SignatureData, signature); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(signature.Signatures) != 0 {
+		return nil, status.Error(codes.InvalidArgument, "transaction should not be signed")
+	}
+
+	cState := s.blockchain.CurrentState()
+	if !cState.Accounts().GetAccount(signature.Multisig).IsMultisig() {
+		return nil, s.createError(status.New(codes.InvalidArgument, "Multisig does not exists"), transaction.EncodeError(code.NewMultisigNotExists(signature.Multisig.String())))
+	}
+	if nonce := cState.Accounts().GetNonce(signature.Multisig); tx.Nonce <= nonce {
+		return nil, s.createError(status.New(codes.InvalidArgument, fmt.Sprintf("Unexpected nonce. Expected greater than %d, got %d.", nonce, tx.Nonce)),
+			transaction.EncodeError(code.NewWrongNonce(fmt.Sprintf("%d", nonce+1), fmt.Sprintf("%d", tx.Nonce))))
+	}
+
+	// the transaction collects signatures until it can be sent
+	height := s.blockchain.Height()
+	expireHeight := height + multisigpool.TxLifetime
+	if tx.ValidUntil != 0 && tx.ValidUntil < expireHeight {
+		expireHeight = tx.ValidUntil
+	}
+	if expireHeight <= height {
+		return nil, status.Error(codes.InvalidArgument, "transaction is expired")
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	multisigTx := &multisigpool.Tx{Hash: tx.Hash(), Multisig: signature.Multisig, Nonce: tx.Nonce, ExpireHeight: expireHeight, Tx: rawTx}
+	if err := pool.Add(multisigTx); err != nil {
+		switch err {
+		case multisigpool.ErrTxExists:
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case multisigpool.ErrTooManyTxs:
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return multisigTxResponse(cState, multisigTx), nil
+}
+
+// AddMultisigSignature adds the signature of the member to the multisig transaction,
+// the transaction is sent with collected signatures once their weight reaches the threshold of the multisig.
+func (s *Service) AddMultisigSignature(ctx context.Context, req *AddMultisigSignatureRequest) (*AddMultisigSignatureResponse, error) {
+	pool := s.blockchain.MultisigPool()
+	if pool == nil {
+		return nil, status.Error(codes.Unavailable, "collecting of multisig signatures is disabled")
+	}
+
+	hashBytes, err := hex.DecodeString(strings.TrimPrefix(req.Hash, "0x"))
+	if err != nil || len(hashBytes) != types.HashLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid hash")
+	}
+	hash := types.BytesToHash(hashBytes)
+
+	signatureBytes, err := hex.DecodeString(strings.TrimPrefix(req.Signature, "0x"))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	signature := &transaction.Signature{}
+	if err := rlp.DecodeBytes(signatureBytes, signature); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	signer, err := transaction.RecoverPlain(hash, signature.R, signature.S, signature.V)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	multisigTx, err := pool.Get(hash)
+	if err != nil {
+		if err == multisigpool.ErrTxNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	cState := s.blockchain.CurrentState()
+	multisig := cState.Accounts().GetAccount(multisigTx.Multisig).Multisig()
+	if multisig.GetWeight(signer) == 0 {
+		return nil, s.createError(status.New(codes.InvalidArgument, "Signer is not a member of the multisig"), transaction.EncodeError(code.NewIsNotMultisigMember(multisigTx.Multisig.String(), signer.String())))
+	}
+
+	multisigTx, err = pool.AddSignature(hash, &multisigpool.Signature{Signer: signer, Data: signatureBytes})
+	if err != nil {
+		if err == multisigpool.ErrSignatureExists {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &AddMultisigSignatureResponse{MultisigTx: multisigTxResponse(cState, multisigTx)}
+	if res.Weight < res.Threshold {
+		return res, nil
+	}
+
+	rawTx, err := assembleMultisigTx(s.decoderTx, multisigTx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	result, statusErr := s.broadcastTxSync(ctx, rawTx)
+	if statusErr != nil {
+		return nil, statusErr.Err()
+	}
+
+	res.Code = result.Code
+	res.Log = result.Log
+	if result.Code != code.OK {
+		return res, nil
+	}
+
+	if err := pool.Remove(hash); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res.Sent = true
+	res.TxHash = "Mt" + strings.ToLower(fmt.Sprintf("%x", result.Hash))
+
+	return res, nil
+}
+
+// MultisigTxs returns transactions of the multisig collecting signatures.
+func (s *Service) MultisigTxs(ctx context.Context, req *MultisigTxsRequest) (*MultisigTxsResponse, error) {
+	pool := s.blockchain.MultisigPool()
+	if pool == nil {
+		return nil, status.Error(codes.Unavailable, "collecting of multisig signatures is disabled")
+	}
+
+	multisig, err := decodeAddress(req.Multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := pool.Txs(multisig)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	cState := s.blockchain.CurrentState()
+	res := &MultisigTxsResponse{Txs: make([]*MultisigTx, 0, len(txs))}
+	for _, tx := range txs {
+		res.Txs = append(res.Txs, multisigTxResponse(cState, tx))
+	}
+
+	return res, nil
+}
+
+// assembleMultisigTx returns the encoded transaction with collected signatures of members
+func assembleMultisigTx(decoder transaction.DecoderTx, multisigTx *multisigpool.Tx) ([]byte, error) {
+	tx, err := decoder.DecodeFromBytesWithoutSig(multisigTx.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signatureMulti := &transaction.SignatureMulti{Multisig: multisigTx.Multisig}
+	for _, s := range multisigTx.Signatures {
+		signature := transaction.Signature{}
+		if err := rlp.DecodeBytes(s.Data, &signature); err != nil {
+			return nil, err
+		}
+		signatureMulti.Signatures = append(signatureMulti.Signatures, signature)
+	}
+
+	tx.SignatureData, err = rlp.EncodeToBytes(signatureMulti)
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(tx)
+}
+
+func multisigTxResponse(cState *state.CheckState, tx *multisigpool.Tx) *MultisigTx {
+	res := &MultisigTx{
+		Hash:         tx.Hash.String(),
+		Multisig:     tx.Multisig.String(),
+		Tx:           "0x" + hex.EncodeToString(tx.Tx),
+		ExpireHeight: tx.ExpireHeight,
+		Signatures:   make([]*MultisigTxSignature, 0, len(tx.Signatures)),
+	}
+
+	multisig := cState.Accounts().GetAccount(tx.Multisig).Multisig()
+	res.Threshold = uint64(multisig.Threshold)
+	for _, s := range tx.Signatures {
+		weight := uint64(multisig.GetWeight(s.Signer))
+		res.Weight += weight
+		res.Signatures = append(res.Signatures, &MultisigTxSignature{Signer: s.Signer.String(), Weight: weight})
+	}
+
+	return res
+}
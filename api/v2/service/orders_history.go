@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Statuses of limit orders in OrdersHistory
+const (
+	orderStatusOpen            = "open"
+	orderStatusPartiallyFilled = "partially_filled"
+	orderStatusFilled          = "filled"
+	orderStatusCanceled        = "canceled"
+	orderStatusExpired         = "expired"
+)
+
+// OrdersHistoryRequest is a request of OrdersHistory.
+// Status filters orders by one of open, partially_filled, filled, canceled and expired, empty Status matches any order.
+type OrdersHistoryRequest struct {
+	Address string `json:"address"`
+	Status  string `json:"status"`
+}
+
+// OrdersHistoryResponse contains limit orders of the address ordered by id.
+type OrdersHistoryResponse struct {
+	Orders []*OrderHistory `json:"orders"`
+}
+
+// OrderHistory is the lifecycle of the limit order.
+// Sold and Bought are totals of Fills, Returned is the amount returned to the owner on cancellation or expiration.
+// Fields of the creation are empty for orders created before the history was recorded.
+type OrderHistory struct {
+	ID            uint64              `json:"id"`
+	Status        string              `json:"status"`
+	CoinSell      uint64              `json:"coin_sell"`
+	ValueSell     string              `json:"value_sell,omitempty"`
+	CoinBuy       uint64              `json:"coin_buy"`
+	ValueBuy      string              `json:"value_buy,omitempty"`
+	CreatedHeight uint64              `json:"created_height,omitempty"`
+	CreatedTxHash string              `json:"created_tx_hash,omitempty"`
+	ClosedHeight  uint64              `json:"closed_height,omitempty"`
+	ClosedTxHash  string              `json:"closed_tx_hash,omitempty"`
+	Sold          string              `json:"sold"`
+	Bought        string              `json:"bought"`
+	Returned      string              `json:"returned,omitempty"`
+	Fills         []*OrderHistoryFill `json:"fills"`
+}
+
+// OrderHistoryFill is a part of the order filled by the transaction.
+type OrderHistoryFill struct {
+	Height uint64 `json:"height"`
+	TxHash string `json:"tx_hash"`
+	Sold   string `json:"sold"`
+	Bought string `json:"bought"`
+}
+
+// OrdersHistory returns created, filled, canceled and expired limit orders of the address with their fills.
+func (s *Service) OrdersHistory(ctx context.Context, req *OrdersHistoryRequest) (*OrdersHistoryResponse, error) {
+	index, ok := s.blockchain.GetEventsDB().(eventsdb.OrdersIndex)
+	if !ok {
+		return nil, status.Error(codes.Unavailable, "orders history is not supported by the events store")
+	}
+
+	if !strings.HasPrefix(strings.Title(req.Address), "Mx") || len(req.Address) != 42 {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+	address := types.HexToAddress(req.Address)
+
+	switch req.Status {
+	case "", orderStatusOpen, orderStatusPartiallyFilled, orderStatusFilled, orderStatusCanceled, orderStatusExpired:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "unknown status")
+	}
+
+	orderHeights, err := index.OrderHeights(address)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ids := make([]uint32, 0, len(orderHeights))
+	for id := range orderHeights {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	loaded := map[uint32]eventsdb.Events{}
+	response := &OrdersHistoryResponse{Orders: make([]*OrderHistory, 0, len(ids))}
+	for _, id := range ids {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		order := &OrderHistory{ID: uint64(id), Status: orderStatusOpen, Fills: []*OrderHistoryFill{}}
+		sold, bought := big.NewInt(0), big.NewInt(0)
+		for _, height := range orderHeights[id] {
+			events, ok := loaded[height]
+			if !ok {
+				events = s.blockchain.GetEventsDB().LoadEvents(height)
+				loaded[height] = events
+			}
+			for _, event := range events {
+				applyOrderEvent(order, event, address, id, uint64(height), sold, bought)
+			}
+		}
+		order.Sold, order.Bought = sold.String(), bought.String()
+
+		if req.Status != "" && req.Status != order.Status {
+			continue
+		}
+		response.Orders = append(response.Orders, order)
+	}
+
+	return response, nil
+}
+
+func applyOrderEvent(order *OrderHistory, event eventsdb.Event, address types.Address, id uint32, height uint64, sold, bought *big.Int) {
+	switch e := event.(type) {
+	case *eventsdb.OrderCreatedEvent:
+		if e.Address != address || uint32(e.ID) != id {
+			return
+		}
+		order.CoinSell, order.ValueSell, order.CoinBuy, order.ValueBuy = e.CoinSell, e.ValueSell, e.CoinBuy, e.ValueBuy
+		order.CreatedHeight, order.CreatedTxHash = height, e.TxHash
+	case *eventsdb.OrderPartiallyFilledEvent:
+		if e.Address != address || uint32(e.ID) != id {
+			return
+		}
+		order.Status = orderStatusPartiallyFilled
+		order.CoinSell, order.CoinBuy = e.CoinSell, e.CoinBuy
+		order.Fills = append(order.Fills, &OrderHistoryFill{Height: height, TxHash: e.TxHash, Sold: e.Sold, Bought: e.Bought})
+		addStringAmount(sold, e.Sold)
+		addStringAmount(bought, e.Bought)
+	case *eventsdb.OrderFilledEvent:
+		if e.Address != address || uint32(e.ID) != id {
+			return
+		}
+		order.Status = orderStatusFilled
+		order.CoinSell, order.CoinBuy = e.CoinSell, e.CoinBuy
+		order.ClosedHeight, order.ClosedTxHash = height, e.TxHash
+		order.Fills = append(order.Fills, &OrderHistoryFill{Height: height, TxHash: e.TxHash, Sold: e.Sold, Bought: e.Bought})
+		addStringAmount(sold, e.Sold)
+		addStringAmount(bought, e.Bought)
+	case *eventsdb.OrderCanceledEvent:
+		if e.Address != address || uint32(e.ID) != id {
+			return
+		}
+		order.Status = orderStatusCanceled
+		order.ClosedHeight, order.ClosedTxHash, order.Returned = height, e.TxHash, e.Amount
+	case *eventsdb.OrderExpiredEvent:
+		if e.Address != address || uint32(e.ID) != id {
+			return
+		}
+		order.Status = orderStatusExpired
+		order.ClosedHeight, order.Returned = height, e.Amount
+	}
+}
+
+func addStringAmount(sum *big.Int, amount string) {
+	if value, ok := new(big.Int).SetString(amount, 10); ok {
+		sum.Add(sum, value)
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NetworkParamsRequest is a request of network parameters changed by validators.
+type NetworkParamsRequest struct {
+	Height uint64 `json:"height"`
+}
+
+// NetworkParamsResponse contains values of network parameters changed by validators, other parameters have default values.
+type NetworkParamsResponse struct {
+	Params []*NetworkParam `json:"params"`
+}
+
+// NetworkParam is the value of the network parameter.
+type NetworkParam struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ParamsProposalsRequest is a request of proposals of network parameters voted after the height.
+type ParamsProposalsRequest struct {
+	Height uint64 `json:"height"`
+}
+
+// ParamsProposalsResponse contains proposals of network parameters not voted yet.
+// TotalStake is the stake of validators, the value is applied when validators with more than 2/3 of it vote for the proposal.
+type ParamsProposalsResponse struct {
+	TotalStake string            `json:"total_stake"`
+	Proposals  []*ParamsProposal `json:"proposals"`
+}
+
+// ParamsProposal is the proposed value of the network parameter voted at the Height.
+// VotedStake is the stake of validators voted for it by current stakes.
+type ParamsProposal struct {
+	Height     uint64   `json:"height"`
+	Name       string   `json:"name"`
+	Value      string   `json:"value"`
+	Votes      []string `json:"votes"`
+	VotedStake string   `json:"voted_stake"`
+}
+
+// NetworkParams returns values of network parameters changed by validators.
+func (s *Service) NetworkParams(ctx context.Context, req *NetworkParamsRequest) (*NetworkParamsResponse, error) {
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	res := &NetworkParamsResponse{Params: []*NetworkParam{}}
+	for _, name := range params.Names {
+		if value := cState.Params().GetValue(name); value != nil {
+			res.Params = append(res.Params, &NetworkParam{Name: name, Value: value.String()})
+		}
+	}
+
+	return res, nil
+}
+
+// ParamsProposals returns proposals of network parameters which are not voted yet.
+func (s *Service) ParamsProposals(ctx context.Context, req *ParamsProposalsRequest) (*ParamsProposalsResponse, error) {
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	if req.Height != 0 {
+		cState.Validators().LoadValidators()
+	}
+
+	totalStake := big.NewInt(0)
+	stakes := map[string]*big.Int{}
+	for _, validator := range cState.Validators().GetValidators() {
+		if validator.IsToDrop() {
+			continue
+		}
+		stakes[validator.PubKey.String()] = validator.GetTotalBipStake()
+		totalStake.Add(totalStake, validator.GetTotalBipStake())
+	}
+
+	res := &ParamsProposalsResponse{TotalStake: totalStake.String(), Proposals: []*ParamsProposal{}}
+	for _, proposal := range cState.Params().GetActiveProposals(height) {
+		votedStake := big.NewInt(0)
+		votes := make([]string, 0, len(proposal.Votes))
+		for _, vote := range proposal.Votes {
+			votes = append(votes, vote.String())
+			if stake, ok := stakes[vote.String()]; ok {
+				votedStake.Add(votedStake, stake)
+			}
+		}
+		res.Proposals = append(res.Proposals, &ParamsProposal{
+			Height:     proposal.Height(),
+			Name:       proposal.Name,
+			Value:      proposal.Value,
+			Votes:      votes,
+			VotedStake: votedStake.String(),
+		})
+	}
+
+	return res, nil
+}
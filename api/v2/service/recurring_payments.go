@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecurringPaymentsRequest is a request of standing orders of the payer.
+type RecurringPaymentsRequest struct {
+	Payer  string `json:"payer"`
+	Height uint64 `json:"height"`
+}
+
+// RecurringPaymentsResponse contains not finished standing orders of the payer.
+type RecurringPaymentsResponse struct {
+	Payments []*RecurringPayment `json:"payments"`
+}
+
+// RecurringPayment is the standing order, the next of Remaining payments is made at the end of NextHeight block.
+type RecurringPayment struct {
+	ID         uint64 `json:"id"`
+	Recipient  string `json:"recipient"`
+	Coin       uint64 `json:"coin"`
+	CoinSymbol string `json:"coin_symbol"`
+	Value      string `json:"value"`
+	Period     uint64 `json:"period"`
+	Remaining  uint64 `json:"remaining"`
+	NextHeight uint64 `json:"next_height"`
+}
+
+// RecurringPayments returns standing orders of the payer.
+func (s *Service) RecurringPayments(ctx context.Context, req *RecurringPaymentsRequest) (*RecurringPaymentsResponse, error) {
+	payer, err := decodeAddress(req.Payer)
+	if err != nil {
+		return nil, err
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	res := &RecurringPaymentsResponse{Payments: []*RecurringPayment{}}
+	for _, payment := range cState.Recurring().GetPaymentsByPayer(payer) {
+		res.Payments = append(res.Payments, &RecurringPayment{
+			ID:         payment.ID,
+			Recipient:  payment.Recipient.String(),
+			Coin:       uint64(payment.Coin),
+			CoinSymbol: cState.Coins().GetCoin(payment.Coin).GetFullSymbol(),
+			Value:      payment.Value.String(),
+			Period:     payment.Period,
+			Remaining:  payment.Remaining,
+			NextHeight: payment.NextHeight,
+		})
+	}
+
+	return res, nil
+}
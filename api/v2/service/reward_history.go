@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/rewardhistory"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	rewardHistoryGroupDay    = "day"
+	rewardHistoryGroupPeriod = "period"
+
+	rewardHistoryMaxPeriods = 10000
+	secondsPerYear          = 365 * 24 * 60 * 60
+)
+
+// RewardHistoryRequest is a request of RewardHistory, either Address or PublicKey should be set.
+// Group is "day" (default) or "period" for every payment of rewards, zero ToHeight is the current height.
+type RewardHistoryRequest struct {
+	Address    string `json:"address"`
+	PublicKey  string `json:"public_key"`
+	FromHeight uint64 `json:"from_height"`
+	ToHeight   uint64 `json:"to_height"`
+	Group      string `json:"group"`
+}
+
+// RewardHistoryResponse contains rewards by roles grouped by days or payments, their totals and the realized APR over the range.
+type RewardHistoryResponse struct {
+	Periods []*RewardHistoryPeriod `json:"periods"`
+	Total   map[string]string      `json:"total"`
+	Apr     string                 `json:"apr"`
+}
+
+// RewardHistoryPeriod contains rewards by roles paid in the heights range.
+// Stake is the average BIP value of stakes of the address or the candidate at payments,
+// Apr is the realized annual percentage rate of delegator rewards to the stake, empty if the time of the previous payment is unknown.
+type RewardHistoryPeriod struct {
+	Time       string            `json:"time"`
+	FromHeight uint64            `json:"from_height"`
+	ToHeight   uint64            `json:"to_height"`
+	Rewards    map[string]string `json:"rewards"`
+	Stake      string            `json:"stake"`
+	Apr        string            `json:"apr"`
+}
+
+// rewardPayment is the payment of rewards to the address or for the candidate with the stake it was paid for
+type rewardPayment struct {
+	period  rewardhistory.Period
+	stake   *big.Int
+	rewards map[string]*big.Int
+}
+
+// rewardYield accumulates delegator rewards and stakes weighted by the time they were held
+type rewardYield struct {
+	rewards  *big.Int
+	weighted *big.Float
+}
+
+func newRewardYield() *rewardYield {
+	return &rewardYield{rewards: big.NewInt(0), weighted: new(big.Float).SetPrec(128)}
+}
+
+func (y *rewardYield) add(payment *rewardPayment) {
+	if payment.period.Interval <= 0 || payment.stake.Sign() == 0 {
+		return
+	}
+	if reward, ok := payment.rewards[eventsdb.RoleDelegator.String()]; ok {
+		y.rewards.Add(y.rewards, reward)
+	}
+	weighted := new(big.Float).SetPrec(128).SetInt(payment.stake)
+	weighted.Mul(weighted, big.NewFloat(payment.period.Interval.Seconds()))
+	y.weighted.Add(y.weighted, weighted)
+}
+
+// apr returns the percentage with two decimals, empty if the stake was not held
+func (y *rewardYield) apr() string {
+	if y.weighted.Sign() == 0 {
+		return ""
+	}
+	apr := new(big.Float).SetPrec(128).SetInt(y.rewards)
+	apr.Quo(apr, y.weighted)
+	apr.Mul(apr, big.NewFloat(secondsPerYear*100))
+	return apr.Text('f', 2)
+}
+
+// RewardHistory returns rewards of the delegator address or the candidate aggregated by days or payments and roles,
+// with the realized APR against stakes at every payment of rewards.
+func (s *Service) RewardHistory(ctx context.Context, req *RewardHistoryRequest) (*RewardHistoryResponse, error) {
+	history := s.blockchain.RewardHistory()
+	if history == nil {
+		return nil, status.Error(codes.Unavailable, "reward history is disabled")
+	}
+
+	switch req.Group {
+	case "":
+		req.Group = rewardHistoryGroupDay
+	case rewardHistoryGroupDay, rewardHistoryGroupPeriod:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "group should be day or period")
+	}
+
+	toHeight := req.ToHeight
+	if toHeight == 0 || toHeight > s.blockchain.Height() {
+		toHeight = s.blockchain.Height()
+	}
+	if req.FromHeight > toHeight {
+		return nil, status.Error(codes.InvalidArgument, "from_height should not be greater than to_height")
+	}
+
+	var payments []*rewardPayment
+	switch {
+	case req.Address != "" && req.PublicKey != "":
+		return nil, status.Error(codes.InvalidArgument, "either address or public_key should be set")
+	case req.Address != "":
+		if !strings.HasPrefix(strings.Title(req.Address), "Mx") || len(req.Address) != 42 {
+			return nil, status.Error(codes.InvalidArgument, "invalid address")
+		}
+		address := types.HexToAddress(req.Address)
+		records, err := history.AddressRecords(address, req.FromHeight, toHeight)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if len(records) > rewardHistoryMaxPeriods {
+			return nil, status.Errorf(codes.InvalidArgument, "range contains more than %d payments of rewards", rewardHistoryMaxPeriods)
+		}
+		for _, record := range records {
+			if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+				return nil, timeoutStatus.Err()
+			}
+			payment := &rewardPayment{period: record.Period, stake: big.NewInt(0), rewards: map[string]*big.Int{}}
+			for _, stake := range record.Stakes {
+				payment.stake.Add(payment.stake, stake.BipValue)
+			}
+			for _, event := range s.blockchain.GetEventsDB().LoadEvents(uint32(record.Height)) {
+				if reward, ok := event.(*eventsdb.RewardEvent); ok && reward.Address == address {
+					addReward(payment.rewards, reward)
+				}
+			}
+			payments = append(payments, payment)
+		}
+	case req.PublicKey != "":
+		if !strings.HasPrefix(req.PublicKey, "Mp") || len(req.PublicKey) != 66 {
+			return nil, status.Error(codes.InvalidArgument, "invalid public_key")
+		}
+		pubKey := types.HexToPubkey(req.PublicKey)
+		records, err := history.CandidateRecords(pubKey, req.FromHeight, toHeight)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if len(records) > rewardHistoryMaxPeriods {
+			return nil, status.Errorf(codes.InvalidArgument, "range contains more than %d payments of rewards", rewardHistoryMaxPeriods)
+		}
+		for _, record := range records {
+			if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+				return nil, timeoutStatus.Err()
+			}
+			payment := &rewardPayment{period: record.Period, stake: record.BipStake, rewards: map[string]*big.Int{}}
+			for _, event := range s.blockchain.GetEventsDB().LoadEvents(uint32(record.Height)) {
+				if reward, ok := event.(*eventsdb.RewardEvent); ok && reward.ValidatorPubKey == pubKey {
+					addReward(payment.rewards, reward)
+				}
+			}
+			payments = append(payments, payment)
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "either address or public_key should be set")
+	}
+
+	response := &RewardHistoryResponse{Periods: []*RewardHistoryPeriod{}, Total: map[string]string{}}
+	total := map[string]*big.Int{}
+	totalYield := newRewardYield()
+	for i := 0; i < len(payments); {
+		start := payments[i].period.Time.UTC()
+		if req.Group == rewardHistoryGroupDay {
+			start = start.Truncate(24 * time.Hour)
+		}
+
+		rewards := map[string]*big.Int{}
+		stakes := big.NewInt(0)
+		yield := newRewardYield()
+		j := i
+		for ; j < len(payments); j++ {
+			payment := payments[j]
+			if j != i && (req.Group == rewardHistoryGroupPeriod || !payment.period.Time.UTC().Truncate(24*time.Hour).Equal(start)) {
+				break
+			}
+			for role, amount := range payment.rewards {
+				addAmount(rewards, role, amount)
+				addAmount(total, role, amount)
+			}
+			stakes.Add(stakes, payment.stake)
+			yield.add(payment)
+			totalYield.add(payment)
+		}
+
+		period := &RewardHistoryPeriod{
+			Time:       start.Format(time.RFC3339),
+			FromHeight: payments[i].period.Height,
+			ToHeight:   payments[j-1].period.Height,
+			Rewards:    map[string]string{},
+			Stake:      stakes.Div(stakes, big.NewInt(int64(j-i))).String(),
+			Apr:        yield.apr(),
+		}
+		for role, amount := range rewards {
+			period.Rewards[role] = amount.String()
+		}
+		response.Periods = append(response.Periods, period)
+		i = j
+	}
+
+	for role, amount := range total {
+		response.Total[role] = amount.String()
+	}
+	response.Apr = totalYield.apr()
+
+	return response, nil
+}
+
+func addReward(rewards map[string]*big.Int, reward *eventsdb.RewardEvent) {
+	if amount, ok := new(big.Int).SetString(reward.Amount, 10); ok {
+		addAmount(rewards, reward.Role, amount)
+	}
+}
+
+func addAmount(sums map[string]*big.Int, key string, amount *big.Int) {
+	if sums[key] == nil {
+		sums[key] = big.NewInt(0)
+	}
+	sums[key].Add(sums[key], amount)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SimulateTransactionRequest is a request of SimulateTransaction.
+// From is the sender of the unsigned transaction, it must be empty for the signed one.
+type SimulateTransactionRequest struct {
+	Tx   string `json:"tx"`
+	From string `json:"from"`
+}
+
+// SimulateTransactionResponse is a result of the simulated transaction.
+type SimulateTransactionResponse struct {
+	Height         uint64                  `json:"height"`
+	Code           uint32                  `json:"code"`
+	Log            string                  `json:"log"`
+	Info           json.RawMessage         `json:"info,omitempty"`
+	Tags           map[string]string       `json:"tags"`
+	GasWanted      int64                   `json:"gas_wanted"`
+	GasUsed        int64                   `json:"gas_used"`
+	GasPrice       uint32                  `json:"gas_price"`
+	CommissionCoin *SimulatedCoin          `json:"commission_coin"`
+	Commission     string                  `json:"commission"`
+	Balances       []*SimulatedBalanceDiff `json:"balances"`
+	Stakes         []*SimulatedStakeDiff   `json:"stakes"`
+	Pools          []*SimulatedPoolDiff    `json:"pools"`
+	OrderFills     []*SimulatedOrderFill   `json:"order_fills"`
+	Events         []json.RawMessage       `json:"events"`
+}
+
+// SimulatedCoin is a coin of SimulateTransactionResponse.
+type SimulatedCoin struct {
+	ID     uint64 `json:"id"`
+	Symbol string `json:"symbol"`
+}
+
+// SimulatedBalanceDiff is a change of the address balance.
+type SimulatedBalanceDiff struct {
+	Address string         `json:"address"`
+	Coin    *SimulatedCoin `json:"coin"`
+	Before  string         `json:"before"`
+	After   string         `json:"after"`
+}
+
+// SimulatedStakeDiff is a change of the delegator stake, pending stakes included.
+type SimulatedStakeDiff struct {
+	PublicKey string         `json:"public_key"`
+	Owner     string         `json:"owner"`
+	Coin      *SimulatedCoin `json:"coin"`
+	Before    string         `json:"before"`
+	After     string         `json:"after"`
+}
+
+// SimulatedPoolDiff is a change of the pool reserves.
+type SimulatedPoolDiff struct {
+	PoolID         uint64         `json:"pool_id"`
+	Coin0          *SimulatedCoin `json:"coin0"`
+	Coin1          *SimulatedCoin `json:"coin1"`
+	Reserve0Before string         `json:"reserve0_before"`
+	Reserve1Before string         `json:"reserve1_before"`
+	Reserve0After  string         `json:"reserve0_after"`
+	Reserve1After  string         `json:"reserve1_after"`
+}
+
+// SimulatedOrderFill is a filled part of the limit order.
+type SimulatedOrderFill struct {
+	PoolID  uint64 `json:"pool_id"`
+	OrderID uint64 `json:"order_id"`
+	Seller  string `json:"seller"`
+	Buy     string `json:"buy"`
+	Sell    string `json:"sell"`
+}
+
+// SimulateTransaction executes the signed or unsigned transaction with the deliver-path code against a throwaway
+// copy of the current state and returns the result with balance, stake, pool reserve diffs and order fills.
+// Nothing is broadcast and the state is not changed.
+func (s *Service) SimulateTransaction(ctx context.Context, req *SimulateTransactionRequest) (*SimulateTransactionResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Tx), "0x") {
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction")
+	}
+
+	rawTx, err := hex.DecodeString(req.Tx[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var sender *types.Address
+	if req.From != "" {
+		if !strings.HasPrefix(strings.Title(req.From), "Mx") || len(req.From) != 42 {
+			return nil, status.Error(codes.InvalidArgument, "invalid from address")
+		}
+		address := types.HexToAddress(req.From)
+		sender = &address
+	}
+
+	result, err := s.blockchain.SimulateTx(rawTx, sender)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	cState := s.blockchain.CurrentState()
+	coinsState := cState.Coins()
+
+	tags := make(map[string]string, len(result.Response.Tags))
+	for _, tag := range result.Response.Tags {
+		tags[string(tag.Key)] = string(tag.Value)
+	}
+
+	response := &SimulateTransactionResponse{
+		Height:         result.Height,
+		Code:           result.Response.Code,
+		Log:            result.Response.Log,
+		Tags:           tags,
+		GasWanted:      result.Response.GasWanted,
+		GasUsed:        result.Response.GasUsed,
+		GasPrice:       result.Response.GasPrice,
+		CommissionCoin: simulatedCoin(coinsState, result.CommissionCoin),
+		Commission:     result.Commission.String(),
+		Balances:       make([]*SimulatedBalanceDiff, 0, len(result.Balances)),
+		Stakes:         make([]*SimulatedStakeDiff, 0, len(result.Stakes)),
+		Pools:          make([]*SimulatedPoolDiff, 0, len(result.Pools)),
+		OrderFills:     make([]*SimulatedOrderFill, 0, len(result.OrderFills)),
+		Events:         make([]json.RawMessage, 0, len(result.Events)),
+	}
+	if json.Valid([]byte(result.Response.Info)) {
+		response.Info = json.RawMessage(result.Response.Info)
+	}
+
+	for _, diff := range result.Balances {
+		response.Balances = append(response.Balances, &SimulatedBalanceDiff{
+			Address: diff.Address.String(),
+			Coin:    simulatedCoin(coinsState, diff.Coin),
+			Before:  diff.Before.String(),
+			After:   diff.After.String(),
+		})
+	}
+
+	for _, diff := range result.Stakes {
+		response.Stakes = append(response.Stakes, &SimulatedStakeDiff{
+			PublicKey: diff.PubKey.String(),
+			Owner:     diff.Owner.String(),
+			Coin:      simulatedCoin(coinsState, diff.Coin),
+			Before:    diff.Before.String(),
+			After:     diff.After.String(),
+		})
+	}
+
+	for _, diff := range result.Pools {
+		response.Pools = append(response.Pools, &SimulatedPoolDiff{
+			PoolID:         uint64(diff.PoolID),
+			Coin0:          simulatedCoin(coinsState, diff.Coin0),
+			Coin1:          simulatedCoin(coinsState, diff.Coin1),
+			Reserve0Before: diff.Reserve0Before.String(),
+			Reserve1Before: diff.Reserve1Before.String(),
+			Reserve0After:  diff.Reserve0After.String(),
+			Reserve1After:  diff.Reserve1After.String(),
+		})
+	}
+
+	for _, fill := range result.OrderFills {
+		response.OrderFills = append(response.OrderFills, &SimulatedOrderFill{
+			PoolID:  uint64(fill.PoolID),
+			OrderID: uint64(fill.OrderID),
+			Seller:  fill.Seller.String(),
+			Buy:     fill.Buy.String(),
+			Sell:    fill.Sell.String(),
+		})
+	}
+
+	for _, event := range result.Events {
+		marshalJSON, err := tmjson.Marshal(event)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		response.Events = append(response.Events, marshalJSON)
+	}
+
+	return response, nil
+}
+
+func simulatedCoin(coinsState coins.RCoins, id types.CoinID) *SimulatedCoin {
+	coin := &SimulatedCoin{ID: uint64(id)}
+	if model := coinsState.GetCoin(id); model != nil {
+		coin.Symbol = model.GetFullSymbol()
+	}
+	return coin
+}
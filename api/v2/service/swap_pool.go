@@ -240,7 +240,7 @@ func (s *Service) SwapPoolProvider(ctx context.Context, req *pb.SwapPoolProvider
 	}
 
 	liquidityCoin := cState.Coins().GetCoinBySymbol(transaction.LiquidityCoinSymbol(liquidityID), 0)
-	balance := cState.Accounts().GetBalance(address, liquidityCoin.ID())
+	balance := cState.AccountsWithLocked().GetBalance(address, liquidityCoin.ID())
 
 	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
 		return nil, timeoutStatus.Err()
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	swapPoolDepthDefaultLevels = 20
+	swapPoolDepthMaxLevels     = 200
+	swapPoolDepthOrdersLimit   = 1000
+)
+
+// SwapPoolDepthRequest is a request of SwapPoolDepth.
+// Tick is the price step of levels as a decimal or a fraction, by default 0.1% of the pool price.
+type SwapPoolDepthRequest struct {
+	Coin0  uint64 `json:"coin0"`
+	Coin1  uint64 `json:"coin1"`
+	Tick   string `json:"tick"`
+	Levels int    `json:"levels"`
+	Height uint64 `json:"height"`
+}
+
+// SwapPoolDepthResponse is the order book of the pool, prices are amounts of Coin1 per unit of Coin0.
+// Bids buy Coin0 and asks sell it, both sides start from the pool price.
+type SwapPoolDepthResponse struct {
+	PoolID uint64                `json:"pool_id"`
+	Coin0  uint64                `json:"coin0"`
+	Coin1  uint64                `json:"coin1"`
+	Price  string                `json:"price"`
+	Tick   string                `json:"tick"`
+	Bids   []*SwapPoolDepthLevel `json:"bids"`
+	Asks   []*SwapPoolDepthLevel `json:"asks"`
+}
+
+// SwapPoolDepthLevel is the aggregated price level.
+// CumulativeVolume0 sums limit orders from the pool price, PoolVolume0 is the amount of Coin0 exchanged by the pool
+// to move its price to the level and TotalVolume0 is their sum.
+type SwapPoolDepthLevel struct {
+	Price             string `json:"price"`
+	Orders            uint64 `json:"orders"`
+	Volume0           string `json:"volume0"`
+	Volume1           string `json:"volume1"`
+	CumulativeVolume0 string `json:"cumulative_volume0"`
+	PoolVolume0       string `json:"pool_volume0"`
+	TotalVolume0      string `json:"total_volume0"`
+}
+
+// SwapPoolDepth returns limit orders of both sides of the pool aggregated into price levels combined with the pool liquidity.
+func (s *Service) SwapPoolDepth(ctx context.Context, req *SwapPoolDepthRequest) (*SwapPoolDepthResponse, error) {
+	if req.Coin0 == req.Coin1 {
+		return nil, status.Error(codes.InvalidArgument, "equal coins id")
+	}
+
+	levels := req.Levels
+	if levels <= 0 {
+		levels = swapPoolDepthDefaultLevels
+	}
+	if levels > swapPoolDepthMaxLevels {
+		return nil, status.Errorf(codes.InvalidArgument, "levels should not exceed %d", swapPoolDepthMaxLevels)
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	swapper := cState.Swap().GetSwapper(types.CoinID(req.Coin0), types.CoinID(req.Coin1))
+	if swapper.GetID() == 0 {
+		return nil, status.Error(codes.NotFound, "pair not found")
+	}
+
+	var tick *big.Rat
+	if req.Tick != "" {
+		var ok bool
+		tick, ok = new(big.Rat).SetString(req.Tick)
+		if !ok || tick.Sign() != 1 {
+			return nil, status.Error(codes.InvalidArgument, "invalid tick")
+		}
+	} else {
+		tick = new(big.Rat).Mul(swapper.PriceRat(), big.NewRat(1, 1000))
+		if tick.Sign() != 1 {
+			return nil, status.Error(codes.FailedPrecondition, "pool has no price")
+		}
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	depth := swap.CalculateDepth(swapper, tick, levels, swapPoolDepthOrdersLimit)
+
+	return &SwapPoolDepthResponse{
+		PoolID: uint64(swapper.GetID()),
+		Coin0:  req.Coin0,
+		Coin1:  req.Coin1,
+		Price:  depth.Price.FloatString(precision),
+		Tick:   tick.FloatString(precision),
+		Bids:   swapPoolDepthLevels(depth.Bids),
+		Asks:   swapPoolDepthLevels(depth.Asks),
+	}, nil
+}
+
+func swapPoolDepthLevels(levels []*swap.DepthLevel) []*SwapPoolDepthLevel {
+	result := make([]*SwapPoolDepthLevel, 0, len(levels))
+	for _, level := range levels {
+		result = append(result, &SwapPoolDepthLevel{
+			Price:             level.Price.FloatString(precision),
+			Orders:            uint64(level.Orders),
+			Volume0:           level.Volume0.String(),
+			Volume1:           level.Volume1.String(),
+			CumulativeVolume0: level.CumulativeVolume0.String(),
+			PoolVolume0:       level.PoolVolume0.String(),
+			TotalVolume0:      new(big.Int).Add(level.CumulativeVolume0, level.PoolVolume0).String(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/swaphistory"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	swapPoolMaxCandles   = 1000
+	swapPoolStatsPeriod  = 24 * time.Hour
+	swapPoolDefaultRange = 100
+)
+
+// SwapPoolCandlesRequest is a request of SwapPoolCandles.
+// Interval is a duration like "1m", "1h" or "24h", From and To are unix timestamps in seconds,
+// by default To is now and From is 100 intervals before it.
+type SwapPoolCandlesRequest struct {
+	Coin0    uint64 `json:"coin0"`
+	Coin1    uint64 `json:"coin1"`
+	Interval string `json:"interval"`
+	From     int64  `json:"from"`
+	To       int64  `json:"to"`
+}
+
+// SwapPoolCandlesResponse contains candles of the pool, prices are amounts of Coin1 per unit of Coin0.
+// Coin0 is the pool coin with the lower id.
+type SwapPoolCandlesResponse struct {
+	PoolID  uint64            `json:"pool_id"`
+	Coin0   uint64            `json:"coin0"`
+	Coin1   uint64            `json:"coin1"`
+	Candles []*SwapPoolCandle `json:"candles"`
+}
+
+// SwapPoolCandle is the OHLCV candle starting at Time.
+type SwapPoolCandle struct {
+	Time    int64  `json:"time"`
+	Open    string `json:"open"`
+	High    string `json:"high"`
+	Low     string `json:"low"`
+	Close   string `json:"close"`
+	Volume0 string `json:"volume0"`
+	Volume1 string `json:"volume1"`
+	Trades  uint64 `json:"trades"`
+}
+
+// SwapPoolStatsRequest is a request of SwapPoolStats.
+type SwapPoolStatsRequest struct {
+	Coin0 uint64 `json:"coin0"`
+	Coin1 uint64 `json:"coin1"`
+}
+
+// SwapPoolStatsResponse contains 24h volume and current liquidity of the pool.
+// TVL is the value of both reserves in Coin1, Coin0 is the pool coin with the lower id.
+type SwapPoolStatsResponse struct {
+	PoolID         uint64 `json:"pool_id"`
+	Coin0          uint64 `json:"coin0"`
+	Coin1          uint64 `json:"coin1"`
+	Volume0        string `json:"volume0_24h"`
+	Volume1        string `json:"volume1_24h"`
+	Trades         uint64 `json:"trades_24h"`
+	Reserve0       string `json:"reserve0"`
+	Reserve1       string `json:"reserve1"`
+	TVL            string `json:"tvl"`
+	LastSwapHeight uint64 `json:"last_swap_height"`
+}
+
+// SwapPoolCandles returns OHLCV candles of the pool.
+func (s *Service) SwapPoolCandles(ctx context.Context, req *SwapPoolCandlesRequest) (*SwapPoolCandlesResponse, error) {
+	history := s.blockchain.SwapHistory()
+	if history == nil {
+		return nil, status.Error(codes.Unavailable, "swap history is disabled")
+	}
+
+	interval, err := time.ParseDuration(req.Interval)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid interval: %s", err)
+	}
+	if interval < time.Minute {
+		return nil, status.Error(codes.InvalidArgument, "interval should not be less than 1m")
+	}
+
+	to := time.Now()
+	if req.To != 0 {
+		to = time.Unix(req.To, 0)
+	}
+	from := to.Add(-swapPoolDefaultRange * interval)
+	if req.From != 0 {
+		from = time.Unix(req.From, 0)
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from should be less than to")
+	}
+	if to.Sub(from)/interval > swapPoolMaxCandles {
+		return nil, status.Errorf(codes.InvalidArgument, "range should not exceed %d intervals", swapPoolMaxCandles)
+	}
+
+	coin0, coin1, poolID, err := s.swapHistoryPool(req.Coin0, req.Coin1)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	candles, err := history.Candles(poolID, interval, from, to)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &SwapPoolCandlesResponse{
+		PoolID:  uint64(poolID),
+		Coin0:   uint64(coin0),
+		Coin1:   uint64(coin1),
+		Candles: make([]*SwapPoolCandle, 0, len(candles)),
+	}
+	for _, candle := range candles {
+		response.Candles = append(response.Candles, &SwapPoolCandle{
+			Time:    candle.Time.Unix(),
+			Open:    candle.Open,
+			High:    candle.High,
+			Low:     candle.Low,
+			Close:   candle.Close,
+			Volume0: candle.Volume0.String(),
+			Volume1: candle.Volume1.String(),
+			Trades:  uint64(candle.Trades),
+		})
+	}
+
+	return response, nil
+}
+
+// SwapPoolStats returns 24h volume, reserves and TVL of the pool.
+func (s *Service) SwapPoolStats(ctx context.Context, req *SwapPoolStatsRequest) (*SwapPoolStatsResponse, error) {
+	history := s.blockchain.SwapHistory()
+	if history == nil {
+		return nil, status.Error(codes.Unavailable, "swap history is disabled")
+	}
+
+	coin0, coin1, poolID, err := s.swapHistoryPool(req.Coin0, req.Coin1)
+	if err != nil {
+		return nil, err
+	}
+	reserve0, reserve1, _ := s.blockchain.CurrentState().Swap().SwapPool(coin0, coin1)
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	stats, err := history.Stats(poolID, swapPoolStatsPeriod)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if stats == nil {
+		stats = &swaphistory.Stats{Reserves: &swaphistory.Reserves{}, Volume0: big.NewInt(0), Volume1: big.NewInt(0)}
+	}
+
+	return &SwapPoolStatsResponse{
+		PoolID:         uint64(poolID),
+		Coin0:          uint64(coin0),
+		Coin1:          uint64(coin1),
+		Volume0:        stats.Volume0.String(),
+		Volume1:        stats.Volume1.String(),
+		Trades:         uint64(stats.Trades),
+		Reserve0:       reserve0.String(),
+		Reserve1:       reserve1.String(),
+		TVL:            new(big.Int).Mul(reserve1, big.NewInt(2)).String(),
+		LastSwapHeight: stats.Reserves.Height,
+	}, nil
+}
+
+// swapHistoryPool returns coins of the existing pool ordered as in the swap history and its id
+func (s *Service) swapHistoryPool(coinA, coinB uint64) (coin0, coin1 types.CoinID, poolID uint32, err error) {
+	if coinA == coinB {
+		return 0, 0, 0, status.Error(codes.InvalidArgument, "equal coins id")
+	}
+
+	coin0, coin1 = types.CoinID(coinA), types.CoinID(coinB)
+	if coin0 > coin1 {
+		coin0, coin1 = coin1, coin0
+	}
+
+	_, _, poolID = s.blockchain.CurrentState().Swap().SwapPool(coin0, coin1)
+	if poolID == 0 {
+		return 0, 0, 0, status.Error(codes.NotFound, "pair not found")
+	}
+
+	return coin0, coin1, poolID, nil
+}
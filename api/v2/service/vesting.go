@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddressVestingRequest is a request of vesting schedules of the address.
+type AddressVestingRequest struct {
+	Address string `json:"address"`
+	Height  uint64 `json:"height"`
+}
+
+// AddressVestingResponse contains vesting schedules of the address and its balances split into spendable and locked parts.
+type AddressVestingResponse struct {
+	Balances  []*VestingBalance  `json:"balances"`
+	Schedules []*VestingSchedule `json:"schedules"`
+}
+
+// VestingBalance is the balance of the coin, Locked part can't be spent in the next block.
+type VestingBalance struct {
+	Coin       uint64 `json:"coin"`
+	CoinSymbol string `json:"coin_symbol"`
+	Total      string `json:"total"`
+	Locked     string `json:"locked"`
+	Spendable  string `json:"spendable"`
+}
+
+// VestingSchedule is the vesting schedule, Locked is its value which isn't vested by the next block.
+type VestingSchedule struct {
+	Coin        uint64 `json:"coin"`
+	CoinSymbol  string `json:"coin_symbol"`
+	Value       string `json:"value"`
+	Locked      string `json:"locked"`
+	StartHeight uint64 `json:"start_height"`
+	CliffHeight uint64 `json:"cliff_height"`
+	EndHeight   uint64 `json:"end_height"`
+	Steps       uint64 `json:"steps"`
+	Finished    bool   `json:"finished"`
+}
+
+// AddressVesting returns vesting schedules of the address and the spendable parts of its balances.
+func (s *Service) AddressVesting(ctx context.Context, req *AddressVestingRequest) (*AddressVestingResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(req.Address[2:])
+	if err != nil || len(decodeString) != types.AddressLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+	address := types.BytesToAddress(decodeString)
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	res := &AddressVestingResponse{Balances: []*VestingBalance{}, Schedules: []*VestingSchedule{}}
+	lockedByCoin := map[types.CoinID]*big.Int{}
+	for _, schedule := range cState.Vestings().GetSchedules(address) {
+		locked := schedule.Locked(height + 1)
+		if total, ok := lockedByCoin[schedule.Coin]; ok {
+			total.Add(total, locked)
+		} else {
+			lockedByCoin[schedule.Coin] = big.NewInt(0).Set(locked)
+		}
+
+		res.Schedules = append(res.Schedules, &VestingSchedule{
+			Coin:        uint64(schedule.Coin),
+			CoinSymbol:  cState.Coins().GetCoin(schedule.Coin).GetFullSymbol(),
+			Value:       schedule.Value.String(),
+			Locked:      locked.String(),
+			StartHeight: schedule.StartHeight,
+			CliffHeight: schedule.CliffHeight,
+			EndHeight:   schedule.EndHeight,
+			Steps:       schedule.Steps,
+			Finished:    schedule.Finished(height + 1),
+		})
+	}
+
+	for _, balance := range cState.AccountsWithLocked().GetBalances(address) {
+		locked, ok := lockedByCoin[balance.Coin.ID]
+		if !ok {
+			locked = big.NewInt(0)
+		}
+		spendable := big.NewInt(0).Sub(balance.Value, locked)
+		if spendable.Sign() == -1 {
+			spendable.SetInt64(0)
+		}
+
+		res.Balances = append(res.Balances, &VestingBalance{
+			Coin:       uint64(balance.Coin.ID),
+			CoinSymbol: balance.Coin.GetFullSymbol(),
+			Total:      balance.Value.String(),
+			Locked:     locked.String(),
+			Spendable:  spendable.String(),
+		})
+	}
+
+	return res, nil
+}
@@ -100,6 +100,10 @@ func Run(srv *service.Service, addrGRPC, addrAPI string, logger log.Logger) erro
 	if err != nil {
 		return err
 	}
+	err = registerExtHandlers(gwmux, srv)
+	if err != nil {
+		return err
+	}
 
 	mux := http.NewServeMux()
 	const openapi = "/v2/openapi-ui/"
@@ -752,6 +752,282 @@ func (x *PruneBlocksResponse) GetCurrent() int64 {
 	return 0
 }
 
+type Webhook struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url          string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Kinds        []string `protobuf:"bytes,3,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	Addresses    []string `protobuf:"bytes,4,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	PubKeys      []string `protobuf:"bytes,5,rep,name=pub_keys,json=pubKeys,proto3" json:"pub_keys,omitempty"`
+	MissedBlocks int64    `protobuf:"varint,6,opt,name=missed_blocks,json=missedBlocks,proto3" json:"missed_blocks,omitempty"`
+	QueueSize    int64    `protobuf:"varint,7,opt,name=queue_size,json=queueSize,proto3" json:"queue_size,omitempty"`
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_manager_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_manager_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *Webhook) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *Webhook) GetPubKeys() []string {
+	if x != nil {
+		return x.PubKeys
+	}
+	return nil
+}
+
+func (x *Webhook) GetMissedBlocks() int64 {
+	if x != nil {
+		return x.MissedBlocks
+	}
+	return 0
+}
+
+func (x *Webhook) GetQueueSize() int64 {
+	if x != nil {
+		return x.QueueSize
+	}
+	return 0
+}
+
+type AddWebhookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url          string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Secret       string   `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Kinds        []string `protobuf:"bytes,3,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	Addresses    []string `protobuf:"bytes,4,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	PubKeys      []string `protobuf:"bytes,5,rep,name=pub_keys,json=pubKeys,proto3" json:"pub_keys,omitempty"`
+	MissedBlocks int64    `protobuf:"varint,6,opt,name=missed_blocks,json=missedBlocks,proto3" json:"missed_blocks,omitempty"`
+}
+
+func (x *AddWebhookRequest) Reset() {
+	*x = AddWebhookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_manager_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWebhookRequest) ProtoMessage() {}
+
+func (x *AddWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWebhookRequest.ProtoReflect.Descriptor instead.
+func (*AddWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_manager_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AddWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AddWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *AddWebhookRequest) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *AddWebhookRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *AddWebhookRequest) GetPubKeys() []string {
+	if x != nil {
+		return x.PubKeys
+	}
+	return nil
+}
+
+func (x *AddWebhookRequest) GetMissedBlocks() int64 {
+	if x != nil {
+		return x.MissedBlocks
+	}
+	return 0
+}
+
+type RemoveWebhookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RemoveWebhookRequest) Reset() {
+	*x = RemoveWebhookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_manager_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWebhookRequest) ProtoMessage() {}
+
+func (x *RemoveWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_manager_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WebhooksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Webhooks []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+}
+
+func (x *WebhooksResponse) Reset() {
+	*x = WebhooksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_manager_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhooksResponse) ProtoMessage() {}
+
+func (x *WebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhooksResponse.ProtoReflect.Descriptor instead.
+func (*WebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_manager_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
 type NodeInfo_ProtocolVersion struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -765,7 +1041,7 @@ type NodeInfo_ProtocolVersion struct {
 func (x *NodeInfo_ProtocolVersion) Reset() {
 	*x = NodeInfo_ProtocolVersion{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_manager_proto_msgTypes[8]
+		mi := &file_manager_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -778,7 +1054,7 @@ func (x *NodeInfo_ProtocolVersion) String() string {
 func (*NodeInfo_ProtocolVersion) ProtoMessage() {}
 
 func (x *NodeInfo_ProtocolVersion) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_proto_msgTypes[8]
+	mi := &file_manager_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -827,7 +1103,7 @@ type NodeInfo_Other struct {
 func (x *NodeInfo_Other) Reset() {
 	*x = NodeInfo_Other{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_manager_proto_msgTypes[9]
+		mi := &file_manager_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -840,7 +1116,7 @@ func (x *NodeInfo_Other) String() string {
 func (*NodeInfo_Other) ProtoMessage() {}
 
 func (x *NodeInfo_Other) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_proto_msgTypes[9]
+	mi := &file_manager_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -885,7 +1161,7 @@ type NetInfoResponse_Peer struct {
 func (x *NetInfoResponse_Peer) Reset() {
 	*x = NetInfoResponse_Peer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_manager_proto_msgTypes[10]
+		mi := &file_manager_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -898,7 +1174,7 @@ func (x *NetInfoResponse_Peer) String() string {
 func (*NetInfoResponse_Peer) ProtoMessage() {}
 
 func (x *NetInfoResponse_Peer) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_proto_msgTypes[10]
+	mi := &file_manager_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -963,7 +1239,7 @@ type NetInfoResponse_Peer_ConnectionStatus struct {
 func (x *NetInfoResponse_Peer_ConnectionStatus) Reset() {
 	*x = NetInfoResponse_Peer_ConnectionStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_manager_proto_msgTypes[11]
+		mi := &file_manager_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -976,7 +1252,7 @@ func (x *NetInfoResponse_Peer_ConnectionStatus) String() string {
 func (*NetInfoResponse_Peer_ConnectionStatus) ProtoMessage() {}
 
 func (x *NetInfoResponse_Peer_ConnectionStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_proto_msgTypes[11]
+	mi := &file_manager_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1043,7 +1319,7 @@ type NetInfoResponse_Peer_ConnectionStatus_Monitor struct {
 func (x *NetInfoResponse_Peer_ConnectionStatus_Monitor) Reset() {
 	*x = NetInfoResponse_Peer_ConnectionStatus_Monitor{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_manager_proto_msgTypes[12]
+		mi := &file_manager_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1056,7 +1332,7 @@ func (x *NetInfoResponse_Peer_ConnectionStatus_Monitor) String() string {
 func (*NetInfoResponse_Peer_ConnectionStatus_Monitor) ProtoMessage() {}
 
 func (x *NetInfoResponse_Peer_ConnectionStatus_Monitor) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_proto_msgTypes[12]
+	mi := &file_manager_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1178,7 +1454,7 @@ type NetInfoResponse_Peer_ConnectionStatus_Channel struct {
 func (x *NetInfoResponse_Peer_ConnectionStatus_Channel) Reset() {
 	*x = NetInfoResponse_Peer_ConnectionStatus_Channel{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_manager_proto_msgTypes[13]
+		mi := &file_manager_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1191,7 +1467,7 @@ func (x *NetInfoResponse_Peer_ConnectionStatus_Channel) String() string {
 func (*NetInfoResponse_Peer_ConnectionStatus_Channel) ProtoMessage() {}
 
 func (x *NetInfoResponse_Peer_ConnectionStatus_Channel) ProtoReflect() protoreflect.Message {
-	mi := &file_manager_proto_msgTypes[13]
+	mi := &file_manager_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1449,34 +1725,76 @@ var file_manager_proto_rawDesc = []byte{
 	0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72,
 	0x72, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x32, 0x9f, 0x03, 0x0a, 0x0e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70,
-	0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x3a, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x11,
-	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x21, 0x2e, 0x63, 0x6c, 0x69, 0x5f,
-	0x70, 0x62, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b,
-	0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6c,
-	0x69, 0x5f, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62,
-	0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x08, 0x44, 0x65, 0x61, 0x6c, 0x50, 0x65,
-	0x65, 0x72, 0x12, 0x17, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x61, 0x6c,
-	0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x12, 0x40, 0x0a, 0x09, 0x44, 0x61, 0x73, 0x68, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x65, 0x6e, 0x74, 0x22, 0xbe, 0x01, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0xb1, 0x01, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x75, 0x62, 0x5f,
+	0x6b, 0x65, 0x79, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x75, 0x62, 0x4b,
+	0x65, 0x79, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x69, 0x73, 0x73,
+	0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22, 0x26, 0x0a, 0x14, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x3f, 0x0a, 0x10, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x73, 0x32, 0xde, 0x04, 0x0a, 0x0e, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a,
+	0x0a, 0x07, 0x4e, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x17, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x11, 0x41, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x21, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62,
+	0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x50, 0x72,
+	0x75, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x69, 0x5f,
+	0x70, 0x62, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e, 0x50,
+	0x72, 0x75, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x08, 0x44, 0x65, 0x61, 0x6c, 0x50, 0x65, 0x65, 0x72,
+	0x12, 0x17, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x50, 0x65,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x40, 0x0a, 0x09, 0x44, 0x61, 0x73, 0x68, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e,
+	0x44, 0x61, 0x73, 0x68, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x30, 0x01, 0x12, 0x38, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x63,
+	0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x45, 0x0a,
+	0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x1c,
+	0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x08, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73,
 	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70,
-	0x62, 0x2e, 0x44, 0x61, 0x73, 0x68, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x3b, 0x63, 0x6c, 0x69, 0x5f, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x63, 0x6c, 0x69, 0x5f, 0x70,
+	0x62, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x3b, 0x63, 0x6c, 0x69, 0x5f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1492,7 +1810,7 @@ func file_manager_proto_rawDescGZIP() []byte {
 }
 
 var file_manager_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_manager_proto_goTypes = []interface{}{
 	(DashboardResponse_ValidatorStatus)(0),                // 0: cli_pb.DashboardResponse.ValidatorStatus
 	(*NodeInfo)(nil),                                      // 1: cli_pb.NodeInfo
@@ -1503,45 +1821,56 @@ var file_manager_proto_goTypes = []interface{}{
 	(*AvailableVersionsResponse)(nil),                     // 6: cli_pb.AvailableVersionsResponse
 	(*PruneBlocksRequest)(nil),                            // 7: cli_pb.PruneBlocksRequest
 	(*PruneBlocksResponse)(nil),                           // 8: cli_pb.PruneBlocksResponse
-	(*NodeInfo_ProtocolVersion)(nil),                      // 9: cli_pb.NodeInfo.ProtocolVersion
-	(*NodeInfo_Other)(nil),                                // 10: cli_pb.NodeInfo.Other
-	(*NetInfoResponse_Peer)(nil),                          // 11: cli_pb.NetInfoResponse.Peer
-	(*NetInfoResponse_Peer_ConnectionStatus)(nil),         // 12: cli_pb.NetInfoResponse.Peer.ConnectionStatus
-	(*NetInfoResponse_Peer_ConnectionStatus_Monitor)(nil), // 13: cli_pb.NetInfoResponse.Peer.ConnectionStatus.Monitor
-	(*NetInfoResponse_Peer_ConnectionStatus_Channel)(nil), // 14: cli_pb.NetInfoResponse.Peer.ConnectionStatus.Channel
-	(*timestamppb.Timestamp)(nil),                         // 15: google.protobuf.Timestamp
-	(*wrapperspb.Int64Value)(nil),                         // 16: google.protobuf.Int64Value
-	(*emptypb.Empty)(nil),                                 // 17: google.protobuf.Empty
+	(*Webhook)(nil),                                       // 9: cli_pb.Webhook
+	(*AddWebhookRequest)(nil),                             // 10: cli_pb.AddWebhookRequest
+	(*RemoveWebhookRequest)(nil),                          // 11: cli_pb.RemoveWebhookRequest
+	(*WebhooksResponse)(nil),                              // 12: cli_pb.WebhooksResponse
+	(*NodeInfo_ProtocolVersion)(nil),                      // 13: cli_pb.NodeInfo.ProtocolVersion
+	(*NodeInfo_Other)(nil),                                // 14: cli_pb.NodeInfo.Other
+	(*NetInfoResponse_Peer)(nil),                          // 15: cli_pb.NetInfoResponse.Peer
+	(*NetInfoResponse_Peer_ConnectionStatus)(nil),         // 16: cli_pb.NetInfoResponse.Peer.ConnectionStatus
+	(*NetInfoResponse_Peer_ConnectionStatus_Monitor)(nil), // 17: cli_pb.NetInfoResponse.Peer.ConnectionStatus.Monitor
+	(*NetInfoResponse_Peer_ConnectionStatus_Channel)(nil), // 18: cli_pb.NetInfoResponse.Peer.ConnectionStatus.Channel
+	(*timestamppb.Timestamp)(nil),                         // 19: google.protobuf.Timestamp
+	(*wrapperspb.Int64Value)(nil),                         // 20: google.protobuf.Int64Value
+	(*emptypb.Empty)(nil),                                 // 21: google.protobuf.Empty
 }
 var file_manager_proto_depIdxs = []int32{
-	9,  // 0: cli_pb.NodeInfo.protocol_version:type_name -> cli_pb.NodeInfo.ProtocolVersion
-	10, // 1: cli_pb.NodeInfo.other:type_name -> cli_pb.NodeInfo.Other
-	11, // 2: cli_pb.NetInfoResponse.peers:type_name -> cli_pb.NetInfoResponse.Peer
-	15, // 3: cli_pb.DashboardResponse.timestamp:type_name -> google.protobuf.Timestamp
+	13, // 0: cli_pb.NodeInfo.protocol_version:type_name -> cli_pb.NodeInfo.ProtocolVersion
+	14, // 1: cli_pb.NodeInfo.other:type_name -> cli_pb.NodeInfo.Other
+	15, // 2: cli_pb.NetInfoResponse.peers:type_name -> cli_pb.NetInfoResponse.Peer
+	19, // 3: cli_pb.DashboardResponse.timestamp:type_name -> google.protobuf.Timestamp
 	0,  // 4: cli_pb.DashboardResponse.validator_status:type_name -> cli_pb.DashboardResponse.ValidatorStatus
-	16, // 5: cli_pb.NetInfoResponse.Peer.latest_block_height:type_name -> google.protobuf.Int64Value
-	1,  // 6: cli_pb.NetInfoResponse.Peer.node_info:type_name -> cli_pb.NodeInfo
-	12, // 7: cli_pb.NetInfoResponse.Peer.connection_status:type_name -> cli_pb.NetInfoResponse.Peer.ConnectionStatus
-	13, // 8: cli_pb.NetInfoResponse.Peer.ConnectionStatus.SendMonitor:type_name -> cli_pb.NetInfoResponse.Peer.ConnectionStatus.Monitor
-	13, // 9: cli_pb.NetInfoResponse.Peer.ConnectionStatus.RecvMonitor:type_name -> cli_pb.NetInfoResponse.Peer.ConnectionStatus.Monitor
-	14, // 10: cli_pb.NetInfoResponse.Peer.ConnectionStatus.channels:type_name -> cli_pb.NetInfoResponse.Peer.ConnectionStatus.Channel
-	17, // 11: cli_pb.ManagerService.Status:input_type -> google.protobuf.Empty
-	17, // 12: cli_pb.ManagerService.NetInfo:input_type -> google.protobuf.Empty
-	17, // 13: cli_pb.ManagerService.AvailableVersions:input_type -> google.protobuf.Empty
-	7,  // 14: cli_pb.ManagerService.PruneBlocks:input_type -> cli_pb.PruneBlocksRequest
-	4,  // 15: cli_pb.ManagerService.DealPeer:input_type -> cli_pb.DealPeerRequest
-	17, // 16: cli_pb.ManagerService.Dashboard:input_type -> google.protobuf.Empty
-	3,  // 17: cli_pb.ManagerService.Status:output_type -> cli_pb.StatusResponse
-	2,  // 18: cli_pb.ManagerService.NetInfo:output_type -> cli_pb.NetInfoResponse
-	6,  // 19: cli_pb.ManagerService.AvailableVersions:output_type -> cli_pb.AvailableVersionsResponse
-	8,  // 20: cli_pb.ManagerService.PruneBlocks:output_type -> cli_pb.PruneBlocksResponse
-	17, // 21: cli_pb.ManagerService.DealPeer:output_type -> google.protobuf.Empty
-	5,  // 22: cli_pb.ManagerService.Dashboard:output_type -> cli_pb.DashboardResponse
-	17, // [17:23] is the sub-list for method output_type
-	11, // [11:17] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	9,  // 5: cli_pb.WebhooksResponse.webhooks:type_name -> cli_pb.Webhook
+	20, // 6: cli_pb.NetInfoResponse.Peer.latest_block_height:type_name -> google.protobuf.Int64Value
+	1,  // 7: cli_pb.NetInfoResponse.Peer.node_info:type_name -> cli_pb.NodeInfo
+	16, // 8: cli_pb.NetInfoResponse.Peer.connection_status:type_name -> cli_pb.NetInfoResponse.Peer.ConnectionStatus
+	17, // 9: cli_pb.NetInfoResponse.Peer.ConnectionStatus.SendMonitor:type_name -> cli_pb.NetInfoResponse.Peer.ConnectionStatus.Monitor
+	17, // 10: cli_pb.NetInfoResponse.Peer.ConnectionStatus.RecvMonitor:type_name -> cli_pb.NetInfoResponse.Peer.ConnectionStatus.Monitor
+	18, // 11: cli_pb.NetInfoResponse.Peer.ConnectionStatus.channels:type_name -> cli_pb.NetInfoResponse.Peer.ConnectionStatus.Channel
+	21, // 12: cli_pb.ManagerService.Status:input_type -> google.protobuf.Empty
+	21, // 13: cli_pb.ManagerService.NetInfo:input_type -> google.protobuf.Empty
+	21, // 14: cli_pb.ManagerService.AvailableVersions:input_type -> google.protobuf.Empty
+	7,  // 15: cli_pb.ManagerService.PruneBlocks:input_type -> cli_pb.PruneBlocksRequest
+	4,  // 16: cli_pb.ManagerService.DealPeer:input_type -> cli_pb.DealPeerRequest
+	21, // 17: cli_pb.ManagerService.Dashboard:input_type -> google.protobuf.Empty
+	10, // 18: cli_pb.ManagerService.AddWebhook:input_type -> cli_pb.AddWebhookRequest
+	11, // 19: cli_pb.ManagerService.RemoveWebhook:input_type -> cli_pb.RemoveWebhookRequest
+	21, // 20: cli_pb.ManagerService.Webhooks:input_type -> google.protobuf.Empty
+	3,  // 21: cli_pb.ManagerService.Status:output_type -> cli_pb.StatusResponse
+	2,  // 22: cli_pb.ManagerService.NetInfo:output_type -> cli_pb.NetInfoResponse
+	6,  // 23: cli_pb.ManagerService.AvailableVersions:output_type -> cli_pb.AvailableVersionsResponse
+	8,  // 24: cli_pb.ManagerService.PruneBlocks:output_type -> cli_pb.PruneBlocksResponse
+	21, // 25: cli_pb.ManagerService.DealPeer:output_type -> google.protobuf.Empty
+	5,  // 26: cli_pb.ManagerService.Dashboard:output_type -> cli_pb.DashboardResponse
+	9,  // 27: cli_pb.ManagerService.AddWebhook:output_type -> cli_pb.Webhook
+	21, // 28: cli_pb.ManagerService.RemoveWebhook:output_type -> google.protobuf.Empty
+	12, // 29: cli_pb.ManagerService.Webhooks:output_type -> cli_pb.WebhooksResponse
+	21, // [21:30] is the sub-list for method output_type
+	12, // [12:21] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_manager_proto_init() }
@@ -1647,7 +1976,7 @@ func file_manager_proto_init() {
 			}
 		}
 		file_manager_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeInfo_ProtocolVersion); i {
+			switch v := v.(*Webhook); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1659,7 +1988,7 @@ func file_manager_proto_init() {
 			}
 		}
 		file_manager_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeInfo_Other); i {
+			switch v := v.(*AddWebhookRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1671,7 +2000,7 @@ func file_manager_proto_init() {
 			}
 		}
 		file_manager_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetInfoResponse_Peer); i {
+			switch v := v.(*RemoveWebhookRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1683,7 +2012,7 @@ func file_manager_proto_init() {
 			}
 		}
 		file_manager_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetInfoResponse_Peer_ConnectionStatus); i {
+			switch v := v.(*WebhooksResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1695,7 +2024,7 @@ func file_manager_proto_init() {
 			}
 		}
 		file_manager_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetInfoResponse_Peer_ConnectionStatus_Monitor); i {
+			switch v := v.(*NodeInfo_ProtocolVersion); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1707,6 +2036,54 @@ func file_manager_proto_init() {
 			}
 		}
 		file_manager_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeInfo_Other); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_manager_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetInfoResponse_Peer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_manager_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetInfoResponse_Peer_ConnectionStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_manager_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetInfoResponse_Peer_ConnectionStatus_Monitor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_manager_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NetInfoResponse_Peer_ConnectionStatus_Channel); i {
 			case 0:
 				return &v.state
@@ -1725,7 +2102,7 @@ func file_manager_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_manager_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   14,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
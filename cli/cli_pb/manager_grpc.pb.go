@@ -24,6 +24,9 @@ type ManagerServiceClient interface {
 	PruneBlocks(ctx context.Context, in *PruneBlocksRequest, opts ...grpc.CallOption) (ManagerService_PruneBlocksClient, error)
 	DealPeer(ctx context.Context, in *DealPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Dashboard(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ManagerService_DashboardClient, error)
+	AddWebhook(ctx context.Context, in *AddWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	RemoveWebhook(ctx context.Context, in *RemoveWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Webhooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WebhooksResponse, error)
 }
 
 type managerServiceClient struct {
@@ -134,6 +137,33 @@ func (x *managerServiceDashboardClient) Recv() (*DashboardResponse, error) {
 	return m, nil
 }
 
+func (c *managerServiceClient) AddWebhook(ctx context.Context, in *AddWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/cli_pb.ManagerService/AddWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerServiceClient) RemoveWebhook(ctx context.Context, in *RemoveWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/cli_pb.ManagerService/RemoveWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerServiceClient) Webhooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WebhooksResponse, error) {
+	out := new(WebhooksResponse)
+	err := c.cc.Invoke(ctx, "/cli_pb.ManagerService/Webhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ManagerServiceServer is the server API for ManagerService service.
 // All implementations must embed UnimplementedManagerServiceServer
 // for forward compatibility
@@ -144,6 +174,9 @@ type ManagerServiceServer interface {
 	PruneBlocks(*PruneBlocksRequest, ManagerService_PruneBlocksServer) error
 	DealPeer(context.Context, *DealPeerRequest) (*emptypb.Empty, error)
 	Dashboard(*emptypb.Empty, ManagerService_DashboardServer) error
+	AddWebhook(context.Context, *AddWebhookRequest) (*Webhook, error)
+	RemoveWebhook(context.Context, *RemoveWebhookRequest) (*emptypb.Empty, error)
+	Webhooks(context.Context, *emptypb.Empty) (*WebhooksResponse, error)
 	mustEmbedUnimplementedManagerServiceServer()
 }
 
@@ -169,6 +202,15 @@ func (UnimplementedManagerServiceServer) DealPeer(context.Context, *DealPeerRequ
 func (UnimplementedManagerServiceServer) Dashboard(*emptypb.Empty, ManagerService_DashboardServer) error {
 	return status.Errorf(codes.Unimplemented, "method Dashboard not implemented")
 }
+func (UnimplementedManagerServiceServer) AddWebhook(context.Context, *AddWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWebhook not implemented")
+}
+func (UnimplementedManagerServiceServer) RemoveWebhook(context.Context, *RemoveWebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWebhook not implemented")
+}
+func (UnimplementedManagerServiceServer) Webhooks(context.Context, *emptypb.Empty) (*WebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Webhooks not implemented")
+}
 func (UnimplementedManagerServiceServer) mustEmbedUnimplementedManagerServiceServer() {}
 
 // UnsafeManagerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -296,6 +338,60 @@ func (x *managerServiceDashboardServer) Send(m *DashboardResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ManagerService_AddWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServiceServer).AddWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cli_pb.ManagerService/AddWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServiceServer).AddWebhook(ctx, req.(*AddWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagerService_RemoveWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServiceServer).RemoveWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cli_pb.ManagerService/RemoveWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServiceServer).RemoveWebhook(ctx, req.(*RemoveWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManagerService_Webhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServiceServer).Webhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cli_pb.ManagerService/Webhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServiceServer).Webhooks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ManagerService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cli_pb.ManagerService",
 	HandlerType: (*ManagerServiceServer)(nil),
@@ -316,6 +412,18 @@ var _ManagerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DealPeer",
 			Handler:    _ManagerService_DealPeer_Handler,
 		},
+		{
+			MethodName: "AddWebhook",
+			Handler:    _ManagerService_AddWebhook_Handler,
+		},
+		{
+			MethodName: "RemoveWebhook",
+			Handler:    _ManagerService_RemoveWebhook_Handler,
+		},
+		{
+			MethodName: "Webhooks",
+			Handler:    _ManagerService_Webhooks_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -190,6 +190,39 @@ func NewCLI(socketPath string) (interface {
 			Usage:   "Show dashboard",
 			Action:  dashboardCMD(client),
 		},
+		{
+			Name:    "webhooks",
+			Aliases: []string{"wh"},
+			Usage:   "display webhooks and the number of queued deliveries",
+			Flags: []cli.Flag{
+				jsonFlag,
+			},
+			Action: webhooksCMD(client),
+		},
+		{
+			Name:    "add_webhook",
+			Aliases: []string{"awh"},
+			Usage:   "add a webhook receiving notifications signed with the secret",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "url", Aliases: []string{"u"}, Required: true},
+				&cli.StringFlag{Name: "secret", Aliases: []string{"s"}, Required: true, Usage: "HMAC-SHA256 key of the X-Minter-Signature header"},
+				&cli.StringSliceFlag{Name: "kind", Aliases: []string{"k"}, Required: false, Usage: "kind of notifications, all kinds by default"},
+				&cli.StringSliceFlag{Name: "address", Aliases: []string{"a"}, Required: false, Usage: "watched address, any address by default"},
+				&cli.StringSliceFlag{Name: "pub_key", Aliases: []string{"p"}, Required: false, Usage: "watched validator, any validator by default"},
+				&cli.IntFlag{Name: "missed_blocks", Aliases: []string{"m"}, Required: false, Usage: "number of missed blocks of the validator to notify about"},
+				jsonFlag,
+			},
+			Action: addWebhookCMD(client),
+		},
+		{
+			Name:    "remove_webhook",
+			Aliases: []string{"rwh"},
+			Usage:   "remove the webhook and its queued deliveries",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "id", Required: true},
+			},
+			Action: removeWebhookCMD(client),
+		},
 		{
 			Name:    "exit",
 			Aliases: []string{"e"},
@@ -482,3 +515,59 @@ func dealPeerCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
 		return nil
 	}
 }
+
+func webhooksCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		response, err := client.Webhooks(c.Context, &empty.Empty{})
+		if err != nil {
+			return err
+		}
+		if c.Bool("json") {
+			bb, err := protojson.Marshal(response)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bb))
+			return nil
+		}
+		fmt.Println(proto.MarshalTextString(response))
+		return nil
+	}
+}
+
+func addWebhookCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		response, err := client.AddWebhook(c.Context, &pb.AddWebhookRequest{
+			Url:          c.String("url"),
+			Secret:       c.String("secret"),
+			Kinds:        c.StringSlice("kind"),
+			Addresses:    c.StringSlice("address"),
+			PubKeys:      c.StringSlice("pub_key"),
+			MissedBlocks: c.Int64("missed_blocks"),
+		})
+		if err != nil {
+			return err
+		}
+		if c.Bool("json") {
+			bb, err := protojson.Marshal(response)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bb))
+			return nil
+		}
+		fmt.Println(proto.MarshalTextString(response))
+		return nil
+	}
+}
+
+func removeWebhookCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		_, err := client.RemoveWebhook(c.Context, &pb.RemoveWebhookRequest{Id: c.String("id")})
+		if err != nil {
+			return err
+		}
+		fmt.Println("OK")
+		return nil
+	}
+}
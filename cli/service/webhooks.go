@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/MinterTeam/minter-go-node/cli/cli_pb"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/coreV2/webhooks"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (m *managerServer) AddWebhook(_ context.Context, req *pb.AddWebhookRequest) (*pb.Webhook, error) {
+	dispatcher := m.blockchain.Webhooks()
+	if dispatcher == nil {
+		return nil, status.Error(codes.Unavailable, "webhooks are disabled")
+	}
+
+	hook := &webhooks.Webhook{URL: req.Url, Secret: req.Secret, Kinds: req.Kinds, MissedBlocks: int(req.MissedBlocks)}
+	for _, address := range req.Addresses {
+		if !strings.HasPrefix(strings.Title(address), "Mx") || len(address) != 42 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid address %s", address)
+		}
+		hook.Addresses = append(hook.Addresses, types.HexToAddress(address))
+	}
+	for _, pubKey := range req.PubKeys {
+		if !strings.HasPrefix(strings.Title(pubKey), "Mp") || len(pubKey) != 66 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid public key %s", pubKey)
+		}
+		hook.PubKeys = append(hook.PubKeys, types.HexToPubkey(pubKey))
+	}
+
+	if err := dispatcher.AddWebhook(hook); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return webhookResponse(hook, 0), nil
+}
+
+func (m *managerServer) RemoveWebhook(_ context.Context, req *pb.RemoveWebhookRequest) (*empty.Empty, error) {
+	dispatcher := m.blockchain.Webhooks()
+	if dispatcher == nil {
+		return nil, status.Error(codes.Unavailable, "webhooks are disabled")
+	}
+
+	if err := dispatcher.RemoveWebhook(req.Id); err != nil {
+		if err == webhooks.ErrNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return new(empty.Empty), nil
+}
+
+func (m *managerServer) Webhooks(context.Context, *empty.Empty) (*pb.WebhooksResponse, error) {
+	dispatcher := m.blockchain.Webhooks()
+	if dispatcher == nil {
+		return nil, status.Error(codes.Unavailable, "webhooks are disabled")
+	}
+
+	hooks, err := dispatcher.Webhooks()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	queue, err := dispatcher.QueueSize()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &pb.WebhooksResponse{Webhooks: make([]*pb.Webhook, 0, len(hooks))}
+	for _, hook := range hooks {
+		response.Webhooks = append(response.Webhooks, webhookResponse(hook, queue[hook.ID]))
+	}
+	return response, nil
+}
+
+// webhookResponse converts the webhook without its secret
+func webhookResponse(hook *webhooks.Webhook, queueSize int) *pb.Webhook {
+	response := &pb.Webhook{
+		Id:           hook.ID,
+		Url:          hook.URL,
+		Kinds:        hook.Kinds,
+		MissedBlocks: int64(hook.MissedBlocks),
+		QueueSize:    int64(queueSize),
+	}
+	for _, address := range hook.Addresses {
+		response.Addresses = append(response.Addresses, address.String())
+	}
+	for _, pubKey := range hook.PubKeys {
+		response.PubKeys = append(response.PubKeys, pubKey.String())
+	}
+	return response
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/MinterTeam/minter-go-node/cmd/utils"
+	"github.com/MinterTeam/minter-go-node/config"
+	"github.com/MinterTeam/minter-go-node/coreV2/activity"
+	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
+	"github.com/MinterTeam/minter-go-node/coreV2/minter"
+	"github.com/spf13/cobra"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	dbm "github.com/tendermint/tm-db"
+)
+
+var RebuildAddressIndexCommand = &cobra.Command{
+	Use:   "rebuild_address_index",
+	Short: "Rebuild the index of transactions by addresses from the stored blocks",
+	RunE:  rebuildAddressIndex,
+}
+
+func rebuildAddressIndex(cmd *cobra.Command, args []string) error {
+	from, err := cmd.Flags().GetInt64("from")
+	if err != nil {
+		return err
+	}
+	to, err := cmd.Flags().GetInt64("to")
+	if err != nil {
+		return err
+	}
+
+	homeDir, err := cmd.Flags().GetString("home-dir")
+	if err != nil {
+		return err
+	}
+	storages := utils.NewStorage(homeDir, "")
+
+	tmConfig := config.GetTmConfig(cfg)
+	blockStoreDB, err := dbm.NewDB("blockstore", dbm.BackendType(tmConfig.DBBackend), tmConfig.DBDir())
+	if err != nil {
+		return err
+	}
+	defer blockStoreDB.Close()
+	stateDB, err := dbm.NewDB("state", dbm.BackendType(tmConfig.DBBackend), tmConfig.DBDir())
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	addressIndexDB, err := storages.InitAddressIndexLevelDB("data/address_index", minter.GetDbOpts(1024))
+	if err != nil {
+		return err
+	}
+	defer addressIndexDB.Close()
+
+	appDB := appdb.NewAppDB(storages.GetMinterHome(), cfg)
+	defer appDB.Close()
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+	if from < blockStore.Base() {
+		from = blockStore.Base()
+	}
+	if to == 0 || to > blockStore.Height() {
+		to = blockStore.Height()
+	}
+	if from > to {
+		return fmt.Errorf("no blocks to index between %d and %d", from, to)
+	}
+
+	log.Printf("Indexing blocks from %d to %d...", from, to)
+	err = minter.RebuildAddressIndex(activity.NewIndex(addressIndexDB), appDB, blockStore, sm.NewStore(stateDB), from, to, func(height int64) {
+		if height%10000 == 0 {
+			log.Printf("Indexed block %d", height)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	log.Println("Done")
+
+	return nil
+}
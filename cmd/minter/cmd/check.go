@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/spf13/cobra"
+)
+
+var CheckCommand = &cobra.Command{
+	Use:   "check",
+	Short: "Minter checks",
+}
+
+var CheckIssueCommand = &cobra.Command{
+	Use:   "issue",
+	Short: "Issue and sign the check offline",
+	RunE:  issueCheck,
+}
+
+func issueCheck(cmd *cobra.Command, args []string) error {
+	privateKeyHex, err := readPrivateKey(cmd)
+	if err != nil {
+		return err
+	}
+	passphrase, err := cmd.Flags().GetString("passphrase")
+	if err != nil {
+		return err
+	}
+	nonce, err := cmd.Flags().GetString("nonce")
+	if err != nil {
+		return err
+	}
+	dueBlock, err := cmd.Flags().GetUint64("due-block")
+	if err != nil {
+		return err
+	}
+	coin, err := cmd.Flags().GetUint32("coin")
+	if err != nil {
+		return err
+	}
+	gasCoin, err := cmd.Flags().GetUint32("gas-coin")
+	if err != nil {
+		return err
+	}
+	valueString, err := cmd.Flags().GetString("value")
+	if err != nil {
+		return err
+	}
+
+	if passphrase == "" {
+		return errors.New("passphrase is required")
+	}
+	if nonce == "" || len(nonce) > 16 {
+		return errors.New("nonce should be from 1 to 16 bytes")
+	}
+	value := helpers.StringToBigIntOrNil(valueString)
+	if value == nil || value.Sign() <= 0 {
+		return errors.New("value should be a positive integer in pip")
+	}
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x"))
+	if err != nil {
+		return err
+	}
+
+	passphraseHash := sha256.Sum256([]byte(passphrase))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		return err
+	}
+
+	c := check.Check{
+		Nonce:    []byte(nonce),
+		ChainID:  types.CurrentChainID,
+		DueBlock: dueBlock,
+		Coin:     types.CoinID(coin),
+		Value:    value,
+		GasCoin:  types.CoinID(gasCoin),
+	}
+
+	lock, err := crypto.Sign(c.HashWithoutLock().Bytes(), passphrasePk)
+	if err != nil {
+		return err
+	}
+	c.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := c.Sign(privateKey); err != nil {
+		return err
+	}
+
+	rawCheck, err := rlp.EncodeToBytes(c)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Mc%x\n", rawCheck)
+	return nil
+}
+
+// readPrivateKey reads the hex encoded private key of the issuer from the file or the first line of stdin,
+// so the key is not kept in the shell history and not seen in the list of processes
+func readPrivateKey(cmd *cobra.Command) (string, error) {
+	file, err := cmd.Flags().GetString("private-key-file")
+	if err != nil {
+		return "", err
+	}
+	if file != "" {
+		key, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		return string(key), nil
+	}
+
+	key, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && key == "" {
+		return "", fmt.Errorf("private key is not read from stdin: %s", err)
+	}
+	return key, nil
+}
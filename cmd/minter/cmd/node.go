@@ -84,6 +84,48 @@ func runNode(cmd *cobra.Command) error {
 		if err != nil {
 			return err
 		}
+		if cfg.AddressIndex {
+			_, err = storages.InitAddressIndexLevelDB("data/address_index", minter.GetDbOpts(1024))
+			if err != nil {
+				return err
+			}
+		}
+		if cfg.SwapHistory {
+			_, err = storages.InitSwapHistoryLevelDB("data/swap_history", minter.GetDbOpts(1024))
+			if err != nil {
+				return err
+			}
+		}
+		if cfg.Webhooks {
+			_, err = storages.InitWebhooksLevelDB("data/webhooks", minter.GetDbOpts(1024))
+			if err != nil {
+				return err
+			}
+		}
+		if cfg.HoldersIndex {
+			_, err = storages.InitHoldersIndexLevelDB("data/holders_index", minter.GetDbOpts(1024))
+			if err != nil {
+				return err
+			}
+		}
+		if cfg.RewardHistory {
+			_, err = storages.InitRewardHistoryLevelDB("data/reward_history", minter.GetDbOpts(1024))
+			if err != nil {
+				return err
+			}
+		}
+		if cfg.MultisigSignatures {
+			_, err = storages.InitMultisigPoolLevelDB("data/multisig_pool", minter.GetDbOpts(1024))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if cfg.PersistentMempool {
+		_, err = storages.InitMempoolLevelDB("data/mempool", minter.GetDbOpts(1024))
+		if err != nil {
+			return err
+		}
 	}
 	_, err = storages.InitStateLevelDB("data/state", minter.GetDbOpts(cfg.StateMemAvailable))
 	if err != nil {
@@ -114,6 +156,10 @@ func runNode(cmd *cobra.Command) error {
 		runAPI(logger, app, client, node, app.RewardCounter())
 	}
 
+	if dispatcher := app.Webhooks(); dispatcher != nil {
+		go dispatcher.Run(cmd.Context())
+	}
+
 	runCLI(cmd.Context(), app, client, node, storages.GetMinterHome())
 
 	if cfg.Instrumentation.Prometheus {
@@ -250,6 +296,8 @@ func startTendermintNode(app *minter.Blockchain, cfg *tmCfg.Config, logger tmLog
 
 	logger.Info("Started node", "nodeInfo", node.Switch().NodeInfo())
 
+	app.ReplayPendingTxs()
+
 	return node
 }
 
@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/MinterTeam/minter-go-node/cmd/utils"
 	"github.com/MinterTeam/minter-go-node/config"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/version"
 	"github.com/spf13/cobra"
@@ -40,6 +41,8 @@ var RootCmd = &cobra.Command{
 			panic("keep_last_states field should be greater than 0")
 		}
 
+		transaction.ReplaceByFeeBump = cfg.ReplaceByFeeBump
+
 		isTestnet, _ := cmd.Flags().GetBool("testnet")
 		if isTestnet {
 			types.CurrentChainID = types.ChainTestnet
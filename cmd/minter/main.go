@@ -28,7 +28,10 @@ func main() {
 		cmd.VerifyGenesis,
 		cmd.Version,
 		cmd.ExportCommand,
+		cmd.RebuildAddressIndexCommand,
+		cmd.CheckCommand,
 	)
+	cmd.CheckCommand.AddCommand(cmd.CheckIssueCommand)
 
 	rootCmd.PersistentFlags().String("home-dir", "", "base dir (default is $HOME/.minter)")
 	rootCmd.PersistentFlags().String("config", "", "path to config (default is $(home-dir)/config/config.toml)")
@@ -44,6 +47,17 @@ func main() {
 	cmd.ExportCommand.Flags().String("chain-id", "", "export chain id")
 	cmd.ExportCommand.Flags().Duration("genesis-time", 0, "export height")
 
+	cmd.RebuildAddressIndexCommand.Flags().Int64("from", 0, "first height to index (default is the first stored block)")
+	cmd.RebuildAddressIndexCommand.Flags().Int64("to", 0, "last height to index (default is the last stored block)")
+
+	cmd.CheckIssueCommand.Flags().String("private-key-file", "", "file with hex encoded private key of the issuer, the key is read from stdin if not set")
+	cmd.CheckIssueCommand.Flags().String("passphrase", "", "passphrase to redeem the check")
+	cmd.CheckIssueCommand.Flags().String("nonce", "", "unique id of the check, up to 16 bytes")
+	cmd.CheckIssueCommand.Flags().Uint64("due-block", 999999999, "last block height in which the check can be redeemed")
+	cmd.CheckIssueCommand.Flags().Uint32("coin", 0, "id of the coin of the check")
+	cmd.CheckIssueCommand.Flags().String("value", "", "amount of coins in pip")
+	cmd.CheckIssueCommand.Flags().Uint32("gas-coin", 0, "id of the coin to pay the commission of redeeming")
+
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		panic(err)
 	}
@@ -13,6 +13,13 @@ type Storage struct {
 	eventDB      db.DB
 	stateDB      db.DB
 	snapshotDB   db.DB
+	addressDB    db.DB
+	swapsDB      db.DB
+	webhooksDB   db.DB
+	holdersDB    db.DB
+	rewardsDB    db.DB
+	mempoolDB    db.DB
+	multisigDB   db.DB
 }
 
 func (s *Storage) SetMinterConfig(minterConfig string) {
@@ -35,8 +42,36 @@ func (s *Storage) SnapshotDB() db.DB {
 	return s.snapshotDB
 }
 
+func (s *Storage) AddressIndexDB() db.DB {
+	return s.addressDB
+}
+
+func (s *Storage) SwapHistoryDB() db.DB {
+	return s.swapsDB
+}
+
+func (s *Storage) WebhooksDB() db.DB {
+	return s.webhooksDB
+}
+
+func (s *Storage) HoldersIndexDB() db.DB {
+	return s.holdersDB
+}
+
+func (s *Storage) RewardHistoryDB() db.DB {
+	return s.rewardsDB
+}
+
+func (s *Storage) MempoolDB() db.DB {
+	return s.mempoolDB
+}
+
+func (s *Storage) MultisigPoolDB() db.DB {
+	return s.multisigDB
+}
+
 func NewStorage(home string, config string) *Storage {
-	return &Storage{eventDB: db.NewMemDB(), stateDB: db.NewMemDB(), snapshotDB: db.NewMemDB(), minterConfig: config, minterHome: home}
+	return &Storage{eventDB: db.NewMemDB(), stateDB: db.NewMemDB(), snapshotDB: db.NewMemDB(), addressDB: db.NewMemDB(), swapsDB: db.NewMemDB(), webhooksDB: db.NewMemDB(), holdersDB: db.NewMemDB(), rewardsDB: db.NewMemDB(), mempoolDB: db.NewMemDB(), multisigDB: db.NewMemDB(), minterConfig: config, minterHome: home}
 }
 
 func (s *Storage) InitSnapshotLevelDB(name string, opts *opt.Options) (db.DB, error) {
@@ -57,6 +92,69 @@ func (s *Storage) InitEventLevelDB(name string, opts *opt.Options) (db.DB, error
 	return s.eventDB, nil
 }
 
+func (s *Storage) InitAddressIndexLevelDB(name string, opts *opt.Options) (db.DB, error) {
+	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
+	if err != nil {
+		return nil, err
+	}
+	s.addressDB = levelDB
+	return s.addressDB, nil
+}
+
+func (s *Storage) InitSwapHistoryLevelDB(name string, opts *opt.Options) (db.DB, error) {
+	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
+	if err != nil {
+		return nil, err
+	}
+	s.swapsDB = levelDB
+	return s.swapsDB, nil
+}
+
+func (s *Storage) InitWebhooksLevelDB(name string, opts *opt.Options) (db.DB, error) {
+	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
+	if err != nil {
+		return nil, err
+	}
+	s.webhooksDB = levelDB
+	return s.webhooksDB, nil
+}
+
+func (s *Storage) InitHoldersIndexLevelDB(name string, opts *opt.Options) (db.DB, error) {
+	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
+	if err != nil {
+		return nil, err
+	}
+	s.holdersDB = levelDB
+	return s.holdersDB, nil
+}
+
+func (s *Storage) InitRewardHistoryLevelDB(name string, opts *opt.Options) (db.DB, error) {
+	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
+	if err != nil {
+		return nil, err
+	}
+	s.rewardsDB = levelDB
+	return s.rewardsDB, nil
+}
+
+func (s *Storage) InitMempoolLevelDB(name string, opts *opt.Options) (db.DB, error) {
+	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
+	if err != nil {
+		return nil, err
+	}
+	s.mempoolDB = levelDB
+	return s.mempoolDB, nil
+}
+
+func (s *Storage) InitMultisigPoolLevelDB(name string, opts *opt.Options) (db.DB, error) {
+	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
+	if err != nil {
+		return nil, err
+	}
+	s.multisigDB = levelDB
+	return s.multisigDB, nil
+}
+
 func (s *Storage) InitStateLevelDB(name string, opts *opt.Options) (db.DB, error) {
 	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
 	if err != nil {
@@ -259,6 +259,30 @@ type BaseConfig struct {
 
 	KeepLastStates int64 `mapstructure:"keep_last_states"`
 
+	// Index transactions by every involved address, disabled in validator mode
+	AddressIndex bool `mapstructure:"address_index"`
+
+	// Store swaps through the pools for candles and volumes, disabled in validator mode
+	SwapHistory bool `mapstructure:"swap_history"`
+
+	// Deliver notifications to webhooks managed via the manager console, disabled in validator mode
+	Webhooks bool `mapstructure:"webhooks"`
+
+	// Index holders of every coin for the rich list API, disabled in validator mode
+	HoldersIndex bool `mapstructure:"holders_index"`
+
+	// Store stakes at every payment of rewards for the reward history API, disabled in validator mode
+	RewardHistory bool `mapstructure:"reward_history"`
+
+	// Collect signatures of members for multisig transactions via API and send them when the threshold is reached, disabled in validator mode
+	MultisigSignatures bool `mapstructure:"multisig_signatures"`
+
+	// Minimal increase of the gas price in percent to replace the last pending transaction of the sender with the same nonce, zero disables replacements
+	ReplaceByFeeBump uint32 `mapstructure:"replace_by_fee_bump"`
+
+	// Store pending transactions on disk and check them again after restart
+	PersistentMempool bool `mapstructure:"persistent_mempool"`
+
 	APISimultaneousRequests int `mapstructure:"api_simultaneous_requests"`
 
 	LogPath string `mapstructure:"log_path"`
@@ -297,6 +321,14 @@ func DefaultBaseConfig() BaseConfig {
 		WSConnectionDuration:    time.Minute,
 		ValidatorMode:           false,
 		KeepLastStates:          120,
+		AddressIndex:            false,
+		SwapHistory:             false,
+		Webhooks:                false,
+		HoldersIndex:            false,
+		RewardHistory:           false,
+		MultisigSignatures:      false,
+		ReplaceByFeeBump:        10,
+		PersistentMempool:       false,
 		APISimultaneousRequests: 100,
 		LogPath:                 "stdout",
 		LogFormat:               LogFormatPlain,
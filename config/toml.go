@@ -89,6 +89,30 @@ validator_mode = {{ .BaseConfig.ValidatorMode }}
 # Sets number of last stated to be saved on disk.
 keep_last_states = {{ .BaseConfig.KeepLastStates }}
 
+# Index transactions by every involved address to serve the address history API. Disabled in validator mode.
+address_index = {{ .BaseConfig.AddressIndex }}
+
+# Store swaps through the pools to serve candles and volumes of the pools via API. Disabled in validator mode.
+swap_history = {{ .BaseConfig.SwapHistory }}
+
+# Deliver notifications about addresses, stakes and validators to webhooks managed via the manager console. Disabled in validator mode.
+webhooks = {{ .BaseConfig.Webhooks }}
+
+# Index holders of every coin with their balances and stakes to serve top holders and distribution via API. Disabled in validator mode.
+holders_index = {{ .BaseConfig.HoldersIndex }}
+
+# Store stakes of delegators and candidates at every payment of rewards to serve the reward history and APR via API. Disabled in validator mode.
+reward_history = {{ .BaseConfig.RewardHistory }}
+
+# Collect signatures of members for multisig transactions via API and send transactions once the weight of signatures reaches the threshold. Disabled in validator mode.
+multisig_signatures = {{ .BaseConfig.MultisigSignatures }}
+
+# Minimal increase of the gas price in percent for a transaction to replace the last pending one of the sender with the same nonce. 0 disables replacements.
+replace_by_fee_bump = {{ .BaseConfig.ReplaceByFeeBump }}
+
+# Store pending transactions of the mempool on disk and check them again after restart, dropping no longer valid ones.
+persistent_mempool = {{ .BaseConfig.PersistentMempool }}
+
 # State cache size 
 state_cache_size = {{ .BaseConfig.StateCacheSize }}
 
@@ -0,0 +1,203 @@
+package activity
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+const (
+	addressPrefix = 'a'
+	hashLength    = 32
+)
+
+var lastHeightKey = []byte("h")
+
+// Tx is a transaction involving the address
+type Tx struct {
+	Height uint64
+	Index  uint32
+	Hash   []byte
+	Type   byte
+	Code   uint32
+}
+
+// Cursor is a position in the address history, the next page starts right after it
+type Cursor struct {
+	Height uint64
+	Index  uint32
+}
+
+// String returns the cursor encoded for API responses
+func (c *Cursor) String() string {
+	return hex.EncodeToString(c.bytes())
+}
+
+func (c *Cursor) bytes() []byte {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b, c.Height)
+	binary.BigEndian.PutUint32(b[8:], c.Index)
+	return b
+}
+
+// ParseCursor decodes the cursor returned by Cursor.String
+func ParseCursor(s string) (*Cursor, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 12 {
+		return nil, errors.New("invalid cursor length")
+	}
+	return &Cursor{Height: binary.BigEndian.Uint64(b), Index: binary.BigEndian.Uint32(b[8:])}, nil
+}
+
+type pendingTx struct {
+	hash      []byte
+	txType    byte
+	code      uint32
+	addresses []types.Address
+}
+
+// Index stores transactions by every address involved into them
+type Index struct {
+	lock    sync.Mutex
+	db      db.DB
+	pending []pendingTx
+}
+
+// NewIndex creates the address index in given DB
+func NewIndex(db db.DB) *Index {
+	return &Index{db: db}
+}
+
+// AddTx adds the transaction of the current block, transactions should be added in the block order
+func (idx *Index) AddTx(hash []byte, txType byte, code uint32, addresses []types.Address) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.pending = append(idx.pending, pendingTx{hash: hash, txType: txType, code: code, addresses: addresses})
+}
+
+// Commit writes transactions added since the previous commit as the block of given height
+func (idx *Index) Commit(height uint64) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	batch := idx.db.NewBatch()
+	defer batch.Close()
+
+	for i, tx := range idx.pending {
+		position := &Cursor{Height: height, Index: uint32(i)}
+		value := make([]byte, 1+4+len(tx.hash))
+		value[0] = tx.txType
+		binary.BigEndian.PutUint32(value[1:], tx.code)
+		copy(value[5:], tx.hash)
+		for _, address := range tx.addresses {
+			if err := batch.Set(addressKey(address, position), value); err != nil {
+				return err
+			}
+		}
+	}
+
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+	if err := batch.Set(lastHeightKey, heightBytes); err != nil {
+		return err
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	idx.pending = nil
+	return nil
+}
+
+// LastHeight returns the height of the last committed block, zero if nothing is indexed
+func (idx *Index) LastHeight() (uint64, error) {
+	value, err := idx.db.Get(lastHeightKey)
+	if err != nil || len(value) != 8 {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+// History returns up to limit transactions of the address after the cursor (from the beginning if cursor is nil),
+// in ascending order or in descending if desc is true. Empty txTypes matches any type.
+// The returned cursor points to the last transaction of the page and is nil if there are no more transactions.
+func (idx *Index) History(address types.Address, cursor *Cursor, limit int, desc bool, txTypes []byte) ([]Tx, *Cursor, error) {
+	if limit <= 0 {
+		return nil, nil, errors.New("limit should be positive")
+	}
+
+	start := addressKey(address, &Cursor{})
+	end := addressKey(address, &Cursor{Height: ^uint64(0), Index: ^uint32(0)})
+	var it db.Iterator
+	var err error
+	if desc {
+		if cursor != nil {
+			end = addressKey(address, cursor)
+		}
+		it, err = idx.db.ReverseIterator(start, end)
+	} else {
+		if cursor != nil {
+			start = addressKey(address, &Cursor{Height: cursor.Height, Index: cursor.Index + 1})
+			if cursor.Index == ^uint32(0) {
+				start = addressKey(address, &Cursor{Height: cursor.Height + 1})
+			}
+		}
+		it, err = idx.db.Iterator(start, end)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer it.Close()
+
+	var txs []Tx
+	for ; it.Valid(); it.Next() {
+		value := it.Value()
+		if len(value) != 5+hashLength {
+			continue
+		}
+		if len(txTypes) != 0 && !containsType(txTypes, value[0]) {
+			continue
+		}
+
+		if len(txs) == limit {
+			last := txs[len(txs)-1]
+			return txs, &Cursor{Height: last.Height, Index: last.Index}, nil
+		}
+
+		key := it.Key()[1+types.AddressLength:]
+		txs = append(txs, Tx{
+			Height: binary.BigEndian.Uint64(key),
+			Index:  binary.BigEndian.Uint32(key[8:]),
+			Type:   value[0],
+			Code:   binary.BigEndian.Uint32(value[1:]),
+			Hash:   append([]byte(nil), value[5:]...),
+		})
+	}
+
+	return txs, nil, it.Error()
+}
+
+func containsType(txTypes []byte, txType byte) bool {
+	for _, t := range txTypes {
+		if t == txType {
+			return true
+		}
+	}
+	return false
+}
+
+func addressKey(address types.Address, position *Cursor) []byte {
+	key := make([]byte, 0, 1+types.AddressLength+12)
+	key = append(key, addressPrefix)
+	key = append(key, address.Bytes()...)
+	return append(key, position.bytes()...)
+}
@@ -0,0 +1,71 @@
+package activity
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestIndex_History(t *testing.T) {
+	index := NewIndex(db.NewMemDB())
+	alice := types.Address{1}
+	bob := types.Address{2}
+
+	hash := func(b byte) []byte {
+		h := make([]byte, hashLength)
+		h[0] = b
+		return h
+	}
+
+	index.AddTx(hash(1), 0x01, 0, []types.Address{alice, bob})
+	index.AddTx(hash(2), 0x02, 0, []types.Address{bob})
+	if err := index.Commit(10); err != nil {
+		t.Fatal(err)
+	}
+	index.AddTx(hash(3), 0x01, 107, []types.Address{alice})
+	index.AddTx(hash(4), 0x02, 0, []types.Address{alice})
+	if err := index.Commit(11); err != nil {
+		t.Fatal(err)
+	}
+
+	if lastHeight, err := index.LastHeight(); err != nil || lastHeight != 11 {
+		t.Fatalf("unexpected last height %d, %v", lastHeight, err)
+	}
+
+	txs, cursor, err := index.History(alice, nil, 2, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 || txs[0].Hash[0] != 1 || txs[1].Hash[0] != 3 || txs[1].Code != 107 || cursor == nil {
+		t.Fatalf("unexpected first page %#v, %v", txs, cursor)
+	}
+
+	parsed, err := ParseCursor(cursor.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs, cursor, err = index.History(alice, parsed, 2, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].Hash[0] != 4 || txs[0].Height != 11 || txs[0].Index != 1 || cursor != nil {
+		t.Fatalf("unexpected last page %#v, %v", txs, cursor)
+	}
+
+	txs, cursor, err = index.History(alice, &Cursor{Height: 11, Index: 1}, 10, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 || txs[0].Hash[0] != 3 || txs[1].Hash[0] != 1 || cursor != nil {
+		t.Fatalf("unexpected descending page %#v, %v", txs, cursor)
+	}
+
+	txs, _, err = index.History(bob, nil, 10, true, []byte{0x02})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].Hash[0] != 2 {
+		t.Fatalf("unexpected filtered page %#v", txs)
+	}
+}
@@ -30,6 +30,9 @@ const (
 	WrongUpdateVersionName       uint32 = 122
 	WrongDueHeight               uint32 = 123
 	Unavailable                  uint32 = 124
+	WrongBatch                   uint32 = 125
+	WrongFeePayer                uint32 = 126
+	TxExpired                    uint32 = 127
 
 	// coin creation
 	CoinHasNotReserve uint32 = 200
@@ -74,6 +77,8 @@ const (
 	TooHighGasPrice  uint32 = 504
 	WrongGasCoin     uint32 = 505
 	TooLongNonce     uint32 = 506
+	WrongChecksCount uint32 = 507
+	IsNotCheckIssuer uint32 = 508
 
 	// multisig
 	IncorrectWeights                  uint32 = 601
@@ -107,6 +112,39 @@ const (
 	CoinIsNotToken  uint32 = 800
 	CoinNotMintable uint32 = 801
 	CoinNotBurnable uint32 = 802
+
+	// htlc
+	HTLCAlreadyExists uint32 = 900
+	HTLCNotExists     uint32 = 901
+	HTLCExpired       uint32 = 902
+	HTLCNotExpired    uint32 = 903
+	WrongHTLC         uint32 = 904
+
+	// vesting
+	WrongVestingSchedule    uint32 = 910
+	TooManyVestingSchedules uint32 = 911
+
+	// allowance
+	AllowanceNotExists    uint32 = 920
+	InsufficientAllowance uint32 = 921
+	WrongAllowance        uint32 = 922
+
+	// recurring payment
+	RecurringPaymentNotExists    uint32 = 930
+	WrongRecurringPayment        uint32 = 931
+	IsNotPayerOfRecurringPayment uint32 = 932
+
+	// multisig proposal
+	MultisigProposalNotExists       uint32 = 940
+	WrongMultisigProposal           uint32 = 941
+	IsNotMultisigMember             uint32 = 942
+	MultisigProposalAlreadyApproved uint32 = 943
+	IsNotProposerOfMultisigProposal uint32 = 944
+
+	// network params
+	WrongParam              uint32 = 950
+	ParamsProposalNotExists uint32 = 951
+	ParamsProposalExists    uint32 = 952
 )
 
 func NewInsufficientLiquidityBalance(liquidity, amount0, coin0, amount1, coin1, requestedLiquidity string) *insufficientLiquidityBalance {
@@ -440,6 +478,16 @@ func NewWrongChainID(currentChainId string, gotChainId string) *wrongChainID {
 	return &wrongChainID{Code: strconv.Itoa(int(WrongChainID)), CurrentChainId: currentChainId, GotChainId: gotChainId}
 }
 
+type txExpired struct {
+	Code         string `json:"code,omitempty"`
+	ValidUntil   string `json:"valid_until,omitempty"`
+	CurrentBlock string `json:"current_block,omitempty"`
+}
+
+func NewTxExpired(validUntil string, currentBlock string) *txExpired {
+	return &txExpired{Code: strconv.Itoa(int(TxExpired)), ValidUntil: validUntil, CurrentBlock: currentBlock}
+}
+
 type coinReserveUnderflow struct {
 	Code           string `json:"code,omitempty"`
 	Delta          string `json:"delta,omitempty"`
@@ -940,3 +988,129 @@ func NewEqualPubKey(pubKey string) *equalPubKey {
 		PublicKey: pubKey,
 	}
 }
+
+type htlcCode struct {
+	Code     string `json:"code,omitempty"`
+	HashLock string `json:"hash_lock"`
+	Timeout  string `json:"timeout,omitempty"`
+}
+
+func NewHTLCAlreadyExists(hashLock string) *htlcCode {
+	return &htlcCode{Code: strconv.Itoa(int(HTLCAlreadyExists)), HashLock: hashLock}
+}
+
+func NewHTLCNotExists(hashLock string) *htlcCode {
+	return &htlcCode{Code: strconv.Itoa(int(HTLCNotExists)), HashLock: hashLock}
+}
+
+func NewHTLCExpired(hashLock string, timeout string) *htlcCode {
+	return &htlcCode{Code: strconv.Itoa(int(HTLCExpired)), HashLock: hashLock, Timeout: timeout}
+}
+
+func NewHTLCNotExpired(hashLock string, timeout string) *htlcCode {
+	return &htlcCode{Code: strconv.Itoa(int(HTLCNotExpired)), HashLock: hashLock, Timeout: timeout}
+}
+
+type wrongVestingSchedule struct {
+	Code       string `json:"code,omitempty"`
+	CliffBlock string `json:"cliff_block"`
+	EndBlock   string `json:"end_block"`
+	Steps      string `json:"steps"`
+}
+
+func NewWrongVestingSchedule(cliffBlock string, endBlock string, steps string) *wrongVestingSchedule {
+	return &wrongVestingSchedule{Code: strconv.Itoa(int(WrongVestingSchedule)), CliffBlock: cliffBlock, EndBlock: endBlock, Steps: steps}
+}
+
+type tooManyVestingSchedules struct {
+	Code         string `json:"code,omitempty"`
+	Address      string `json:"address"`
+	MaxSchedules string `json:"max_schedules"`
+}
+
+func NewTooManyVestingSchedules(address string, maxSchedules string) *tooManyVestingSchedules {
+	return &tooManyVestingSchedules{Code: strconv.Itoa(int(TooManyVestingSchedules)), Address: address, MaxSchedules: maxSchedules}
+}
+
+type allowanceCode struct {
+	Code      string `json:"code,omitempty"`
+	Owner     string `json:"owner"`
+	Spender   string `json:"spender"`
+	CoinID    string `json:"coin_id"`
+	Allowance string `json:"allowance,omitempty"`
+	Needed    string `json:"needed,omitempty"`
+}
+
+func NewAllowanceNotExists(owner string, spender string, coinID string) *allowanceCode {
+	return &allowanceCode{Code: strconv.Itoa(int(AllowanceNotExists)), Owner: owner, Spender: spender, CoinID: coinID}
+}
+
+func NewInsufficientAllowance(owner string, spender string, coinID string, allowance string, needed string) *allowanceCode {
+	return &allowanceCode{Code: strconv.Itoa(int(InsufficientAllowance)), Owner: owner, Spender: spender, CoinID: coinID, Allowance: allowance, Needed: needed}
+}
+
+type recurringPaymentCode struct {
+	Code  string `json:"code,omitempty"`
+	ID    string `json:"id"`
+	Payer string `json:"payer,omitempty"`
+}
+
+func NewRecurringPaymentNotExists(id string) *recurringPaymentCode {
+	return &recurringPaymentCode{Code: strconv.Itoa(int(RecurringPaymentNotExists)), ID: id}
+}
+
+func NewIsNotPayerOfRecurringPayment(id string, payer string) *recurringPaymentCode {
+	return &recurringPaymentCode{Code: strconv.Itoa(int(IsNotPayerOfRecurringPayment)), ID: id, Payer: payer}
+}
+
+type multisigProposalCode struct {
+	Code     string `json:"code,omitempty"`
+	ID       string `json:"id,omitempty"`
+	Multisig string `json:"multisig,omitempty"`
+	Address  string `json:"address,omitempty"`
+}
+
+func NewMultisigProposalNotExists(id string) *multisigProposalCode {
+	return &multisigProposalCode{Code: strconv.Itoa(int(MultisigProposalNotExists)), ID: id}
+}
+
+func NewIsNotMultisigMember(multisig string, address string) *multisigProposalCode {
+	return &multisigProposalCode{Code: strconv.Itoa(int(IsNotMultisigMember)), Multisig: multisig, Address: address}
+}
+
+func NewMultisigProposalAlreadyApproved(id string, address string) *multisigProposalCode {
+	return &multisigProposalCode{Code: strconv.Itoa(int(MultisigProposalAlreadyApproved)), ID: id, Address: address}
+}
+
+func NewIsNotProposerOfMultisigProposal(id string, address string) *multisigProposalCode {
+	return &multisigProposalCode{Code: strconv.Itoa(int(IsNotProposerOfMultisigProposal)), ID: id, Address: address}
+}
+
+type isNotCheckIssuer struct {
+	Code   string `json:"code,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+	Sender string `json:"sender,omitempty"`
+}
+
+func NewIsNotCheckIssuer(issuer string, sender string) *isNotCheckIssuer {
+	return &isNotCheckIssuer{Code: strconv.Itoa(int(IsNotCheckIssuer)), Issuer: issuer, Sender: sender}
+}
+
+type paramsCode struct {
+	Code   string `json:"code,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Height string `json:"height,omitempty"`
+}
+
+func NewWrongParam(name string, value string) *paramsCode {
+	return &paramsCode{Code: strconv.Itoa(int(WrongParam)), Name: name, Value: value}
+}
+
+func NewParamsProposalNotExists(height string, name string, value string) *paramsCode {
+	return &paramsCode{Code: strconv.Itoa(int(ParamsProposalNotExists)), Height: height, Name: name, Value: value}
+}
+
+func NewParamsProposalExists(height string, name string, value string) *paramsCode {
+	return &paramsCode{Code: strconv.Itoa(int(ParamsProposalExists)), Height: height, Name: name, Value: value}
+}
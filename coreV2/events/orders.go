@@ -0,0 +1,76 @@
+package events
+
+import (
+	"encoding/binary"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+const orderPrefix = "order"
+
+// OrdersIndex is implemented by events stores which index the lifecycle events of limit orders by their owners
+type OrdersIndex interface {
+	// OrderHeights returns heights of the blocks with events of the address orders by order id
+	OrderHeights(address types.Address) (map[uint32][]uint32, error)
+}
+
+type orderEvent interface {
+	address() types.Address
+	orderID() uint32
+}
+
+func orderKey(address types.Address, id uint32) []byte {
+	return append(append([]byte(orderPrefix), address[:]...), uint32ToBytes(id)...)
+}
+
+// saveOrderHeights appends the height to the heights of orders changed by the events
+func (store *eventsStore) saveOrderHeights(height uint32, items Events) error {
+	saved := map[string]struct{}{}
+	for _, item := range items {
+		order, ok := item.(orderEvent)
+		if !ok {
+			continue
+		}
+		key := orderKey(order.address(), order.orderID())
+		if _, ok := saved[string(key)]; ok {
+			continue
+		}
+		saved[string(key)] = struct{}{}
+
+		heights, err := store.db.Get(key)
+		if err != nil {
+			return err
+		}
+		if err := store.db.Set(key, append(heights, uint32ToBytes(height)...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (store *eventsStore) OrderHeights(address types.Address) (map[uint32][]uint32, error) {
+	store.RLock()
+	defer store.RUnlock()
+
+	prefix := append([]byte(orderPrefix), address[:]...)
+	iterator, err := db.IteratePrefix(store.db, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	result := map[uint32][]uint32{}
+	for ; iterator.Valid(); iterator.Next() {
+		key, value := iterator.Key(), iterator.Value()
+		if len(key) != len(prefix)+4 {
+			continue
+		}
+		id := binary.BigEndian.Uint32(key[len(prefix):])
+		for i := 0; i+4 <= len(value); i += 4 {
+			result[id] = append(result[id], binary.BigEndian.Uint32(value[i:]))
+		}
+	}
+
+	return result, iterator.Error()
+}
@@ -0,0 +1,53 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestEventsStore_OrderHeights(t *testing.T) {
+	store := NewEventsStore(db.NewMemDB())
+	owner := types.HexToAddress("Mx04bea23efb744dc93b4fda4c20bf4a21c6e195f1")
+	other := types.HexToAddress("Mx18467bbb64a8edf890201d526c35957d82be3d95")
+
+	store.AddEvent(&OrderCreatedEvent{ID: 5, Address: owner, ValueSell: "10", ValueBuy: "20"})
+	store.AddEvent(&OrderCreatedEvent{ID: 6, Address: other, ValueSell: "10", ValueBuy: "20"})
+	if err := store.CommitEvents(10); err != nil {
+		t.Fatal(err)
+	}
+
+	store.AddEvent(&OrderPartiallyFilledEvent{ID: 5, Address: owner, Sold: "1", Bought: "2"})
+	store.AddEvent(&OrderFilledEvent{ID: 5, Address: owner, Sold: "9", Bought: "18"})
+	if err := store.CommitEvents(11); err != nil {
+		t.Fatal(err)
+	}
+
+	store.AddEvent(&OrderExpiredEvent{ID: 7, Address: owner, Amount: "1"})
+	if err := store.CommitEvents(12); err != nil {
+		t.Fatal(err)
+	}
+
+	heights, err := store.(OrdersIndex).OrderHeights(owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(heights) != 2 {
+		t.Fatalf("expected 2 orders, got %v", heights)
+	}
+	if h := heights[5]; len(h) != 2 || h[0] != 10 || h[1] != 11 {
+		t.Fatalf("unexpected heights of order 5: %v", h)
+	}
+	if h := heights[7]; len(h) != 1 || h[0] != 12 {
+		t.Fatalf("unexpected heights of order 7: %v", h)
+	}
+
+	events := store.LoadEvents(11)
+	if len(events) != 2 || events[1].Type() != TypeOrderFilledEvent {
+		t.Fatalf("unexpected events %#v", events)
+	}
+	if filled := events[1].(*OrderFilledEvent); filled.Address != owner || filled.Sold != "9" {
+		t.Fatalf("unexpected filled event %#v", filled)
+	}
+}
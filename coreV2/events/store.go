@@ -27,11 +27,18 @@ func init() {
 	tmjson.RegisterType(&StakeMoveEvent{}, TypeStakeMoveEvent)
 	tmjson.RegisterType(&StakeKickEvent{}, TypeStakeKickEvent)
 	tmjson.RegisterType(&UpdateNetworkEvent{}, TypeUpdateNetworkEvent)
+	tmjson.RegisterType(&UpdateParamsEvent{}, TypeUpdateParamsEvent)
 	tmjson.RegisterType(&UpdateCommissionsEvent{}, TypeUpdateCommissionsEvent)
 	tmjson.RegisterType(&OrderExpiredEvent{}, TypeOrderExpiredEvent)
 	tmjson.RegisterType(&RemoveCandidateEvent{}, TypeRemoveCandidateEvent)
 	tmjson.RegisterType(&UpdatedBlockRewardEvent{}, TypeUpdatedBlockRewardEvent)
 	tmjson.RegisterType(&UnlockEvent{}, TypeUnlockEvent)
+	tmjson.RegisterType(&OrderCreatedEvent{}, TypeOrderCreatedEvent)
+	tmjson.RegisterType(&OrderPartiallyFilledEvent{}, TypeOrderPartiallyFilledEvent)
+	tmjson.RegisterType(&OrderFilledEvent{}, TypeOrderFilledEvent)
+	tmjson.RegisterType(&OrderCanceledEvent{}, TypeOrderCanceledEvent)
+	tmjson.RegisterType(&RecurringPaymentEvent{}, TypeRecurringPaymentEvent)
+	tmjson.RegisterType(&RecurringPaymentFailedEvent{}, TypeRecurringPaymentFailedEvent)
 }
 
 // IEventsDB is an interface of Events
@@ -59,6 +66,8 @@ type eventsStore struct {
 	pubKeyID  map[[32]byte]uint16
 	idAddress map[uint32][20]byte
 	addressID map[[20]byte]uint32
+
+	subscriptions subscriptions
 }
 
 type pendingEvents struct {
@@ -192,6 +201,10 @@ func (store *eventsStore) CommitEvents(height uint32) error {
 	if err := store.db.Set(uint32ToBytes(height), bytes); err != nil {
 		return err
 	}
+	if err := store.saveOrderHeights(height, store.pending.items); err != nil {
+		return err
+	}
+	store.subscriptions.publish(height, store.pending.items)
 	store.pending.items = Events{}
 	return nil
 }
@@ -0,0 +1,149 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// HeightEvents are events committed at the height
+type HeightEvents struct {
+	Height uint32
+	Events Events
+}
+
+// Subscriber is implemented by events stores which notify about committed events
+type Subscriber interface {
+	// SubscribeEvents returns a channel of events committed after the call and a function to unsubscribe.
+	// The channel is closed if the subscriber does not keep up with new heights, it should reload missed heights with LoadEvents.
+	SubscribeEvents(capacity int) (<-chan HeightEvents, func())
+}
+
+type subscriptions struct {
+	sync.Mutex
+	nextID uint64
+	items  map[uint64]chan HeightEvents
+}
+
+func (s *subscriptions) subscribe(capacity int) (<-chan HeightEvents, func()) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.items == nil {
+		s.items = make(map[uint64]chan HeightEvents)
+	}
+
+	id := s.nextID
+	s.nextID++
+	ch := make(chan HeightEvents, capacity)
+	s.items[id] = ch
+
+	return ch, func() {
+		s.Lock()
+		defer s.Unlock()
+
+		if ch, ok := s.items[id]; ok {
+			delete(s.items, id)
+			close(ch)
+		}
+	}
+}
+
+func (s *subscriptions) publish(height uint32, events Events) {
+	s.Lock()
+	defer s.Unlock()
+
+	for id, ch := range s.items {
+		select {
+		case ch <- HeightEvents{Height: height, Events: events}:
+		default:
+			delete(s.items, id)
+			close(ch)
+		}
+	}
+}
+
+func (store *eventsStore) SubscribeEvents(capacity int) (<-chan HeightEvents, func()) {
+	return store.subscriptions.subscribe(capacity)
+}
+
+// Filter selects events by type, address and validator public key.
+// Empty list matches any value, otherwise the event should match at least one value of each non-empty list.
+type Filter struct {
+	Types     []string
+	Addresses []types.Address
+	PubKeys   []types.Pubkey
+}
+
+// Match reports whether the event satisfies the filter
+func (f *Filter) Match(event Event) bool {
+	if len(f.Types) != 0 && !f.matchType(event.Type()) {
+		return false
+	}
+
+	if len(f.Addresses) != 0 {
+		e, ok := event.(interface{ address() types.Address })
+		if !ok {
+			return false
+		}
+		if !f.matchAddress(e.address()) {
+			return false
+		}
+	}
+
+	if len(f.PubKeys) != 0 {
+		var find bool
+		for _, pubkey := range eventPubKeys(event) {
+			if f.matchPubKey(pubkey) {
+				find = true
+				break
+			}
+		}
+		if !find {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *Filter) matchType(eventType string) bool {
+	for _, t := range f.Types {
+		if t == eventType || "minter/"+t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) matchAddress(address types.Address) bool {
+	for _, a := range f.Addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) matchPubKey(pubkey types.Pubkey) bool {
+	for _, p := range f.PubKeys {
+		if p == pubkey {
+			return true
+		}
+	}
+	return false
+}
+
+func eventPubKeys(event Event) []types.Pubkey {
+	switch e := event.(type) {
+	case *StakeMoveEvent:
+		return []types.Pubkey{e.CandidatePubKey, e.ToCandidatePubKey}
+	case *RemoveCandidateEvent:
+		return []types.Pubkey{e.CandidatePubKey}
+	case interface{ validatorPubKey() *types.Pubkey }:
+		if pubkey := e.validatorPubKey(); pubkey != nil {
+			return []types.Pubkey{*pubkey}
+		}
+	}
+	return nil
+}
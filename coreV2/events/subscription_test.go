@@ -0,0 +1,77 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestEventsStore_SubscribeEvents(t *testing.T) {
+	store := NewEventsStore(db.NewMemDB())
+	subscriber, ok := store.(Subscriber)
+	if !ok {
+		t.Fatal("events store is not a subscriber")
+	}
+
+	ch, unsubscribe := subscriber.SubscribeEvents(1)
+	defer unsubscribe()
+
+	address := types.HexToAddress("Mx04bea23efb744dc93b4fda4c20bf4a21c6e195f1")
+	store.AddEvent(&UnlockEvent{Address: address, Amount: "1", Coin: 0})
+	if err := store.CommitEvents(10); err != nil {
+		t.Fatal(err)
+	}
+
+	committed := <-ch
+	if committed.Height != 10 || len(committed.Events) != 1 {
+		t.Fatalf("unexpected events %#v", committed)
+	}
+
+	if err := store.CommitEvents(11); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CommitEvents(12); err != nil {
+		t.Fatal(err)
+	}
+
+	if committed := <-ch; committed.Height != 11 {
+		t.Fatalf("unexpected height %d", committed.Height)
+	}
+	if _, ok := <-ch; ok {
+		t.Fatal("slow subscription should be closed")
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	address := types.HexToAddress("Mx04bea23efb744dc93b4fda4c20bf4a21c6e195f1")
+	pubkey := types.HexToPubkey("Mp9e13f2f5468dd782b316444fbd66595e13dba7d7bd3efa1becd50b42045f58c6")
+	toPubkey := types.HexToPubkey("Mp0003f2f5468dd782b316444fbd66595e13dba7d7bd3efa1becd50b42045f5000")
+
+	reward := &RewardEvent{Role: RoleDAO.String(), Address: address, Amount: "1", ValidatorPubKey: pubkey}
+	move := &StakeMoveEvent{Address: types.Address{1}, Amount: "1", CandidatePubKey: pubkey, ToCandidatePubKey: toPubkey}
+	unlock := &UnlockEvent{Address: address, Amount: "1"}
+
+	tests := []struct {
+		filter Filter
+		event  Event
+		match  bool
+	}{
+		{Filter{}, reward, true},
+		{Filter{Types: []string{TypeRewardEvent}}, reward, true},
+		{Filter{Types: []string{"RewardEvent"}}, reward, true},
+		{Filter{Types: []string{"SlashEvent"}}, reward, false},
+		{Filter{Addresses: []types.Address{address}}, reward, true},
+		{Filter{Addresses: []types.Address{address}}, move, false},
+		{Filter{PubKeys: []types.Pubkey{toPubkey}}, move, true},
+		{Filter{PubKeys: []types.Pubkey{pubkey}}, unlock, false},
+		{Filter{Types: []string{"UnlockEvent"}, Addresses: []types.Address{address}}, unlock, true},
+		{Filter{Types: []string{"UnlockEvent"}, Addresses: []types.Address{{2}}}, unlock, false},
+	}
+
+	for i, test := range tests {
+		if match := test.filter.Match(test.event); match != test.match {
+			t.Errorf("case %d: expected %t, got %t", i, test.match, match)
+		}
+	}
+}
@@ -17,10 +17,19 @@ const (
 	TypeStakeKickEvent          = "minter/StakeKickEvent"
 	TypeStakeMoveEvent          = "minter/StakeMoveEvent"
 	TypeUpdateNetworkEvent      = "minter/UpdateNetworkEvent"
+	TypeUpdateParamsEvent       = "minter/UpdateParamsEvent"
 	TypeUpdateCommissionsEvent  = "minter/UpdateCommissionsEvent"
 	TypeOrderExpiredEvent       = "minter/OrderExpiredEvent"
 	TypeRemoveCandidateEvent    = "minter/RemoveCandidateEvent"
 	TypeUpdatedBlockRewardEvent = "minter/UpdatedBlockRewardEvent"
+
+	TypeOrderCreatedEvent         = "minter/OrderCreatedEvent"
+	TypeOrderPartiallyFilledEvent = "minter/OrderPartiallyFilledEvent"
+	TypeOrderFilledEvent          = "minter/OrderFilledEvent"
+	TypeOrderCanceledEvent        = "minter/OrderCanceledEvent"
+
+	TypeRecurringPaymentEvent       = "minter/RecurringPaymentEvent"
+	TypeRecurringPaymentFailedEvent = "minter/RecurringPaymentFailedEvent"
 )
 
 type Stake interface {
@@ -280,6 +289,10 @@ func (oe *OrderExpiredEvent) address() types.Address {
 	return oe.Address
 }
 
+func (oe *OrderExpiredEvent) orderID() uint32 {
+	return uint32(oe.ID)
+}
+
 func (oe *OrderExpiredEvent) Type() string {
 	return TypeOrderExpiredEvent
 }
@@ -622,6 +635,16 @@ func (un *UpdateNetworkEvent) Type() string {
 	return TypeUpdateNetworkEvent
 }
 
+// UpdateParamsEvent is the value of the network parameter applied by votes of validators
+type UpdateParamsEvent struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (ue *UpdateParamsEvent) Type() string {
+	return TypeUpdateParamsEvent
+}
+
 type removeCandidate struct {
 	PubKeyID uint16
 }
@@ -667,3 +690,153 @@ type UpdatedBlockRewardEvent struct {
 func (pe *UpdatedBlockRewardEvent) Type() string {
 	return TypeUpdatedBlockRewardEvent
 }
+
+// OrderCreatedEvent is the limit order placed by the transaction
+type OrderCreatedEvent struct {
+	ID        uint64        `json:"id"`
+	Address   types.Address `json:"address"`
+	CoinSell  uint64        `json:"coin_sell"`
+	ValueSell string        `json:"value_sell"`
+	CoinBuy   uint64        `json:"coin_buy"`
+	ValueBuy  string        `json:"value_buy"`
+	TxHash    string        `json:"tx_hash"`
+}
+
+func (oe *OrderCreatedEvent) Type() string {
+	return TypeOrderCreatedEvent
+}
+
+func (oe *OrderCreatedEvent) AddressString() string {
+	return oe.Address.String()
+}
+
+func (oe *OrderCreatedEvent) address() types.Address {
+	return oe.Address
+}
+
+func (oe *OrderCreatedEvent) orderID() uint32 {
+	return uint32(oe.ID)
+}
+
+// OrderPartiallyFilledEvent is a part of the order filled by the transaction, the order remains in the pool
+type OrderPartiallyFilledEvent struct {
+	ID       uint64        `json:"id"`
+	Address  types.Address `json:"address"`
+	CoinSell uint64        `json:"coin_sell"`
+	Sold     string        `json:"sold"`
+	CoinBuy  uint64        `json:"coin_buy"`
+	Bought   string        `json:"bought"`
+	TxHash   string        `json:"tx_hash"`
+}
+
+func (oe *OrderPartiallyFilledEvent) Type() string {
+	return TypeOrderPartiallyFilledEvent
+}
+
+func (oe *OrderPartiallyFilledEvent) AddressString() string {
+	return oe.Address.String()
+}
+
+func (oe *OrderPartiallyFilledEvent) address() types.Address {
+	return oe.Address
+}
+
+func (oe *OrderPartiallyFilledEvent) orderID() uint32 {
+	return uint32(oe.ID)
+}
+
+// OrderFilledEvent is the last part of the order filled by the transaction
+type OrderFilledEvent struct {
+	ID       uint64        `json:"id"`
+	Address  types.Address `json:"address"`
+	CoinSell uint64        `json:"coin_sell"`
+	Sold     string        `json:"sold"`
+	CoinBuy  uint64        `json:"coin_buy"`
+	Bought   string        `json:"bought"`
+	TxHash   string        `json:"tx_hash"`
+}
+
+func (oe *OrderFilledEvent) Type() string {
+	return TypeOrderFilledEvent
+}
+
+func (oe *OrderFilledEvent) AddressString() string {
+	return oe.Address.String()
+}
+
+func (oe *OrderFilledEvent) address() types.Address {
+	return oe.Address
+}
+
+func (oe *OrderFilledEvent) orderID() uint32 {
+	return uint32(oe.ID)
+}
+
+// OrderCanceledEvent is the limit order removed by its owner, Amount is the returned remainder
+type OrderCanceledEvent struct {
+	ID      uint64        `json:"id"`
+	Address types.Address `json:"address"`
+	Coin    uint64        `json:"coin"`
+	Amount  string        `json:"amount"`
+	TxHash  string        `json:"tx_hash"`
+}
+
+func (oe *OrderCanceledEvent) Type() string {
+	return TypeOrderCanceledEvent
+}
+
+func (oe *OrderCanceledEvent) AddressString() string {
+	return oe.Address.String()
+}
+
+func (oe *OrderCanceledEvent) address() types.Address {
+	return oe.Address
+}
+
+func (oe *OrderCanceledEvent) orderID() uint32 {
+	return uint32(oe.ID)
+}
+
+// RecurringPaymentEvent is the payment of the standing order made at the end of the block, Remaining payments are left
+type RecurringPaymentEvent struct {
+	ID        uint64        `json:"id"`
+	Address   types.Address `json:"address"`
+	Recipient types.Address `json:"recipient"`
+	Coin      uint64        `json:"coin"`
+	Amount    string        `json:"amount"`
+	Remaining uint64        `json:"remaining"`
+}
+
+func (re *RecurringPaymentEvent) Type() string {
+	return TypeRecurringPaymentEvent
+}
+
+func (re *RecurringPaymentEvent) AddressString() string {
+	return re.Address.String()
+}
+
+func (re *RecurringPaymentEvent) address() types.Address {
+	return re.Address
+}
+
+// RecurringPaymentFailedEvent is the payment of the standing order skipped for lack of spendable funds of the payer
+type RecurringPaymentFailedEvent struct {
+	ID        uint64        `json:"id"`
+	Address   types.Address `json:"address"`
+	Recipient types.Address `json:"recipient"`
+	Coin      uint64        `json:"coin"`
+	Amount    string        `json:"amount"`
+	Remaining uint64        `json:"remaining"`
+}
+
+func (re *RecurringPaymentFailedEvent) Type() string {
+	return TypeRecurringPaymentFailedEvent
+}
+
+func (re *RecurringPaymentFailedEvent) AddressString() string {
+	return re.Address.String()
+}
+
+func (re *RecurringPaymentFailedEvent) address() types.Address {
+	return re.Address
+}
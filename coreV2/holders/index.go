@@ -0,0 +1,527 @@
+package holders
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	db "github.com/tendermint/tm-db"
+)
+
+const (
+	holderPrefix       = 'h'
+	richListPrefix     = 'r'
+	countPrefix        = 'n'
+	distributionPrefix = 'd'
+	stakesPrefix       = 'c'
+
+	amountLength = 32
+)
+
+var lastHeightKey = []byte("height")
+
+// unit is the amount of one coin, distribution buckets are powers of ten of whole coins
+var unit = big.NewInt(1e18)
+
+// Holder is the address holding the coin on the balance or in stakes
+type Holder struct {
+	Address types.Address
+	Balance *big.Int
+	Stake   *big.Int
+}
+
+// Total returns the sum of the balance and stakes of the holder
+func (h *Holder) Total() *big.Int {
+	return big.NewInt(0).Add(h.Balance, h.Stake)
+}
+
+// Bucket is the range of holdings in the coin distribution.
+// Bucket 0 contains holders of less than one coin, bucket N contains holders of [10^(N-1), 10^N) coins.
+type Bucket struct {
+	Bucket  uint8
+	Holders uint64
+	Amount  *big.Int
+}
+
+// From returns the lowest amount of the bucket in pip
+func (b *Bucket) From() *big.Int {
+	if b.Bucket == 0 {
+		return big.NewInt(0)
+	}
+	return bucketAmount(b.Bucket - 1)
+}
+
+// To returns the amount in pip following the highest amount of the bucket
+func (b *Bucket) To() *big.Int {
+	return bucketAmount(b.Bucket)
+}
+
+type holding struct {
+	Balance *big.Int
+	Stake   *big.Int
+}
+
+type stake struct {
+	Owner types.Address
+	Coin  types.CoinID
+	Value *big.Int
+}
+
+type holderKey struct {
+	coin    types.CoinID
+	address types.Address
+}
+
+// Index stores holders of every coin with their balances and stakes.
+// It is updated incrementally from balances and stakes saved by the state at commit.
+type Index struct {
+	lock     sync.Mutex
+	db       db.DB
+	balances map[holderKey]*big.Int
+	stakes   map[uint32][]*bus.Stake
+}
+
+// NewIndex creates the coin holders index in given DB
+func NewIndex(db db.DB) *Index {
+	return &Index{
+		db:       db,
+		balances: map[holderKey]*big.Int{},
+		stakes:   map[uint32][]*bus.Stake{},
+	}
+}
+
+// SetBalance sets the balance of the address saved in the current block
+func (idx *Index) SetBalance(address types.Address, coin types.CoinID, value *big.Int) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.balances[holderKey{coin: coin, address: address}] = big.NewInt(0).Set(value)
+}
+
+// SetCandidateStakes sets all stakes of the candidate saved in the current block, nil stakes remove the candidate
+func (idx *Index) SetCandidateStakes(candidateID uint32, stakes []*bus.Stake) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.stakes[candidateID] = stakes
+}
+
+// Commit applies balances and stakes set since the previous commit as the block of given height
+func (idx *Index) Commit(height uint64) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	batch := idx.db.NewBatch()
+	defer batch.Close()
+
+	if err := idx.apply(batch, height); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	idx.balances = map[holderKey]*big.Int{}
+	idx.stakes = map[uint32][]*bus.Stake{}
+	return nil
+}
+
+// Rebuild replaces the whole index with balances and stakes of exported accounts and candidates at given height
+func (idx *Index) Rebuild(height uint64, accounts []types.Account, candidates []types.Candidate) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	var keys [][]byte
+	it, err := idx.db.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, append([]byte(nil), it.Key()...))
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return err
+	}
+	it.Close()
+
+	batch := idx.db.NewBatch()
+	defer batch.Close()
+
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	idx.balances = map[holderKey]*big.Int{}
+	idx.stakes = map[uint32][]*bus.Stake{}
+	for _, account := range accounts {
+		for _, balance := range account.Balance {
+			value, ok := big.NewInt(0).SetString(balance.Value, 10)
+			if !ok {
+				return errors.New("invalid balance value")
+			}
+			idx.balances[holderKey{coin: types.CoinID(balance.Coin), address: account.Address}] = value
+		}
+	}
+	for _, candidate := range candidates {
+		stakes := make([]*bus.Stake, 0, len(candidate.Stakes))
+		for _, s := range candidate.Stakes {
+			value, ok := big.NewInt(0).SetString(s.Value, 10)
+			if !ok {
+				return errors.New("invalid stake value")
+			}
+			stakes = append(stakes, &bus.Stake{Owner: s.Owner, Coin: types.CoinID(s.Coin), Value: value})
+		}
+		idx.stakes[uint32(candidate.ID)] = stakes
+	}
+
+	batch = idx.db.NewBatch()
+	defer batch.Close()
+
+	if err := idx.apply(batch, height); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	idx.balances = map[holderKey]*big.Int{}
+	idx.stakes = map[uint32][]*bus.Stake{}
+	return nil
+}
+
+// apply writes pending balances and stakes into the batch, updating rich lists, counts and distributions of touched coins
+func (idx *Index) apply(batch db.Batch, height uint64) error {
+	touched := map[holderKey]*holding{}
+	previous := map[holderKey]*holding{}
+	load := func(key holderKey) (*holding, error) {
+		if h, ok := touched[key]; ok {
+			return h, nil
+		}
+		h, err := idx.holding(key)
+		if err != nil {
+			return nil, err
+		}
+		previous[key] = &holding{Balance: big.NewInt(0).Set(h.Balance), Stake: big.NewInt(0).Set(h.Stake)}
+		touched[key] = h
+		return h, nil
+	}
+
+	for key, balance := range idx.balances {
+		h, err := load(key)
+		if err != nil {
+			return err
+		}
+		h.Balance = balance
+	}
+
+	for candidateID, stakes := range idx.stakes {
+		old, err := idx.candidateStakes(candidateID)
+		if err != nil {
+			return err
+		}
+		for _, s := range old {
+			h, err := load(holderKey{coin: s.Coin, address: s.Owner})
+			if err != nil {
+				return err
+			}
+			h.Stake.Sub(h.Stake, s.Value)
+		}
+
+		if len(stakes) == 0 {
+			if err := batch.Delete(stakesKey(candidateID)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		list := make([]*stake, 0, len(stakes))
+		for _, s := range stakes {
+			h, err := load(holderKey{coin: s.Coin, address: s.Owner})
+			if err != nil {
+				return err
+			}
+			h.Stake.Add(h.Stake, s.Value)
+			list = append(list, &stake{Owner: s.Owner, Coin: s.Coin, Value: s.Value})
+		}
+		data, err := rlp.EncodeToBytes(list)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(stakesKey(candidateID), data); err != nil {
+			return err
+		}
+	}
+
+	counts := map[types.CoinID]int64{}
+	type bucketKey struct {
+		coin   types.CoinID
+		bucket uint8
+	}
+	buckets := map[bucketKey]*Bucket{}
+	updateBucket := func(coin types.CoinID, total *big.Int, sign int64) {
+		key := bucketKey{coin: coin, bucket: bucketOf(total)}
+		b, ok := buckets[key]
+		if !ok {
+			b = &Bucket{Bucket: key.bucket, Amount: big.NewInt(0)}
+			buckets[key] = b
+		}
+		if sign > 0 {
+			b.Holders++
+			b.Amount.Add(b.Amount, total)
+		} else {
+			b.Holders--
+			b.Amount.Sub(b.Amount, total)
+		}
+	}
+
+	for key, h := range touched {
+		oldTotal := big.NewInt(0).Add(previous[key].Balance, previous[key].Stake)
+		newTotal := big.NewInt(0).Add(h.Balance, h.Stake)
+		if oldTotal.Cmp(newTotal) == 0 && previous[key].Balance.Cmp(h.Balance) == 0 {
+			continue
+		}
+
+		if oldTotal.Sign() == 1 {
+			if err := batch.Delete(richListKey(key.coin, oldTotal, key.address)); err != nil {
+				return err
+			}
+			counts[key.coin]--
+			updateBucket(key.coin, oldTotal, -1)
+		}
+
+		if newTotal.Sign() != 1 {
+			if err := batch.Delete(holderKeyBytes(key)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(h)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(holderKeyBytes(key), data); err != nil {
+			return err
+		}
+		if err := batch.Set(richListKey(key.coin, newTotal, key.address), []byte{}); err != nil {
+			return err
+		}
+		counts[key.coin]++
+		updateBucket(key.coin, newTotal, 1)
+	}
+
+	for coin, delta := range counts {
+		if delta == 0 {
+			continue
+		}
+		count, err := idx.Count(coin)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(countKey(coin), uint64ToBytes(uint64(int64(count)+delta))); err != nil {
+			return err
+		}
+	}
+
+	for key, delta := range buckets {
+		b, err := idx.bucket(key.coin, key.bucket)
+		if err != nil {
+			return err
+		}
+		b.Holders += delta.Holders
+		b.Amount.Add(b.Amount, delta.Amount)
+		if b.Holders == 0 {
+			if err := batch.Delete(distributionKey(key.coin, key.bucket)); err != nil {
+				return err
+			}
+			continue
+		}
+		data, err := rlp.EncodeToBytes(b)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(distributionKey(key.coin, key.bucket), data); err != nil {
+			return err
+		}
+	}
+
+	return batch.Set(lastHeightKey, uint64ToBytes(height))
+}
+
+// LastHeight returns the height of the last committed block, zero if nothing is indexed
+func (idx *Index) LastHeight() (uint64, error) {
+	value, err := idx.db.Get(lastHeightKey)
+	if err != nil || len(value) != 8 {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+// Top returns up to limit holders of the coin with the largest sum of the balance and stakes
+func (idx *Index) Top(coin types.CoinID, limit int) ([]*Holder, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit should be positive")
+	}
+
+	prefix := append([]byte{richListPrefix}, coinBytes(coin)...)
+	it, err := idx.db.ReverseIterator(prefix, append([]byte{richListPrefix}, coinBytes(coin+1)...))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var list []*Holder
+	for ; it.Valid() && len(list) < limit; it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+amountLength+types.AddressLength {
+			continue
+		}
+		address := types.BytesToAddress(key[len(prefix)+amountLength:])
+		h, err := idx.holding(holderKey{coin: coin, address: address})
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, &Holder{Address: address, Balance: h.Balance, Stake: h.Stake})
+	}
+
+	return list, it.Error()
+}
+
+// Count returns the number of holders of the coin
+func (idx *Index) Count(coin types.CoinID) (uint64, error) {
+	value, err := idx.db.Get(countKey(coin))
+	if err != nil || len(value) != 8 {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(value), nil
+}
+
+// Distribution returns non-empty buckets of holdings of the coin in ascending order
+func (idx *Index) Distribution(coin types.CoinID) ([]*Bucket, error) {
+	prefix := append([]byte{distributionPrefix}, coinBytes(coin)...)
+	it, err := db.IteratePrefix(idx.db, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var list []*Bucket
+	for ; it.Valid(); it.Next() {
+		b := &Bucket{}
+		if err := rlp.DecodeBytes(it.Value(), b); err != nil {
+			return nil, err
+		}
+		list = append(list, b)
+	}
+
+	return list, it.Error()
+}
+
+func (idx *Index) holding(key holderKey) (*holding, error) {
+	value, err := idx.db.Get(holderKeyBytes(key))
+	if err != nil {
+		return nil, err
+	}
+	h := &holding{Balance: big.NewInt(0), Stake: big.NewInt(0)}
+	if len(value) == 0 {
+		return h, nil
+	}
+	if err := rlp.DecodeBytes(value, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (idx *Index) candidateStakes(candidateID uint32) ([]*stake, error) {
+	value, err := idx.db.Get(stakesKey(candidateID))
+	if err != nil || len(value) == 0 {
+		return nil, err
+	}
+	var list []*stake
+	if err := rlp.DecodeBytes(value, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (idx *Index) bucket(coin types.CoinID, bucket uint8) (*Bucket, error) {
+	value, err := idx.db.Get(distributionKey(coin, bucket))
+	if err != nil {
+		return nil, err
+	}
+	b := &Bucket{Bucket: bucket, Amount: big.NewInt(0)}
+	if len(value) == 0 {
+		return b, nil
+	}
+	if err := rlp.DecodeBytes(value, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// bucketOf returns the number of digits of whole coins in the amount
+func bucketOf(amount *big.Int) uint8 {
+	coins := big.NewInt(0).Quo(amount, unit)
+	if coins.Sign() == 0 {
+		return 0
+	}
+	return uint8(len(coins.String()))
+}
+
+func bucketAmount(bucket uint8) *big.Int {
+	amount := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(bucket)), nil)
+	return amount.Mul(amount, unit)
+}
+
+func holderKeyBytes(key holderKey) []byte {
+	b := make([]byte, 0, 1+4+types.AddressLength)
+	b = append(b, holderPrefix)
+	b = append(b, coinBytes(key.coin)...)
+	return append(b, key.address.Bytes()...)
+}
+
+func richListKey(coin types.CoinID, total *big.Int, address types.Address) []byte {
+	b := make([]byte, 1+4+amountLength, 1+4+amountLength+types.AddressLength)
+	b[0] = richListPrefix
+	copy(b[1:], coinBytes(coin))
+	total.FillBytes(b[1+4:])
+	return append(b, address.Bytes()...)
+}
+
+func countKey(coin types.CoinID) []byte {
+	return append([]byte{countPrefix}, coinBytes(coin)...)
+}
+
+func distributionKey(coin types.CoinID, bucket uint8) []byte {
+	return append(append([]byte{distributionPrefix}, coinBytes(coin)...), bucket)
+}
+
+func stakesKey(candidateID uint32) []byte {
+	b := make([]byte, 5)
+	b[0] = stakesPrefix
+	binary.BigEndian.PutUint32(b[1:], candidateID)
+	return b
+}
+
+func uint64ToBytes(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
+
+// coinBytes returns big endian bytes of the coin id to keep keys of the coin in order
+func coinBytes(coin types.CoinID) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, coin.Uint32())
+	return b
+}
@@ -0,0 +1,84 @@
+package holders
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func coins(n int64) *big.Int {
+	return big.NewInt(0).Mul(big.NewInt(n), unit)
+}
+
+func TestIndex_Holders(t *testing.T) {
+	index := NewIndex(db.NewMemDB())
+	alice := types.Address{1}
+	bob := types.Address{2}
+	carol := types.Address{3}
+	coin := types.CoinID(1)
+
+	index.SetBalance(alice, coin, coins(5))
+	index.SetBalance(bob, coin, coins(50))
+	index.SetBalance(carol, types.GetBaseCoinID(), coins(1))
+	index.SetCandidateStakes(1, []*bus.Stake{{Owner: alice, Coin: coin, Value: coins(100)}})
+	if err := index.Commit(10); err != nil {
+		t.Fatal(err)
+	}
+
+	top, err := index.Top(coin, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0].Address != alice || top[0].Total().Cmp(coins(105)) != 0 || top[1].Address != bob {
+		t.Fatalf("unexpected top %#v", top)
+	}
+	if count, err := index.Count(coin); err != nil || count != 2 {
+		t.Fatalf("unexpected count %d, %v", count, err)
+	}
+
+	// alice unbonds the stake and bob sends everything to carol
+	index.SetCandidateStakes(1, nil)
+	index.SetBalance(bob, coin, big.NewInt(0))
+	index.SetBalance(carol, coin, coins(50))
+	if err := index.Commit(11); err != nil {
+		t.Fatal(err)
+	}
+
+	top, err = index.Top(coin, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0].Address != carol || top[1].Address != alice || top[1].Stake.Sign() != 0 {
+		t.Fatalf("unexpected top %#v", top)
+	}
+	if count, _ := index.Count(coin); count != 2 {
+		t.Fatalf("unexpected count %d", count)
+	}
+
+	distribution, err := index.Distribution(coin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(distribution) != 2 ||
+		distribution[0].Bucket != 1 || distribution[0].Holders != 1 || distribution[0].Amount.Cmp(coins(5)) != 0 ||
+		distribution[1].Bucket != 2 || distribution[1].Holders != 1 || distribution[1].Amount.Cmp(coins(50)) != 0 {
+		t.Fatalf("unexpected distribution %#v", distribution)
+	}
+	if distribution[1].From().Cmp(coins(10)) != 0 || distribution[1].To().Cmp(coins(100)) != 0 {
+		t.Fatal("unexpected bounds of the bucket")
+	}
+
+	if err := index.Rebuild(12, []types.Account{{Address: bob, Balance: []types.Balance{{Coin: uint64(coin), Value: coins(7).String()}}}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	top, _ = index.Top(coin, 10)
+	if len(top) != 1 || top[0].Address != bob {
+		t.Fatalf("unexpected top after rebuild %#v", top)
+	}
+	if height, _ := index.LastHeight(); height != 12 {
+		t.Fatalf("unexpected height %d", height)
+	}
+}
@@ -0,0 +1,137 @@
+package mempool
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
+)
+
+// TxKeySize is the size of the transaction key index
+const TxKeySize = sha256.Size
+
+// TxKey is the fixed length array hash used as the key in maps.
+func TxKey(tx types.Tx) [TxKeySize]byte {
+	return sha256.Sum256(tx)
+}
+
+const (
+	storeTxPrefix  = 's' // sequence -> tx
+	storeSeqPrefix = 'h' // tx key -> sequence
+)
+
+// Store keeps pending transactions on disk in order of their arrival, so they can be checked again after restart.
+// A replacement takes the place of the replaced transaction, keeping the order of nonces of the sender.
+type Store struct {
+	lock sync.Mutex
+	db   db.DB
+	seq  uint64
+}
+
+// NewStore creates the store of pending transactions in given DB
+func NewStore(db db.DB) *Store {
+	s := &Store{db: db}
+	it, err := db.ReverseIterator([]byte{storeTxPrefix}, []byte{storeTxPrefix + 1})
+	if err != nil {
+		panic(err)
+	}
+	defer it.Close()
+	if it.Valid() {
+		s.seq = binary.BigEndian.Uint64(it.Key()[1:])
+	}
+	return s
+}
+
+// Add stores the transaction after the others
+func (s *Store) Add(tx types.Tx) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := TxKey(tx)
+	if has, err := s.db.Has(seqKey(key)); err != nil || has {
+		return err
+	}
+
+	s.seq++
+	return s.set(s.seq, key, tx)
+}
+
+// Replace stores the transaction in place of the replaced one, or after the others if it's not stored
+func (s *Store) Replace(replaced [TxKeySize]byte, tx types.Tx) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	seq, err := s.db.Get(seqKey(replaced))
+	if err != nil {
+		return err
+	}
+	if seq == nil {
+		s.seq++
+		return s.set(s.seq, TxKey(tx), tx)
+	}
+
+	if err := s.db.Delete(seqKey(replaced)); err != nil {
+		return err
+	}
+	return s.set(binary.BigEndian.Uint64(seq), TxKey(tx), tx)
+}
+
+// Remove deletes the transaction with given key
+func (s *Store) Remove(key [TxKeySize]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	seq, err := s.db.Get(seqKey(key))
+	if err != nil || seq == nil {
+		return err
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Delete(seqKey(key)); err != nil {
+		return err
+	}
+	if err := batch.Delete(append([]byte{storeTxPrefix}, seq...)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Txs returns stored transactions in order of their arrival
+func (s *Store) Txs() (types.Txs, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	it, err := s.db.Iterator([]byte{storeTxPrefix}, []byte{storeTxPrefix + 1})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var txs types.Txs
+	for ; it.Valid(); it.Next() {
+		txs = append(txs, append(types.Tx{}, it.Value()...))
+	}
+	return txs, it.Error()
+}
+
+func (s *Store) set(seq uint64, key [TxKeySize]byte, tx types.Tx) error {
+	seqBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(seqBytes, seq)
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(append([]byte{storeTxPrefix}, seqBytes...), tx); err != nil {
+		return err
+	}
+	if err := batch.Set(seqKey(key), seqBytes); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func seqKey(key [TxKeySize]byte) []byte {
+	return append([]byte{storeSeqPrefix}, key[:]...)
+}
@@ -0,0 +1,50 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestStore(t *testing.T) {
+	memDB := db.NewMemDB()
+	store := NewStore(memDB)
+
+	for _, tx := range []string{"1/1/1", "1/2/1", "2/1/1", "1/2/1"} {
+		if err := store.Add([]byte(tx)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Remove(TxKey([]byte("2/1/1"))); err != nil {
+		t.Fatal(err)
+	}
+	// the replacement keeps the place of the replaced tx
+	if err := store.Replace(TxKey([]byte("1/1/1")), []byte("1/1/5")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the order survives a restart
+	store = NewStore(memDB)
+	if err := store.Add([]byte("3/1/1")); err != nil {
+		t.Fatal(err)
+	}
+
+	txs, err := store.Txs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTxs(t, txs, []byte("1/1/5"), []byte("1/2/1"), []byte("3/1/1"))
+}
+
+func assertTxs(t *testing.T, got types.Txs, want ...types.Tx) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected txs %s, got %s", want, got)
+	}
+	for i := range want {
+		if string(got[i]) != string(want[i]) {
+			t.Fatalf("expected txs %s, got %s", want, got)
+		}
+	}
+}
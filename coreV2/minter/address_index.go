@@ -0,0 +1,67 @@
+package minter
+
+import (
+	"fmt"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/activity"
+	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// AddressIndex returns the index of transactions by addresses, nil if it is disabled
+func (blockchain *Blockchain) AddressIndex() *activity.Index {
+	return blockchain.addressIndex
+}
+
+func (blockchain *Blockchain) indexAddresses(rawTx []byte, response transaction.Response) {
+	indexTx(blockchain.addressIndex, blockchain.executor, rawTx, response.Code, response.Tags)
+}
+
+func indexTx(index *activity.Index, decoder transaction.DecoderTx, rawTx []byte, code uint32, tags []abciTypes.EventAttribute) {
+	tx, err := decoder.DecodeFromBytes(rawTx)
+	if err != nil {
+		return
+	}
+	index.AddTx(tmTypes.Tx(rawTx).Hash(), byte(tx.Type), code, transaction.InvolvedAddresses(tx, tags))
+}
+
+// RebuildAddressIndex indexes transactions of blocks from the block store using results saved by Tendermint
+func RebuildAddressIndex(index *activity.Index, appDB *appdb.AppDB, blockStore *store.BlockStore, stateStore sm.Store, from, to int64, progress func(height int64)) error {
+	for height := from; height <= to; height++ {
+		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return fmt.Errorf("block %d is not found", height)
+		}
+
+		abciResponses, err := stateStore.LoadABCIResponses(height)
+		if err != nil {
+			return fmt.Errorf("results of block %d: %w", height, err)
+		}
+		if len(abciResponses.DeliverTxs) != len(block.Txs) {
+			return fmt.Errorf("results of block %d do not match its transactions", height)
+		}
+
+		executor := GetExecutor(appDB.GetVersionName(uint64(height)))
+		for i, rawTx := range block.Txs {
+			result := abciResponses.DeliverTxs[i]
+			var tags []abciTypes.EventAttribute
+			if len(result.Events) != 0 {
+				tags = result.Events[0].Attributes
+			}
+			indexTx(index, executor, rawTx, result.Code, tags)
+		}
+
+		if err := index.Commit(uint64(height)); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(height)
+		}
+	}
+
+	return nil
+}
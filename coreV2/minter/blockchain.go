@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
 	"github.com/MinterTeam/minter-go-node/helpers"
 	"github.com/cosmos/cosmos-sdk/snapshots"
 	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
@@ -21,13 +22,20 @@ import (
 
 	"github.com/MinterTeam/minter-go-node/cmd/utils"
 	"github.com/MinterTeam/minter-go-node/config"
+	"github.com/MinterTeam/minter-go-node/coreV2/activity"
 	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/holders"
+	"github.com/MinterTeam/minter-go-node/coreV2/mempool"
+	"github.com/MinterTeam/minter-go-node/coreV2/multisigpool"
+	"github.com/MinterTeam/minter-go-node/coreV2/rewardhistory"
 	"github.com/MinterTeam/minter-go-node/coreV2/rewards"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
 	"github.com/MinterTeam/minter-go-node/coreV2/statistics"
+	"github.com/MinterTeam/minter-go-node/coreV2/swaphistory"
 	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/coreV2/webhooks"
 	"github.com/MinterTeam/minter-go-node/upgrades"
 	"github.com/MinterTeam/minter-go-node/version"
 	abciTypes "github.com/tendermint/tendermint/abci/types"
@@ -46,7 +54,7 @@ const (
 const (
 	blockMaxBytes = 10000000
 	defaultMaxGas = 100000
-	minMaxGas     = 5000
+	minMaxGas     = params.MinMaxGas
 )
 
 const votingPowerConsensus = 2. / 3.
@@ -60,15 +68,24 @@ type Blockchain struct {
 	executor      transaction.ExecutorTx
 	statisticData *statistics.Data
 
-	appDB        *appdb.AppDB
-	eventsDB     eventsdb.IEventsDB
-	stateDeliver *state.State
-	stateCheck   *state.CheckState
-	height       uint64   // current Blockchain height
-	rewards      *big.Int // Rewards pool
+	appDB         *appdb.AppDB
+	eventsDB      eventsdb.IEventsDB
+	addressIndex  *activity.Index        // nil if transactions are not indexed by addresses
+	swapHistory   *swaphistory.History   // nil if swaps are not stored
+	webhooks      *webhooks.Dispatcher   // nil if webhooks are disabled
+	holders       *holders.Index         // nil if coin holders are not indexed
+	rewardHistory *rewardhistory.History // nil if rewards are not stored
+	pendingTxs    *mempool.Store         // nil if pending transactions are not persisted
+	multisigPool  *multisigpool.Pool     // nil if signatures of multisig transactions are not collected
+	webhookVotes  blockVotes
+	stateDeliver  *state.State
+	stateCheck    *state.CheckState
+	height        uint64   // current Blockchain height
+	rewards       *big.Int // Rewards pool
 
 	lockValidators     sync.RWMutex
 	validatorsStatuses map[types.TmAddress]int8
+	missedBlocks       map[types.TmAddress]int // missed blocks of validators absent in the current block before it, kept for webhooks
 	validatorsPowers   map[types.Pubkey]*big.Int
 	totalPower         *big.Int
 
@@ -80,7 +97,7 @@ type Blockchain struct {
 
 	tmNode *tmNode.Node
 
-	// currentMempool is responsive for prevent sending multiple transactions from one address in one block
+	// currentMempool keeps the last nonce of pending transactions of every sender, so senders can send sequences of transactions in one block
 	currentMempool *sync.Map
 
 	haltHeight   uint64
@@ -118,6 +135,34 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 	} else {
 		eventsDB = &eventsdb.MockEvents{}
 	}
+	var addressIndex *activity.Index
+	if !cfg.ValidatorMode && cfg.AddressIndex {
+		addressIndex = activity.NewIndex(storages.AddressIndexDB())
+	}
+	var swapHistory *swaphistory.History
+	if !cfg.ValidatorMode && cfg.SwapHistory {
+		swapHistory = swaphistory.NewHistory(storages.SwapHistoryDB())
+	}
+	var dispatcher *webhooks.Dispatcher
+	if !cfg.ValidatorMode && cfg.Webhooks {
+		dispatcher = webhooks.NewDispatcher(storages.WebhooksDB())
+	}
+	var holdersIndex *holders.Index
+	if !cfg.ValidatorMode && cfg.HoldersIndex {
+		holdersIndex = holders.NewIndex(storages.HoldersIndexDB())
+	}
+	var rewardHistory *rewardhistory.History
+	if !cfg.ValidatorMode && cfg.RewardHistory {
+		rewardHistory = rewardhistory.NewHistory(storages.RewardHistoryDB())
+	}
+	var multisigPool *multisigpool.Pool
+	if !cfg.ValidatorMode && cfg.MultisigSignatures {
+		multisigPool = multisigpool.NewPool(storages.MultisigPoolDB())
+	}
+	var pendingTxs *mempool.Store
+	if cfg.PersistentMempool {
+		pendingTxs = mempool.NewStore(storages.MempoolDB())
+	}
 	const updateStakesAndPayRewards = 720
 	if updateStakePeriod == 0 {
 		updateStakePeriod = updateStakesAndPayRewards
@@ -136,6 +181,13 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 		appDB:                           applicationDB,
 		storages:                        storages,
 		eventsDB:                        eventsDB,
+		addressIndex:                    addressIndex,
+		swapHistory:                     swapHistory,
+		webhooks:                        dispatcher,
+		holders:                         holdersIndex,
+		rewardHistory:                   rewardHistory,
+		pendingTxs:                      pendingTxs,
+		multisigPool:                    multisigPool,
 		currentMempool:                  &sync.Map{},
 		cfg:                             cfg,
 		stopChan:                        ctx,
@@ -148,6 +200,7 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 			V310: {}, // hotfix
 			V320: {},
 			V330: {},
+			V340: {}, // batches, HTLCs, vestings, allowances, recurring payments, multisig proposals, params governance
 		},
 		executor: GetExecutor(V3),
 	}
@@ -163,6 +216,8 @@ func graceForUpdate(height uint64) *upgrades.GracePeriod {
 
 func GetExecutor(v string) transaction.ExecutorTx {
 	switch v {
+	case V340:
+		return transaction.NewExecutorV340(transaction.GetDataV340)
 	//case V3:
 	//	return transaction.NewExecutorV3(transaction.GetDataV3)
 	//case v260, v261, v262:
@@ -181,6 +236,7 @@ const ( // known update versions
 	V310 = "v310" // hotfix
 	V320 = "v320" // hotfix
 	V330 = "v330" // hotfix
+	V340 = "v340" // new transactions, sponsored signature, validity window and governance of network params
 )
 
 func (blockchain *Blockchain) initState() {
@@ -205,6 +261,13 @@ func (blockchain *Blockchain) initState() {
 	blockchain.stateDeliver = stateDeliver
 	blockchain.stateCheck = state.NewCheckState(stateDeliver)
 
+	if blockchain.holders != nil {
+		stateDeliver.Bus().SetHolders(blockchain.holders)
+		if err := blockchain.syncHolders(currentHeight); err != nil {
+			panic(err)
+		}
+	}
+
 	blockchain.grace = upgrades.NewGrace()
 	blockchain.grace.AddGracePeriods(upgrades.NewGracePeriod(initialHeight, initialHeight+120, true))
 
@@ -294,15 +357,22 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 	blockchain.StatisticData().PushStartBlock(&statistics.StartRequest{Height: int64(height), Now: time.Now(), HeaderTime: req.Header.Time})
 
 	// compute max gas
-	maxGas := blockchain.calcMaxGas()
+	maxGas := blockchain.calcMaxGas(height)
 	blockchain.stateDeliver.App.SetMaxGas(maxGas)
 	blockchain.appDB.AddBlocksTime(req.Header.Time)
+	if blockchain.swapHistory != nil {
+		blockchain.swapHistory.StartBlock(req.Header.Time)
+	}
+	if blockchain.rewardHistory != nil {
+		blockchain.rewardHistory.StartBlock(req.Header.Time)
+	}
 
 	blockchain.rewards.SetInt64(0)
 
 	// clear absent candidates
 	blockchain.lockValidators.Lock()
 	blockchain.validatorsStatuses = map[types.TmAddress]int8{}
+	blockchain.missedBlocks = map[types.TmAddress]int{}
 	// give penalty to absent validators
 	for _, v := range req.LastCommitInfo.Votes {
 		var address types.TmAddress
@@ -312,6 +382,11 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 			blockchain.stateDeliver.Validators.SetValidatorPresent(height, address)
 			blockchain.validatorsStatuses[address] = ValidatorPresent
 		} else {
+			if blockchain.webhooks != nil {
+				if validator := blockchain.stateDeliver.Validators.GetByTmAddress(address); validator != nil {
+					blockchain.missedBlocks[address] = validator.CountAbsentTimes()
+				}
+			}
 			blockchain.stateDeliver.Validators.SetValidatorAbsent(height, address, blockchain.grace)
 			blockchain.validatorsStatuses[address] = ValidatorAbsent
 		}
@@ -344,7 +419,7 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 			continue
 		}
 
-		blockchain.stateDeliver.FrozenFunds.PunishFrozenFundsWithID(height, height+types.GetUnbondPeriod(), candidate.ID)
+		blockchain.stateDeliver.FrozenFunds.PunishFrozenFundsWithID(height, height+blockchain.unbondPeriod(height), candidate.ID)
 		blockchain.stateDeliver.Validators.PunishByzantineValidator(address)
 		blockchain.stateDeliver.Candidates.PunishByzantineCandidate(height, address)
 	}
@@ -446,8 +521,13 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 	}
 
 	// expire orders
-	if height > blockchain.expiredOrdersPeriod && height%blockchain.updateStakesAndPayRewardsPeriod == blockchain.updateStakesAndPayRewardsPeriod/2 {
-		blockchain.stateDeliver.Swapper().ExpireOrders(height - blockchain.expiredOrdersPeriod)
+	if expireOrdersPeriod := blockchain.expireOrdersPeriod(height); height > expireOrdersPeriod && height%blockchain.updateStakesAndPayRewardsPeriod == blockchain.updateStakesAndPayRewardsPeriod/2 {
+		blockchain.stateDeliver.Swapper().ExpireOrders(height - expireOrdersPeriod)
+	}
+
+	// make recurring payments
+	if blockchain.isV340(height) {
+		blockchain.payRecurringPayments(height)
 	}
 
 	// pay rewards
@@ -466,6 +546,9 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 		} else if h := blockchain.appDB.GetVersionHeight(V310); h > 0 && height > h {
 			PayRewards = blockchain.stateDeliver.Validators.PayRewardsV4
 		}
+		if blockchain.rewardHistory != nil {
+			blockchain.addRewardStakes()
+		}
 		moreRewards = PayRewards(heightIsMaxIfIssueIsOverOrNotDynamic, int64(blockchain.updateStakesAndPayRewardsPeriod))
 		blockchain.appDB.SetEmission(big.NewInt(0).Add(blockchain.appDB.Emission(), moreRewards))
 		blockchain.stateDeliver.Checker.AddCoinVolume(types.GetBaseCoinID(), moreRewards)
@@ -555,6 +638,10 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 		blockchain.stateDeliver.Updates.Delete(height)
 	}
 
+	if blockchain.isV340(height) {
+		blockchain.updateParams(height)
+	}
+
 	hasChangedPublicKeys := false
 	if blockchain.stateDeliver.Candidates.IsChangedPublicKeys() {
 		blockchain.stateDeliver.Candidates.ResetIsChangedPublicKeys()
@@ -598,6 +685,21 @@ func (blockchain *Blockchain) Info(_ abciTypes.RequestInfo) (resInfo abciTypes.R
 func (blockchain *Blockchain) DeliverTx(req abciTypes.RequestDeliverTx) abciTypes.ResponseDeliverTx {
 	response := blockchain.executor.RunTx(blockchain.stateDeliver, req.Tx, blockchain.rewards, blockchain.Height()+1, &sync.Map{}, 0, blockchain.cfg.ValidatorMode)
 
+	if blockchain.addressIndex != nil {
+		blockchain.indexAddresses(req.Tx, response)
+	}
+	if blockchain.swapHistory != nil {
+		blockchain.swapHistory.AddSwaps(transaction.PoolChanges(response.Tags))
+	}
+	if blockchain.webhooks != nil {
+		blockchain.notifyTx(req.Tx, response)
+	}
+	if blockchain.pendingTxs != nil {
+		if err := blockchain.pendingTxs.Remove(mempool.TxKey(req.Tx)); err != nil {
+			blockchain.logger.Error("failed to remove pending tx", "err", err)
+		}
+	}
+
 	return abciTypes.ResponseDeliverTx{
 		Code:      response.Code,
 		Data:      response.Data,
@@ -617,6 +719,12 @@ func (blockchain *Blockchain) DeliverTx(req abciTypes.RequestDeliverTx) abciType
 // CheckTx validates a tx for the mempool
 func (blockchain *Blockchain) CheckTx(req abciTypes.RequestCheckTx) abciTypes.ResponseCheckTx {
 	response := blockchain.executor.RunTx(blockchain.CurrentState(), req.Tx, nil, blockchain.Height()+1, blockchain.currentMempool, blockchain.MinGasPrice(), true)
+	if response.Replaced != nil && req.Type == abciTypes.CheckTxType_New {
+		blockchain.replacePendingTx(req.Tx, response.Replaced)
+	}
+	if blockchain.pendingTxs != nil {
+		blockchain.storePendingTx(req, response)
+	}
 
 	return abciTypes.ResponseCheckTx{
 		Code:      response.Code,
@@ -658,6 +766,27 @@ func (blockchain *Blockchain) Commit() abciTypes.ResponseCommit {
 		panic(err)
 	}
 
+	if blockchain.addressIndex != nil {
+		if err := blockchain.addressIndex.Commit(height); err != nil {
+			panic(err)
+		}
+	}
+	if blockchain.swapHistory != nil {
+		if err := blockchain.swapHistory.Commit(height, blockchain.stateCheck.Swap().SwapPool); err != nil {
+			panic(err)
+		}
+	}
+	if blockchain.webhooks != nil {
+		if err := blockchain.notifyBlock(height); err != nil {
+			panic(err)
+		}
+	}
+	if blockchain.rewardHistory != nil {
+		if err := blockchain.rewardHistory.Commit(height, blockchain.eventsDB.LoadEvents(uint32(height))); err != nil {
+			panic(err)
+		}
+	}
+
 	// Committing Minter Blockchain state
 	hash, err := blockchain.stateDeliver.Commit()
 	if err != nil {
@@ -675,6 +804,17 @@ func (blockchain *Blockchain) Commit() abciTypes.ResponseCommit {
 		blockchain.appDB.SavePrice()
 	}
 
+	if blockchain.holders != nil {
+		if err := blockchain.holders.Commit(height); err != nil {
+			panic(err)
+		}
+	}
+	if blockchain.multisigPool != nil {
+		if err := blockchain.multisigPool.Prune(height, blockchain.stateCheck.Accounts().GetNonce); err != nil {
+			panic(err)
+		}
+	}
+
 	// Clear mempool
 	blockchain.currentMempool = &sync.Map{}
 
@@ -693,11 +833,6 @@ func (blockchain *Blockchain) Commit() abciTypes.ResponseCommit {
 	}
 }
 
-// Query Unused method, required by Tendermint
-func (blockchain *Blockchain) Query(_ abciTypes.RequestQuery) abciTypes.ResponseQuery {
-	return abciTypes.ResponseQuery{}
-}
-
 // SetOption Unused method, required by Tendermint
 func (blockchain *Blockchain) SetOption(_ abciTypes.RequestSetOption) abciTypes.ResponseSetOption {
 	return abciTypes.ResponseSetOption{}
@@ -717,5 +852,26 @@ func (blockchain *Blockchain) Close() error {
 	if err := blockchain.storages.SnapshotDB().Close(); err != nil {
 		return err
 	}
+	if err := blockchain.storages.AddressIndexDB().Close(); err != nil {
+		return err
+	}
+	if err := blockchain.storages.SwapHistoryDB().Close(); err != nil {
+		return err
+	}
+	if err := blockchain.storages.WebhooksDB().Close(); err != nil {
+		return err
+	}
+	if err := blockchain.storages.HoldersIndexDB().Close(); err != nil {
+		return err
+	}
+	if err := blockchain.storages.RewardHistoryDB().Close(); err != nil {
+		return err
+	}
+	if err := blockchain.storages.MempoolDB().Close(); err != nil {
+		return err
+	}
+	if err := blockchain.storages.MultisigPoolDB().Close(); err != nil {
+		return err
+	}
 	return nil
 }
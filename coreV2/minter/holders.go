@@ -0,0 +1,28 @@
+package minter
+
+import (
+	"github.com/MinterTeam/minter-go-node/coreV2/holders"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Holders returns the index of coin holders, nil if it is disabled
+func (blockchain *Blockchain) Holders() *holders.Index {
+	return blockchain.holders
+}
+
+// syncHolders rebuilds the index of coin holders from the state if it was not committed at the height of the state
+func (blockchain *Blockchain) syncHolders(height uint64) error {
+	lastHeight, err := blockchain.holders.LastHeight()
+	if err != nil {
+		return err
+	}
+	if lastHeight == height {
+		return nil
+	}
+
+	blockchain.logger.Info("Rebuilding coin holders index", "height", height, "index_height", lastHeight)
+	appState := new(types.AppState)
+	blockchain.stateCheck.Accounts().Export(appState)
+	blockchain.stateCheck.Candidates().Export(appState)
+	return blockchain.holders.Rebuild(height, appState.Accounts, appState.Candidates)
+}
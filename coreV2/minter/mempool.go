@@ -0,0 +1,109 @@
+package minter
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/mempool"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	tmpool "github.com/tendermint/tendermint/mempool"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// EventReplacedTx is the type of events published to subscribers of the node when a pending transaction
+// is replaced by the transaction with the same sender and nonce paying more for gas, query "tm.event = 'ReplacedTx'"
+const EventReplacedTx = "ReplacedTx"
+
+// EventDataReplacedTx is the data of EventReplacedTx
+type EventDataReplacedTx struct {
+	Hash       string `json:"hash"`
+	ReplacedBy string `json:"replaced_by"`
+	Sender     string `json:"sender"`
+	Nonce      uint64 `json:"nonce"`
+	GasPrice   uint32 `json:"gas_price"`
+}
+
+// replacePendingTx removes the replaced transaction from the mempool and notifies subscribers about the replacement
+func (blockchain *Blockchain) replacePendingTx(rawTx tmTypes.Tx, replaced *transaction.PendingTx) {
+	if blockchain.tmNode == nil {
+		return
+	}
+
+	// the replaced transaction is removed from the CListMempool of tendermint
+	if mempool, ok := blockchain.tmNode.Mempool().(interface {
+		RemoveTxByKey(txKey [sha256.Size]byte, removeFromCache bool)
+	}); ok {
+		mempool.RemoveTxByKey(replaced.Hash, false)
+	}
+
+	data := EventDataReplacedTx{
+		Hash:       strings.Title(fmt.Sprintf("Mt%x", replaced.Hash)),
+		ReplacedBy: strings.Title(fmt.Sprintf("Mt%x", rawTx.Hash())),
+		Sender:     replaced.Sender.String(),
+		Nonce:      replaced.Nonce,
+		GasPrice:   replaced.GasPrice,
+	}
+	if err := blockchain.tmNode.EventBus().Publish(EventReplacedTx, data); err != nil {
+		blockchain.logger.Error("failed to publish replaced tx", "hash", data.Hash, "err", err)
+	}
+}
+
+// storePendingTx keeps the checked transaction in the persistent mempool and drops the ones which are no longer valid
+func (blockchain *Blockchain) storePendingTx(req abciTypes.RequestCheckTx, response transaction.Response) {
+	var err error
+	switch {
+	case response.Code != code.OK:
+		err = blockchain.pendingTxs.Remove(mempool.TxKey(req.Tx))
+	case req.Type != abciTypes.CheckTxType_New:
+	case response.Replaced != nil:
+		err = blockchain.pendingTxs.Replace(response.Replaced.Hash, req.Tx)
+	default:
+		err = blockchain.pendingTxs.Add(req.Tx)
+	}
+	if err != nil {
+		blockchain.logger.Error("failed to store pending tx", "err", err)
+	}
+}
+
+// ReplayPendingTxs checks transactions of the persistent mempool again once the node has caught up with the network,
+// transactions with stale nonces or no longer valid by the state are dropped by CheckTx
+func (blockchain *Blockchain) ReplayPendingTxs() {
+	if blockchain.pendingTxs == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-blockchain.stopChan.Done():
+				return
+			case <-ticker.C:
+			}
+			if status, err := blockchain.rpcClient.Status(context.Background()); err == nil && !status.SyncInfo.CatchingUp {
+				break
+			}
+		}
+
+		txs, err := blockchain.pendingTxs.Txs()
+		if err != nil {
+			blockchain.logger.Error("failed to load pending txs", "err", err)
+			return
+		}
+		for _, tx := range txs {
+			if err := blockchain.tmNode.Mempool().CheckTx(tx, nil, tmpool.TxInfo{}); err != nil && err != tmpool.ErrTxInCache {
+				blockchain.logger.Info("dropped pending tx", "hash", fmt.Sprintf("%X", tx.Hash()), "err", err)
+				if err := blockchain.pendingTxs.Remove(mempool.TxKey(tx)); err != nil {
+					blockchain.logger.Error("failed to remove pending tx", "err", err)
+				}
+			}
+		}
+		blockchain.logger.Info("Replayed pending transactions", "count", len(txs), "mempool", blockchain.tmNode.Mempool().Size())
+	}()
+}
@@ -10,12 +10,13 @@ import (
 
 	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/multisigpool"
 	"github.com/MinterTeam/minter-go-node/coreV2/rewards"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
 	validators2 "github.com/MinterTeam/minter-go-node/coreV2/state/validators"
 	"github.com/MinterTeam/minter-go-node/coreV2/statistics"
+	"github.com/MinterTeam/minter-go-node/coreV2/swaphistory"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
-	"github.com/MinterTeam/minter-go-node/coreV2/validators"
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	abciTypes "github.com/tendermint/tendermint/abci/types"
@@ -102,13 +103,9 @@ func (blockchain *Blockchain) updateValidators() []abciTypes.ValidatorUpdate {
 	height := blockchain.Height()
 
 	blockchain.stateDeliver.Candidates.RecalculateStakesV2(height)
-	valsCount := validators.GetValidatorsCountForBlock(height)
-	newCandidates := blockchain.stateDeliver.Candidates.GetNewCandidates(valsCount)
-	if len(newCandidates) < valsCount {
-		valsCount = len(newCandidates)
-	}
+	newCandidates := blockchain.getNewCandidates(height)
 
-	newValidators := make([]abciTypes.ValidatorUpdate, 0, valsCount)
+	newValidators := make([]abciTypes.ValidatorUpdate, 0, len(newCandidates))
 
 	// calculate total power
 	totalPower := big.NewInt(0)
@@ -228,13 +225,13 @@ func (blockchain *Blockchain) MinGasPrice() uint32 {
 	return 1
 }
 
-func (blockchain *Blockchain) calcMaxGas() uint64 {
+func (blockchain *Blockchain) calcMaxGas(height uint64) uint64 {
 	const targetTime = 7
 
 	// check if blocks are created in time
 	delta, count := blockchain.appDB.GetLastBlockTimeDelta()
 	if delta == 0 {
-		return defaultMaxGas
+		return blockchain.maxGasLimit(height)
 	}
 
 	// get current max gas
@@ -247,8 +244,8 @@ func (blockchain *Blockchain) calcMaxGas() uint64 {
 	}
 
 	// check if max gas is too high
-	if newMaxGas > defaultMaxGas {
-		return defaultMaxGas
+	if maxGas := blockchain.maxGasLimit(height); newMaxGas > maxGas {
+		return maxGas
 	}
 
 	// check if max gas is too low
@@ -264,6 +261,16 @@ func (blockchain *Blockchain) GetEventsDB() eventsdb.IEventsDB {
 	return blockchain.eventsDB
 }
 
+// SwapHistory returns the history of swaps through the pools, nil if it is disabled
+func (blockchain *Blockchain) SwapHistory() *swaphistory.History {
+	return blockchain.swapHistory
+}
+
+// MultisigPool returns multisig transactions collecting signatures of members, nil if it is disabled
+func (blockchain *Blockchain) MultisigPool() *multisigpool.Pool {
+	return blockchain.multisigPool
+}
+
 // SetStatisticData used for collection statistics about blockchain operations
 func (blockchain *Blockchain) SetStatisticData(statisticData *statistics.Data) *statistics.Data {
 	blockchain.statisticData = statisticData
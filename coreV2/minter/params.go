@@ -0,0 +1,121 @@
+package minter
+
+import (
+	"math/big"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/coreV2/validators"
+)
+
+// isV340 returns whether the network is updated to V340 at the height,
+// recurring payments are paid and network params voted by validators are applied only since then
+func (blockchain *Blockchain) isV340(height uint64) bool {
+	h := blockchain.appDB.GetVersionHeight(V340)
+	return h > 0 && height > h
+}
+
+// updateParams applies values of network parameters voted at the height by validators with more than 2/3 of voting power,
+// a validator votes for one value of the parameter, so at most one value of each parameter is applied
+func (blockchain *Blockchain) updateParams(height uint64) {
+	proposals := blockchain.stateDeliver.Params.GetProposals(height)
+	if len(proposals) == 0 {
+		return
+	}
+
+	for _, name := range params.Names {
+		maxVotingResult := big.NewFloat(0)
+		var value string
+		for _, proposal := range proposals {
+			if proposal.Name != name {
+				continue
+			}
+			totalVotedPower := big.NewInt(0)
+			for _, vote := range proposal.Votes {
+				if power, ok := blockchain.validatorsPowers[vote]; ok {
+					totalVotedPower.Add(totalVotedPower, power)
+				}
+			}
+			votingResult := new(big.Float).Quo(
+				new(big.Float).SetInt(totalVotedPower),
+				new(big.Float).SetInt(blockchain.totalPower),
+			)
+
+			if maxVotingResult.Cmp(votingResult) == -1 {
+				maxVotingResult = votingResult
+				value = proposal.Value
+			}
+		}
+		if maxVotingResult.Cmp(big.NewFloat(votingPowerConsensus)) != 1 {
+			continue
+		}
+
+		v, _ := big.NewInt(0).SetString(value, 10)
+		blockchain.stateDeliver.Params.SetValue(name, v)
+		blockchain.eventsDB.AddEvent(&eventsdb.UpdateParamsEvent{
+			Name:  name,
+			Value: value,
+		})
+	}
+
+	blockchain.stateDeliver.Params.Delete(height)
+}
+
+// maxGasLimit returns the max gas of the block changed by validators or the default one, it's not less than minMaxGas
+func (blockchain *Blockchain) maxGasLimit(height uint64) uint64 {
+	if !blockchain.isV340(height) {
+		return defaultMaxGas
+	}
+	if value := blockchain.stateDeliver.Params.GetValue(params.MaxGas); value != nil {
+		if value.Uint64() < minMaxGas {
+			return minMaxGas
+		}
+		return value.Uint64()
+	}
+	return defaultMaxGas
+}
+
+// expireOrdersPeriod returns the period of orders expiration changed by validators or the default one
+func (blockchain *Blockchain) expireOrdersPeriod(height uint64) uint64 {
+	if !blockchain.isV340(height) {
+		return blockchain.expiredOrdersPeriod
+	}
+	if value := blockchain.stateDeliver.Params.GetValue(params.ExpireOrdersPeriod); value != nil {
+		return value.Uint64()
+	}
+	return blockchain.expiredOrdersPeriod
+}
+
+// getNewCandidates returns candidates for new validators limited by the max count and the min stake changed by validators,
+// the min stake can't drop the top candidates below params.MinValidatorsLimit, so the validator set is never emptied by it
+func (blockchain *Blockchain) getNewCandidates(height uint64) []*candidates.Candidate {
+	valsCount := validators.GetValidatorsCountForBlock(height)
+	if !blockchain.isV340(height) {
+		return blockchain.stateDeliver.Candidates.GetNewCandidates(valsCount)
+	}
+	if value := blockchain.stateDeliver.Params.GetValue(params.MaxValidators); value != nil {
+		valsCount = int(value.Int64())
+	}
+
+	if minStake := blockchain.stateDeliver.Params.GetValue(params.MinStake); minStake != nil {
+		newCandidates := blockchain.stateDeliver.Candidates.GetNewCandidatesWithMinStake(valsCount, minStake)
+		if len(newCandidates) >= params.MinValidatorsLimit {
+			return newCandidates
+		}
+		if top := blockchain.stateDeliver.Candidates.GetNewCandidates(params.MinValidatorsLimit); len(top) > len(newCandidates) {
+			return top
+		}
+		return newCandidates
+	}
+	return blockchain.stateDeliver.Candidates.GetNewCandidates(valsCount)
+}
+
+// unbondPeriod returns the unbond period changed by validators or the default one
+func (blockchain *Blockchain) unbondPeriod(height uint64) uint64 {
+	if !blockchain.isV340(height) {
+		return types.GetUnbondPeriod()
+	}
+	return blockchain.stateDeliver.Params.GetUnbondPeriod()
+}
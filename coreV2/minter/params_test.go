@@ -0,0 +1,89 @@
+package minter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/config"
+	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestBlockchain_ParamsSinceV340(t *testing.T) {
+	stateDeliver, err := state.NewStateV3(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateDeliver.Params.SetValue(params.MaxGas, big.NewInt(50000))
+	stateDeliver.Params.SetValue(params.UnbondPeriod, big.NewInt(10))
+
+	cfg := config.DefaultConfig()
+	cfg.DBBackend = "memdb"
+	appDB := appdb.NewAppDB(t.TempDir(), cfg)
+	appDB.AddVersion(V3, 0)
+	blockchain := &Blockchain{stateDeliver: stateDeliver, appDB: appDB}
+
+	if maxGas := blockchain.maxGasLimit(100); maxGas != defaultMaxGas {
+		t.Fatalf("max gas before the update is %d", maxGas)
+	}
+	if period := blockchain.unbondPeriod(100); period != types.GetUnbondPeriod() {
+		t.Fatalf("unbond period before the update is %d", period)
+	}
+
+	appDB.AddVersion(V340, 100)
+	if maxGas := blockchain.maxGasLimit(100); maxGas != defaultMaxGas {
+		t.Fatalf("max gas at the height of the update is %d", maxGas)
+	}
+	if maxGas := blockchain.maxGasLimit(102); maxGas != 50000 {
+		t.Fatalf("max gas after the update is %d", maxGas)
+	}
+	if period := blockchain.unbondPeriod(102); period != 10 {
+		t.Fatalf("unbond period after the update is %d", period)
+	}
+}
+
+func TestBlockchain_GetNewCandidatesWithMinStake(t *testing.T) {
+	stateDeliver, err := state.NewStateV3(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 20; i++ {
+		pubkey := types.Pubkey{byte(i)}
+		stake := helpers.BipToPip(big.NewInt(int64(1000 * i))).String()
+		stateDeliver.Candidates.Create(types.Address{1}, types.Address{2}, types.Address{3}, pubkey, 10, 0, 0)
+		stateDeliver.Candidates.SetStakes(pubkey, []types.Stake{{Owner: types.Address{1}, Coin: 0, Value: stake, BipValue: stake}}, nil)
+		stateDeliver.Candidates.SetOnline(pubkey)
+	}
+	stateDeliver.Candidates.RecalculateStakes(1)
+	if _, err := stateDeliver.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.DBBackend = "memdb"
+	appDB := appdb.NewAppDB(t.TempDir(), cfg)
+	appDB.AddVersion(V3, 0)
+	appDB.AddVersion(V340, 1)
+	blockchain := &Blockchain{stateDeliver: stateDeliver, appDB: appDB}
+
+	stateDeliver.Params.SetValue(params.MinStake, helpers.BipToPip(big.NewInt(3000)))
+	if newCandidates := blockchain.getNewCandidates(10); len(newCandidates) != 18 {
+		t.Fatalf("count of new candidates is %d", len(newCandidates))
+	}
+
+	// the min stake above all stakes keeps the top candidates
+	stateDeliver.Params.SetValue(params.MinStake, helpers.BipToPip(big.NewInt(1000000)))
+	newCandidates := blockchain.getNewCandidates(10)
+	if len(newCandidates) != params.MinValidatorsLimit {
+		t.Fatalf("count of new candidates is %d", len(newCandidates))
+	}
+	if newCandidates[0].PubKey != (types.Pubkey{20}) {
+		t.Fatalf("top candidate is %s", newCandidates[0].PubKey)
+	}
+}
@@ -0,0 +1,226 @@
+package minter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/frozenfunds"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/waitlist"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/iavl"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+// Query paths served by ABCI Query. Value is the raw IAVL leaf stored at the resolved key:
+//
+//	/address/{Mx...}/balance/{coin_id}  big-endian balance
+//	/address/{Mx...}/nonce              RLP of account info (nonce, multisig data)
+//	/address/{Mx...}/waitlist           RLP of the address waitlist
+//	/candidates                         RLP of the candidates list
+//	/candidate/{Mp...}/total_stake      big-endian total bip stake of the candidate
+//	/coin/{coin_id}                     RLP of the coin model
+//	/swap_pool/{coin0}/{coin1}          RLP of the pair reserves
+//	/limit_order/{order_id}             RLP of the limit order
+//	/frozen/{height}                    RLP of the funds unfrozen at the height
+//	/key                                any key given in the request data
+const (
+	QueryPathAddress    = "address"
+	QueryPathCandidates = "candidates"
+	QueryPathCandidate  = "candidate"
+	QueryPathCoin       = "coin"
+	QueryPathSwapPool   = "swap_pool"
+	QueryPathLimitOrder = "limit_order"
+	QueryPathFrozen     = "frozen"
+	QueryPathKey        = "key"
+)
+
+// Query returns the value from the state tree at the requested height with the Merkle proof, if requested.
+// The app hash is the root of the single IAVL tree, so the returned proof ops consist of the one
+// ics23 IAVL existence or absence proof, which is verified against the app hash directly without a multistore root op.
+// The proof of the height is verified against LastBlockAppHash returned by Info after the height is committed,
+// which is the app hash of the header of the next block.
+func (blockchain *Blockchain) Query(req abciTypes.RequestQuery) abciTypes.ResponseQuery {
+	height := req.Height
+	if height == 0 {
+		height = int64(blockchain.appDB.GetLastHeight())
+	}
+
+	key, err := blockchain.queryKey(req.Path, req.Data, uint64(height))
+	if err != nil {
+		return errors.QueryResult(err)
+	}
+
+	immutableTree, err := blockchain.stateDeliver.Tree().GetImmutableAtHeight(height)
+	if err != nil {
+		return errors.QueryResult(errors.Wrapf(errors.ErrInvalidRequest, "state at height %d is not available: %s", height, err))
+	}
+
+	_, value := immutableTree.Get(key)
+	res := abciTypes.ResponseQuery{
+		Key:    key,
+		Value:  value,
+		Height: height,
+	}
+
+	if !req.Prove {
+		return res
+	}
+
+	proofOps, err := queryProof(immutableTree, key, value != nil)
+	if err != nil {
+		return errors.QueryResult(err)
+	}
+	res.ProofOps = proofOps
+
+	return res
+}
+
+// DefaultProofRuntime returns the runtime to verify proofs returned by Query against the app hash.
+func DefaultProofRuntime() *merkle.ProofRuntime {
+	prt := merkle.NewProofRuntime()
+	prt.RegisterOpDecoder(storetypes.ProofOpIAVLCommitment, storetypes.CommitmentOpDecoder)
+	return prt
+}
+
+// QueryKeyPath returns the keypath to verify the value returned by Query with DefaultProofRuntime.
+func QueryKeyPath(key []byte) string {
+	keyPath := new(merkle.KeyPath).AppendKey(key, merkle.KeyEncodingHex)
+	return keyPath.String()
+}
+
+func queryProof(immutableTree *iavl.ImmutableTree, key []byte, exists bool) (*tmcrypto.ProofOps, error) {
+	if exists {
+		proof, err := immutableTree.GetMembershipProof(key)
+		if err != nil {
+			return nil, err
+		}
+		op := storetypes.NewIavlCommitmentOp(key, proof)
+		return &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{op.ProofOp()}}, nil
+	}
+
+	proof, err := immutableTree.GetNonMembershipProof(key)
+	if err != nil {
+		return nil, err
+	}
+	op := storetypes.NewIavlCommitmentOp(key, proof)
+	return &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{op.ProofOp()}}, nil
+}
+
+func (blockchain *Blockchain) queryKey(path string, data []byte, height uint64) ([]byte, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch parts[0] {
+	case QueryPathKey:
+		if len(data) == 0 {
+			return nil, errors.Wrap(errors.ErrInvalidRequest, "empty key")
+		}
+		return data, nil
+	case QueryPathAddress:
+		if len(parts) < 3 {
+			break
+		}
+		address, err := queryAddress(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		switch parts[2] {
+		case "balance":
+			if len(parts) != 4 {
+				break
+			}
+			coinID, err := queryUint32(parts[3])
+			if err != nil {
+				return nil, err
+			}
+			return accounts.PathBalance(address, types.CoinID(coinID)), nil
+		case "nonce":
+			return accounts.PathAccount(address), nil
+		case "waitlist":
+			return waitlist.PathWaitList(address), nil
+		}
+	case QueryPathCandidates:
+		return candidates.PathCandidates(), nil
+	case QueryPathCandidate:
+		if len(parts) != 3 || parts[2] != "total_stake" {
+			break
+		}
+		if !strings.HasPrefix(parts[1], "Mp") || len(parts[1]) != 66 {
+			return nil, errors.Wrap(errors.ErrInvalidPubKey, parts[1])
+		}
+		cState, err := blockchain.GetStateForHeight(height)
+		if err != nil {
+			return nil, errors.Wrapf(errors.ErrInvalidRequest, "state at height %d is not available: %s", height, err)
+		}
+		cState.Candidates().LoadCandidates()
+		candidate := cState.Candidates().GetCandidate(types.HexToPubkey(parts[1]))
+		if candidate == nil {
+			return nil, errors.Wrap(errors.ErrNotFound, "candidate not found")
+		}
+		return candidates.PathTotalStake(candidate.ID), nil
+	case QueryPathCoin:
+		if len(parts) != 2 {
+			break
+		}
+		coinID, err := queryUint32(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return coins.PathCoin(types.CoinID(coinID)), nil
+	case QueryPathSwapPool:
+		if len(parts) != 3 {
+			break
+		}
+		coin0, err := queryUint32(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		coin1, err := queryUint32(parts[2])
+		if err != nil {
+			return nil, err
+		}
+		return swap.PathPair(types.CoinID(coin0), types.CoinID(coin1)), nil
+	case QueryPathLimitOrder:
+		if len(parts) != 2 {
+			break
+		}
+		id, err := queryUint32(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		return swap.PathOrder(id), nil
+	case QueryPathFrozen:
+		if len(parts) != 2 {
+			break
+		}
+		h, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrInvalidRequest, err.Error())
+		}
+		return frozenfunds.PathFrozenFunds(h), nil
+	}
+
+	return nil, errors.Wrap(errors.ErrUnknownRequest, fmt.Sprintf("unknown query path: %s", path))
+}
+
+func queryAddress(s string) (types.Address, error) {
+	if !strings.HasPrefix(s, "Mx") || len(s) != 42 {
+		return types.Address{}, errors.Wrap(errors.ErrInvalidAddress, s)
+	}
+	return types.HexToAddress(s), nil
+}
+
+func queryUint32(s string) (uint32, error) {
+	v, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrInvalidRequest, err.Error())
+	}
+	return uint32(v), nil
+}
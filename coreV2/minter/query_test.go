@@ -0,0 +1,120 @@
+package minter
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/config"
+	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestBlockchain_Query(t *testing.T) {
+	stateDeliver, err := state.NewStateV3(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := types.Address{7}
+	stateDeliver.Accounts.SetBalance(address, types.GetBaseCoinID(), big.NewInt(1e18))
+	stateDeliver.Accounts.SetNonce(address, 5)
+	appHash, err := stateDeliver.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blockchain := &Blockchain{stateDeliver: stateDeliver}
+
+	res := blockchain.Query(abciTypes.RequestQuery{
+		Path:   fmt.Sprintf("/address/%s/balance/%d", address.String(), types.GetBaseCoinID()),
+		Height: 1,
+		Prove:  true,
+	})
+	if res.Code != 0 {
+		t.Fatal(res.Log)
+	}
+	if big.NewInt(0).SetBytes(res.Value).Cmp(big.NewInt(1e18)) != 0 {
+		t.Fatalf("wrong balance %x", res.Value)
+	}
+	if err := DefaultProofRuntime().VerifyValue(res.ProofOps, appHash, QueryKeyPath(res.Key), res.Value); err != nil {
+		t.Fatal(err)
+	}
+
+	res = blockchain.Query(abciTypes.RequestQuery{
+		Path:   fmt.Sprintf("/address/%s/balance/%d", types.Address{1}.String(), types.GetBaseCoinID()),
+		Height: 1,
+		Prove:  true,
+	})
+	if res.Code != 0 {
+		t.Fatal(res.Log)
+	}
+	if len(res.Value) != 0 {
+		t.Fatal("balance should not exist")
+	}
+	if err := DefaultProofRuntime().VerifyAbsence(res.ProofOps, appHash, QueryKeyPath(res.Key)); err != nil {
+		t.Fatal(err)
+	}
+
+	res = blockchain.Query(abciTypes.RequestQuery{Path: "/unknown", Height: 1})
+	if res.Code == 0 {
+		t.Fatal("unknown path should fail")
+	}
+
+	res = blockchain.Query(abciTypes.RequestQuery{Path: "/coin/0", Height: 100})
+	if res.Code == 0 {
+		t.Fatal("unavailable height should fail")
+	}
+}
+
+func TestBlockchain_QueryLastBlockAppHash(t *testing.T) {
+	stateDeliver, err := state.NewStateV3(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.DBBackend = "memdb"
+	appDB := appdb.NewAppDB(t.TempDir(), cfg)
+	blockchain := &Blockchain{stateDeliver: stateDeliver, appDB: appDB}
+
+	address := types.Address{7}
+	var appHashes [][]byte
+	for height := uint64(1); height <= 2; height++ {
+		stateDeliver.Accounts.SetBalance(address, types.GetBaseCoinID(), big.NewInt(int64(height)))
+		hash, err := stateDeliver.Commit()
+		if err != nil {
+			t.Fatal(err)
+		}
+		appDB.SetLastBlockHash(hash)
+		appDB.SetLastHeight(height)
+		appHashes = append(appHashes, blockchain.Info(abciTypes.RequestInfo{}).LastBlockAppHash)
+	}
+
+	path := fmt.Sprintf("/address/%s/balance/%d", address.String(), types.GetBaseCoinID())
+	res := blockchain.Query(abciTypes.RequestQuery{Path: path, Prove: true})
+	if res.Code != 0 {
+		t.Fatal(res.Log)
+	}
+	if res.Height != 2 || big.NewInt(0).SetBytes(res.Value).Int64() != 2 {
+		t.Fatalf("wrong balance %x at height %d", res.Value, res.Height)
+	}
+	if err := DefaultProofRuntime().VerifyValue(res.ProofOps, appHashes[1], QueryKeyPath(res.Key), res.Value); err != nil {
+		t.Fatal(err)
+	}
+
+	res = blockchain.Query(abciTypes.RequestQuery{Path: path, Height: 1, Prove: true})
+	if res.Code != 0 {
+		t.Fatal(res.Log)
+	}
+	if err := DefaultProofRuntime().VerifyValue(res.ProofOps, appHashes[0], QueryKeyPath(res.Key), res.Value); err != nil {
+		t.Fatal(err)
+	}
+	if err := DefaultProofRuntime().VerifyValue(res.ProofOps, appHashes[1], QueryKeyPath(res.Key), res.Value); err == nil {
+		t.Fatal("proof of the previous height should not be verified against the last app hash")
+	}
+}
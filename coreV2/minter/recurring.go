@@ -0,0 +1,36 @@
+package minter
+
+import (
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+)
+
+// payRecurringPayments makes payments of standing orders due at the height from spendable balances of payers,
+// payments of payers without enough funds are skipped
+func (blockchain *Blockchain) payRecurringPayments(height uint64) {
+	accounts := state.NewCheckState(blockchain.stateDeliver).Accounts()
+	for _, payment := range blockchain.stateDeliver.Recurring.GetDuePayments(height) {
+		if accounts.GetBalance(payment.Payer, payment.Coin).Cmp(payment.Value) < 0 {
+			blockchain.eventsDB.AddEvent(&eventsdb.RecurringPaymentFailedEvent{
+				ID:        payment.ID,
+				Address:   payment.Payer,
+				Recipient: payment.Recipient,
+				Coin:      uint64(payment.Coin),
+				Amount:    payment.Value.String(),
+				Remaining: payment.Remaining - 1,
+			})
+		} else {
+			blockchain.stateDeliver.Accounts.SubBalance(payment.Payer, payment.Coin, payment.Value)
+			blockchain.stateDeliver.Accounts.AddBalance(payment.Recipient, payment.Coin, payment.Value)
+			blockchain.eventsDB.AddEvent(&eventsdb.RecurringPaymentEvent{
+				ID:        payment.ID,
+				Address:   payment.Payer,
+				Recipient: payment.Recipient,
+				Coin:      uint64(payment.Coin),
+				Amount:    payment.Value.String(),
+				Remaining: payment.Remaining - 1,
+			})
+		}
+		blockchain.stateDeliver.Recurring.Processed(payment.ID)
+	}
+}
@@ -0,0 +1,87 @@
+package minter
+
+import (
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestBlockchain_PayRecurringPayments(t *testing.T) {
+	stateDeliver, err := state.NewStateV3(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coin := types.GetBaseCoinID()
+	value := helpers.BipToPip(big.NewInt(10))
+	payer, lockedPayer, recipient := types.Address{1}, types.Address{2}, types.Address{3}
+	stateDeliver.Accounts.SetBalance(payer, coin, helpers.BipToPip(big.NewInt(25)))
+	// 15 of 20 are locked by the vesting schedule, so the spendable balance is less than the payment
+	stateDeliver.Accounts.SetBalance(lockedPayer, coin, helpers.BipToPip(big.NewInt(20)))
+	stateDeliver.Vestings.AddSchedule(lockedPayer, coin, helpers.BipToPip(big.NewInt(15)), 0, 100, 200, 1)
+
+	paid := stateDeliver.Recurring.Create(payer, recipient, coin, value, 5, 2, 1)
+	skipped := stateDeliver.Recurring.Create(lockedPayer, recipient, coin, value, 5, 1, 1)
+
+	events := &eventsdb.MockEvents{}
+	blockchain := &Blockchain{stateDeliver: stateDeliver, eventsDB: events}
+	blockchain.payRecurringPayments(1)
+
+	var paidEvent *eventsdb.RecurringPaymentEvent
+	var failedEvent *eventsdb.RecurringPaymentFailedEvent
+	for _, event := range events.LoadEvents(1) {
+		switch e := event.(type) {
+		case *eventsdb.RecurringPaymentEvent:
+			paidEvent = e
+		case *eventsdb.RecurringPaymentFailedEvent:
+			failedEvent = e
+		}
+	}
+	if paidEvent == nil || paidEvent.ID != paid || paidEvent.Address != payer || paidEvent.Remaining != 1 {
+		t.Fatalf("payment event is not correct: %+v", paidEvent)
+	}
+	if failedEvent == nil || failedEvent.ID != skipped || failedEvent.Address != lockedPayer || failedEvent.Remaining != 0 {
+		t.Fatalf("failed payment event is not correct: %+v", failedEvent)
+	}
+
+	if balance := stateDeliver.Accounts.GetBalance(recipient, coin); balance.Cmp(value) != 0 {
+		t.Fatalf("recipient balance is not correct: %s", balance)
+	}
+	if balance := stateDeliver.Accounts.GetBalance(payer, coin); balance.Cmp(helpers.BipToPip(big.NewInt(15))) != 0 {
+		t.Fatalf("payer balance is not correct: %s", balance)
+	}
+	if balance := stateDeliver.Accounts.GetBalance(lockedPayer, coin); balance.Cmp(helpers.BipToPip(big.NewInt(20))) != 0 {
+		t.Fatalf("balance of the payer of the skipped payment is not correct: %s", balance)
+	}
+
+	if payment := stateDeliver.Recurring.GetPayment(paid); payment == nil || payment.Remaining != 1 || payment.NextHeight != 6 {
+		t.Fatalf("payment is not correct: %+v", payment)
+	}
+	if stateDeliver.Recurring.GetPayment(skipped) != nil {
+		t.Fatal("skipped last payment is not removed")
+	}
+
+	if _, err := stateDeliver.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	blockchain.eventsDB = &eventsdb.MockEvents{}
+	blockchain.payRecurringPayments(6)
+
+	if balance := stateDeliver.Accounts.GetBalance(recipient, coin); balance.Cmp(helpers.BipToPip(big.NewInt(20))) != 0 {
+		t.Fatalf("recipient balance is not correct: %s", balance)
+	}
+
+	if _, err := stateDeliver.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if stateDeliver.Recurring.GetPayment(paid) != nil || len(stateDeliver.Recurring.GetDuePayments(11)) != 0 {
+		t.Fatal("finished payment is not removed")
+	}
+}
@@ -0,0 +1,22 @@
+package minter
+
+import (
+	"github.com/MinterTeam/minter-go-node/coreV2/rewardhistory"
+)
+
+// RewardHistory returns the history of stakes at payments of rewards, nil if it is disabled
+func (blockchain *Blockchain) RewardHistory() *rewardhistory.History {
+	return blockchain.rewardHistory
+}
+
+// addRewardStakes adds stakes of validators which rewards are paid for in the current block
+func (blockchain *Blockchain) addRewardStakes() {
+	for _, validator := range blockchain.stateDeliver.Validators.GetValidators() {
+		for _, stake := range blockchain.stateDeliver.Candidates.GetStakes(validator.PubKey) {
+			if stake.BipValue.Sign() == 0 {
+				continue
+			}
+			blockchain.rewardHistory.AddStake(stake.Owner, validator.PubKey, stake.Coin, stake.Value, stake.BipValue)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package minter
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// SimulationResult is the outcome of the transaction executed against a throwaway copy of the current state
+type SimulationResult struct {
+	Height         uint64
+	Response       transaction.Response
+	CommissionCoin types.CoinID
+	Commission     *big.Int
+	Balances       []BalanceDiff
+	Stakes         []StakeDiff
+	Pools          []PoolDiff
+	OrderFills     []transaction.FilledOrder
+	Events         eventsdb.Events
+}
+
+// BalanceDiff is a change of the address balance in the coin
+type BalanceDiff struct {
+	Address types.Address
+	Coin    types.CoinID
+	Before  *big.Int
+	After   *big.Int
+}
+
+// StakeDiff is a change of the owner stake (including pending stake) of the candidate in the coin
+type StakeDiff struct {
+	PubKey types.Pubkey
+	Owner  types.Address
+	Coin   types.CoinID
+	Before *big.Int
+	After  *big.Int
+}
+
+// PoolDiff is a change of the pool reserves
+type PoolDiff struct {
+	PoolID         uint32
+	Coin0, Coin1   types.CoinID
+	Reserve0Before *big.Int
+	Reserve1Before *big.Int
+	Reserve0After  *big.Int
+	Reserve1After  *big.Int
+}
+
+// SimulateTx executes the raw transaction with the deliver-path code against a copy of the last committed state
+// and returns the response with the changes it made. The copy is never committed.
+// If sender is not nil, the transaction is treated as unsigned and executed on behalf of the sender.
+func (blockchain *Blockchain) SimulateTx(rawTx []byte, sender *types.Address) (result *SimulationResult, err error) {
+	immutableTree := blockchain.stateDeliver.Tree().GetLastImmutable()
+	height := uint64(immutableTree.Version())
+
+	events := &eventsdb.MockEvents{}
+	simState, err := state.NewSimulationState(immutableTree, height, events)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := blockchain.executor
+	if executor == nil {
+		executor = GetExecutor(V3)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("transaction execution failed: %v", r)
+		}
+	}()
+
+	var response transaction.Response
+	if sender == nil {
+		response = executor.RunTx(simState, rawTx, big.NewInt(0), height+1, &sync.Map{}, 0, false)
+	} else {
+		unsignedExecutor, ok := executor.(interface {
+			RunUnsignedTx(context state.Interface, rawTx []byte, sender types.Address, rewardPool *big.Int, currentBlock uint64, currentMempool *sync.Map, minGasPrice uint32, notSaveTags bool) transaction.Response
+		})
+		if !ok {
+			return nil, fmt.Errorf("unsigned transactions are not supported by the current executor")
+		}
+		response = unsignedExecutor.RunUnsignedTx(simState, rawTx, *sender, big.NewInt(0), height+1, &sync.Map{}, 0, false)
+	}
+
+	before, err := state.NewCheckStateForImmutableTree(immutableTree)
+	if err != nil {
+		return nil, err
+	}
+
+	result = &SimulationResult{
+		Height:     height,
+		Response:   response,
+		Commission: big.NewInt(0),
+		Balances:   simulatedBalances(before, simState),
+		Stakes:     simulatedStakes(before, simState),
+		Pools:      simulatedPools(before, simState),
+		OrderFills: transaction.FilledOrders(response.Tags),
+		Events:     events.LoadEvents(uint32(height + 1)),
+	}
+
+	for _, tag := range response.Tags {
+		switch string(tag.Key) {
+		case "tx.commission_coin":
+			if coin, err := strconv.ParseUint(string(tag.Value), 10, 32); err == nil {
+				result.CommissionCoin = types.CoinID(coin)
+			}
+		case "tx.commission_amount", "tx.fail_fee":
+			result.Commission.SetString(string(tag.Value), 10)
+		}
+	}
+
+	return result, nil
+}
+
+func simulatedBalances(before *state.CheckState, after *state.State) []BalanceDiff {
+	var diffs []BalanceDiff
+	for address, coins := range after.Accounts.DirtyBalances() {
+		for _, coin := range coins {
+			diff := BalanceDiff{
+				Address: address,
+				Coin:    coin,
+				Before:  before.AccountsWithLocked().GetBalance(address, coin),
+				After:   after.Accounts.GetBalance(address, coin),
+			}
+			if diff.Before.Cmp(diff.After) == 0 {
+				continue
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Address != diffs[j].Address {
+			return diffs[i].Address.Compare(diffs[j].Address) == -1
+		}
+		return diffs[i].Coin < diffs[j].Coin
+	})
+
+	return diffs
+}
+
+type stakeKey struct {
+	Owner types.Address
+	Coin  types.CoinID
+}
+
+func addStake(stakes map[stakeKey]*big.Int, owner types.Address, coin types.CoinID, value *big.Int) {
+	key := stakeKey{Owner: owner, Coin: coin}
+	if stakes[key] == nil {
+		stakes[key] = big.NewInt(0)
+	}
+	stakes[key].Add(stakes[key], value)
+}
+
+func simulatedStakes(before *state.CheckState, after *state.State) []StakeDiff {
+	pubkeys := after.Candidates.DirtyStakes()
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	before.Candidates().LoadCandidates()
+
+	var diffs []StakeDiff
+	for _, pubkey := range pubkeys {
+		beforeStakes := map[stakeKey]*big.Int{}
+		if before.Candidates().Exists(pubkey) {
+			before.Candidates().LoadStakesOfCandidate(pubkey)
+			for _, stake := range before.Candidates().GetStakes(pubkey) {
+				addStake(beforeStakes, stake.Owner, stake.Coin, stake.Value)
+			}
+			for _, stake := range before.Candidates().GetUpdates(pubkey) {
+				addStake(beforeStakes, stake.Owner, stake.Coin, stake.Value)
+			}
+		}
+
+		afterStakes := map[stakeKey]*big.Int{}
+		for _, stake := range after.Candidates.GetStakes(pubkey) {
+			addStake(afterStakes, stake.Owner, stake.Coin, stake.Value)
+		}
+		for _, stake := range after.Candidates.GetUpdates(pubkey) {
+			addStake(afterStakes, stake.Owner, stake.Coin, stake.Value)
+		}
+
+		keys := map[stakeKey]struct{}{}
+		for key := range beforeStakes {
+			keys[key] = struct{}{}
+		}
+		for key := range afterStakes {
+			keys[key] = struct{}{}
+		}
+
+		for key := range keys {
+			diff := StakeDiff{PubKey: pubkey, Owner: key.Owner, Coin: key.Coin, Before: big.NewInt(0), After: big.NewInt(0)}
+			if value, ok := beforeStakes[key]; ok {
+				diff.Before = value
+			}
+			if value, ok := afterStakes[key]; ok {
+				diff.After = value
+			}
+			if diff.Before.Cmp(diff.After) == 0 {
+				continue
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].PubKey != diffs[j].PubKey {
+			return diffs[i].PubKey.String() < diffs[j].PubKey.String()
+		}
+		if diffs[i].Owner != diffs[j].Owner {
+			return diffs[i].Owner.Compare(diffs[j].Owner) == -1
+		}
+		return diffs[i].Coin < diffs[j].Coin
+	})
+
+	return diffs
+}
+
+func simulatedPools(before *state.CheckState, after *state.State) []PoolDiff {
+	var diffs []PoolDiff
+	for _, key := range after.SwapV2.DirtyPairs() {
+		reserve0After, reserve1After, id := after.SwapV2.SwapPool(key.Coin0, key.Coin1)
+		reserve0Before, reserve1Before, _ := before.Swap().SwapPool(key.Coin0, key.Coin1)
+		if reserve0Before == nil {
+			reserve0Before, reserve1Before = big.NewInt(0), big.NewInt(0)
+		}
+		diffs = append(diffs, PoolDiff{
+			PoolID:         id,
+			Coin0:          key.Coin0,
+			Coin1:          key.Coin1,
+			Reserve0Before: reserve0Before,
+			Reserve1Before: reserve1Before,
+			Reserve0After:  reserve0After,
+			Reserve1After:  reserve1After,
+		})
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].PoolID < diffs[j].PoolID
+	})
+
+	return diffs
+}
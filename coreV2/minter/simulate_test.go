@@ -0,0 +1,111 @@
+package minter
+
+import (
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestBlockchain_SimulateTx(t *testing.T) {
+	stateDeliver, err := state.NewStateV3(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(privateKey.PublicKey)
+	recipient := types.Address{1}
+	coin := types.GetBaseCoinID()
+
+	stateDeliver.Accounts.SetBalance(sender, coin, helpers.BipToPip(big.NewInt(100)))
+	stateDeliver.Commission.SetNewCommissions((&commission.Price{
+		Coin:        coin,
+		PayloadByte: big.NewInt(0),
+		Send:        helpers.BipToPip(big.NewInt(1)),
+		FailedTx:    big.NewInt(0),
+	}).Encode())
+	if _, err := stateDeliver.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedData, err := rlp.EncodeToBytes(transaction.SendData{
+		Coin:  coin,
+		To:    recipient,
+		Value: helpers.BipToPip(big.NewInt(10)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := transaction.Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          transaction.TypeSend,
+		Data:          encodedData,
+		SignatureType: transaction.SigTypeSingle,
+	}
+
+	blockchain := &Blockchain{stateDeliver: stateDeliver}
+
+	unsignedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsignedResult, err := blockchain.SimulateTx(unsignedTx, &sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	signedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := blockchain.SimulateTx(signedTx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, result := range []*SimulationResult{result, unsignedResult} {
+		if result.Response.Code != 0 {
+			t.Fatalf("Response code is not 0. Error: %s", result.Response.Log)
+		}
+		if result.Commission.Cmp(helpers.BipToPip(big.NewInt(1))) != 0 {
+			t.Fatalf("wrong commission %s", result.Commission)
+		}
+		if len(result.Balances) != 2 {
+			t.Fatalf("wrong balance diffs %#v", result.Balances)
+		}
+		for _, diff := range result.Balances {
+			var before, after *big.Int
+			switch diff.Address {
+			case sender:
+				before, after = helpers.BipToPip(big.NewInt(100)), helpers.BipToPip(big.NewInt(89))
+			case recipient:
+				before, after = big.NewInt(0), helpers.BipToPip(big.NewInt(10))
+			default:
+				t.Fatalf("unexpected balance diff of %s", diff.Address)
+			}
+			if diff.Before.Cmp(before) != 0 || diff.After.Cmp(after) != 0 {
+				t.Fatalf("wrong balance diff of %s: %s -> %s", diff.Address, diff.Before, diff.After)
+			}
+		}
+	}
+
+	if balance := stateDeliver.Accounts.GetBalance(recipient, coin); balance.Sign() != 0 {
+		t.Fatalf("simulation changed the state, balance %s", balance)
+	}
+}
@@ -0,0 +1,240 @@
+package minter
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/coreV2/webhooks"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// Webhooks returns the dispatcher of webhook notifications, nil if webhooks are disabled
+func (blockchain *Blockchain) Webhooks() *webhooks.Dispatcher {
+	return blockchain.webhooks
+}
+
+// blockVotes are votes for halt blocks and network updates delivered in the current block
+type blockVotes struct {
+	halts   map[uint64][]types.Pubkey
+	updates map[uint64]map[string][]types.Pubkey
+}
+
+// notifyTx adds notifications about transfers, stakes and votes of the delivered transaction, transactions of the batch are unpacked
+func (blockchain *Blockchain) notifyTx(rawTx []byte, response transaction.Response) {
+	if response.Code != code.OK {
+		return
+	}
+	tx, err := blockchain.executor.DecodeFromBytes(rawTx)
+	if err != nil {
+		return
+	}
+	sender, err := tx.Sender()
+	if err != nil {
+		return
+	}
+
+	height := blockchain.Height() + 1
+	txHash := strings.Title(fmt.Sprintf("Mt%x", tmTypes.Tx(rawTx).Hash()))
+	transfer := func(from, to types.Address, coin types.CoinID, value *big.Int) {
+		blockchain.webhooks.Notify(&webhooks.Notification{Kind: webhooks.KindSent, Height: height, Address: &from, TxHash: txHash,
+			Data: map[string]string{"to": to.String(), "coin": coin.String(), "value": value.String()}})
+		blockchain.webhooks.Notify(&webhooks.Notification{Kind: webhooks.KindReceived, Height: height, Address: &to, TxHash: txHash,
+			Data: map[string]string{"from": from.String(), "coin": coin.String(), "value": value.String()}})
+	}
+	redeem := func(rawCheck []byte) {
+		decodedCheck, err := check.DecodeFromBytes(rawCheck)
+		if err != nil {
+			return
+		}
+		issuer, err := decodedCheck.Sender()
+		if err != nil {
+			return
+		}
+		transfer(issuer, sender, decodedCheck.Coin, decodedCheck.Value)
+	}
+	stake := func(pubKey *types.Pubkey, action string, coin types.CoinID, value *big.Int) {
+		data := map[string]string{"action": action}
+		if value != nil {
+			data["coin"], data["value"] = coin.String(), value.String()
+		}
+		blockchain.webhooks.Notify(&webhooks.Notification{Kind: webhooks.KindStake, Height: height, Address: &sender, PubKey: pubKey, TxHash: txHash, Data: data})
+	}
+
+	var notify func(data transaction.Data)
+	notify = func(data transaction.Data) {
+		switch data := data.(type) {
+		case *transaction.BatchData:
+			txs, _ := data.DecodedTxs()
+			for _, d := range txs {
+				notify(d)
+			}
+		case *transaction.SendData:
+			transfer(sender, data.To, data.Coin, data.Value)
+		case *transaction.MultisendData:
+			for _, item := range data.List {
+				transfer(sender, item.To, item.Coin, item.Value)
+			}
+		case *transaction.RedeemCheckData:
+			redeem(data.RawCheck)
+		case *transaction.RedeemChecksData:
+			for _, item := range data.Checks {
+				redeem(item.RawCheck)
+			}
+		case *transaction.TransferFromData:
+			transfer(data.From, data.To, data.Coin, data.Value)
+		case *transaction.VestingData:
+			transfer(sender, data.To, data.Coin, data.Value)
+		case *transaction.ClaimHTLCData, *transaction.RefundHTLCData:
+			// the value is paid out of the HTLC, not by the sender of the transaction
+			payee, coin, value, ok := transaction.HTLCPayout(response.Tags)
+			if !ok {
+				return
+			}
+			action := "claim"
+			if _, ok := data.(*transaction.RefundHTLCData); ok {
+				action = "refund"
+			}
+			blockchain.webhooks.Notify(&webhooks.Notification{Kind: webhooks.KindReceived, Height: height, Address: &payee, TxHash: txHash,
+				Data: map[string]string{"htlc": action, "coin": coin.String(), "value": value.String()}})
+		case *transaction.DelegateDataV260:
+			stake(&data.PubKey, "delegate", data.Coin, data.Value)
+		case *transaction.UnbondDataV3:
+			stake(&data.PubKey, "unbond", data.Coin, data.Value)
+		case *transaction.MoveStakeData:
+			stake(&data.FromPubKey, "move", data.Coin, data.Value)
+		case *transaction.LockStakeData:
+			stake(nil, "lock", 0, nil)
+		case *transaction.SetHaltBlockData:
+			if blockchain.webhookVotes.halts == nil {
+				blockchain.webhookVotes.halts = map[uint64][]types.Pubkey{}
+			}
+			blockchain.webhookVotes.halts[data.Height] = append(blockchain.webhookVotes.halts[data.Height], data.PubKey)
+		case *transaction.VoteUpdateDataV230:
+			if blockchain.webhookVotes.updates == nil {
+				blockchain.webhookVotes.updates = map[uint64]map[string][]types.Pubkey{}
+			}
+			if blockchain.webhookVotes.updates[data.Height] == nil {
+				blockchain.webhookVotes.updates[data.Height] = map[string][]types.Pubkey{}
+			}
+			blockchain.webhookVotes.updates[data.Height][data.Version] = append(blockchain.webhookVotes.updates[data.Height][data.Version], data.PubKey)
+		}
+	}
+	notify(tx.GetDecodedData())
+}
+
+// notifyBlock adds notifications about events, missed blocks and reached votes of the block and queues them
+func (blockchain *Blockchain) notifyBlock(height uint64) error {
+	for _, event := range blockchain.eventsDB.LoadEvents(uint32(height)) {
+		if n := eventNotification(event, height); n != nil {
+			blockchain.webhooks.Notify(n)
+		}
+	}
+
+	blockchain.lockValidators.RLock()
+	for _, val := range blockchain.stateDeliver.Validators.GetValidators() {
+		if blockchain.validatorsStatuses[val.GetAddress()] != ValidatorAbsent {
+			continue
+		}
+		previous, ok := blockchain.missedBlocks[val.GetAddress()]
+		if !ok {
+			continue
+		}
+		pubKey := val.PubKey
+		blockchain.webhooks.Notify(&webhooks.Notification{Kind: webhooks.KindMissedBlocks, Height: height, PubKey: &pubKey,
+			MissedBlocks: val.CountAbsentTimes(), PreviousMissedBlocks: previous})
+	}
+	blockchain.lockValidators.RUnlock()
+
+	votes := blockchain.webhookVotes
+	blockchain.webhookVotes = blockVotes{}
+	for haltHeight, voted := range votes.halts {
+		halts := blockchain.stateDeliver.Halts.GetHaltBlocks(haltHeight)
+		if halts == nil {
+			continue
+		}
+		var all []types.Pubkey
+		for _, halt := range halts.List {
+			all = append(all, halt.Pubkey)
+		}
+		if blockchain.isVotingReached(all, voted) {
+			blockchain.webhooks.Notify(&webhooks.Notification{Kind: webhooks.KindHaltVotes, Height: height,
+				Data: map[string]string{"halt_height": fmt.Sprintf("%d", haltHeight)}})
+		}
+	}
+	for updateHeight, versions := range votes.updates {
+		for _, v := range blockchain.stateDeliver.Updates.GetVotes(updateHeight) {
+			voted, ok := versions[v.Version]
+			if !ok {
+				continue
+			}
+			if blockchain.isVotingReached(v.Votes, voted) {
+				blockchain.webhooks.Notify(&webhooks.Notification{Kind: webhooks.KindUpdateVotes, Height: height,
+					Data: map[string]string{"update_height": fmt.Sprintf("%d", updateHeight), "version": v.Version}})
+			}
+		}
+	}
+
+	return blockchain.webhooks.Commit(height)
+}
+
+// isVotingReached reports whether the votes have the consensus power and did not have it without the votes of the block
+func (blockchain *Blockchain) isVotingReached(votes []types.Pubkey, votedInBlock []types.Pubkey) bool {
+	if blockchain.totalPower == nil || blockchain.totalPower.Sign() != 1 {
+		return false
+	}
+	inBlock := map[types.Pubkey]struct{}{}
+	for _, pubKey := range votedInBlock {
+		inBlock[pubKey] = struct{}{}
+	}
+
+	total, before := big.NewInt(0), big.NewInt(0)
+	for _, pubKey := range votes {
+		power, ok := blockchain.validatorsPowers[pubKey]
+		if !ok {
+			continue
+		}
+		total.Add(total, power)
+		if _, ok := inBlock[pubKey]; !ok {
+			before.Add(before, power)
+		}
+	}
+
+	consensus := big.NewFloat(votingPowerConsensus)
+	share := func(power *big.Int) *big.Float {
+		return new(big.Float).Quo(new(big.Float).SetInt(power), new(big.Float).SetInt(blockchain.totalPower))
+	}
+	return share(total).Cmp(consensus) == 1 && share(before).Cmp(consensus) != 1
+}
+
+func eventNotification(event eventsdb.Event, height uint64) *webhooks.Notification {
+	switch e := event.(type) {
+	case *eventsdb.JailEvent:
+		pubKey := e.ValidatorPubKey
+		return &webhooks.Notification{Kind: webhooks.KindJailed, Height: height, PubKey: &pubKey,
+			Data: map[string]string{"jailed_until": fmt.Sprintf("%d", e.JailedUntil)}}
+	case *eventsdb.OrderFilledEvent:
+		address := e.Address
+		return &webhooks.Notification{Kind: webhooks.KindOrderFilled, Height: height, Address: &address, TxHash: e.TxHash,
+			Data: map[string]string{"id": fmt.Sprintf("%d", e.ID), "coin_sell": fmt.Sprintf("%d", e.CoinSell), "sold": e.Sold, "coin_buy": fmt.Sprintf("%d", e.CoinBuy), "bought": e.Bought}}
+	case *eventsdb.UnbondEvent:
+		return stakeEventNotification(height, e.Address, e.ValidatorPubKey, "unbonded", e.Coin, e.Amount)
+	case *eventsdb.StakeKickEvent:
+		return stakeEventNotification(height, e.Address, &e.ValidatorPubKey, "kicked", e.Coin, e.Amount)
+	case *eventsdb.SlashEvent:
+		return stakeEventNotification(height, e.Address, &e.ValidatorPubKey, "slashed", e.Coin, e.Amount)
+	case *eventsdb.StakeMoveEvent:
+		return stakeEventNotification(height, e.Address, &e.ToCandidatePubKey, "moved", e.Coin, e.Amount)
+	}
+	return nil
+}
+
+func stakeEventNotification(height uint64, address types.Address, pubKey *types.Pubkey, action string, coin uint64, amount string) *webhooks.Notification {
+	return &webhooks.Notification{Kind: webhooks.KindStake, Height: height, Address: &address, PubKey: pubKey,
+		Data: map[string]string{"action": action, "coin": fmt.Sprintf("%d", coin), "value": amount}}
+}
@@ -0,0 +1,259 @@
+package multisigpool
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+const (
+	txPrefix       = 't' // hash -> tx
+	multisigPrefix = 'm' // multisig + hash -> empty
+)
+
+const (
+	// MaxTxsPerMultisig is the max number of transactions of the multisig collecting signatures
+	MaxTxsPerMultisig = 100
+	// TxLifetime is the number of blocks the transaction collects signatures for
+	TxLifetime = 17280
+)
+
+var (
+	// ErrTxExists is returned when the transaction with the same hash is already collecting signatures
+	ErrTxExists = errors.New("transaction already exists")
+	// ErrTxNotFound is returned when there is no transaction with the hash
+	ErrTxNotFound = errors.New("transaction not found")
+	// ErrSignatureExists is returned when the member has already signed the transaction
+	ErrSignatureExists = errors.New("transaction already signed by the member")
+	// ErrTooManyTxs is returned when the multisig already has MaxTxsPerMultisig transactions collecting signatures
+	ErrTooManyTxs = errors.New("too many transactions of the multisig")
+)
+
+// Signature is the signature of the member over the hash of the transaction, Data is the encoded signature
+type Signature struct {
+	Signer types.Address `json:"signer"`
+	Data   []byte        `json:"data"`
+}
+
+// Tx is the multisig transaction collecting signatures of members, Tx is the encoded transaction without signatures.
+// The transaction is removed from the pool by Prune at ExpireHeight or once the nonce of the multisig reaches Nonce.
+type Tx struct {
+	Hash         types.Hash    `json:"hash"`
+	Multisig     types.Address `json:"multisig"`
+	Nonce        uint64        `json:"nonce"`
+	ExpireHeight uint64        `json:"expire_height"`
+	Tx           []byte        `json:"tx"`
+	Signatures   []*Signature  `json:"signatures"`
+}
+
+// Pool keeps multisig transactions on disk until members sign them enough to be sent
+type Pool struct {
+	lock sync.Mutex
+	db   db.DB
+}
+
+// NewPool creates the pool of multisig transactions in given DB
+func NewPool(db db.DB) *Pool {
+	return &Pool{db: db}
+}
+
+// Add stores the transaction without signatures
+func (p *Pool) Add(tx *Tx) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if has, err := p.db.Has(txKey(tx.Hash)); err != nil {
+		return err
+	} else if has {
+		return ErrTxExists
+	}
+
+	hashes, err := p.hashes(tx.Multisig)
+	if err != nil {
+		return err
+	}
+	if len(hashes) >= MaxTxsPerMultisig {
+		return ErrTooManyTxs
+	}
+
+	return p.set(tx)
+}
+
+// Get returns the transaction by its hash
+func (p *Pool) Get(hash types.Hash) (*Tx, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.get(hash)
+}
+
+// AddSignature adds the signature of the member to the transaction and returns the transaction with it
+func (p *Pool) AddSignature(hash types.Hash, signature *Signature) (*Tx, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	tx, err := p.get(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range tx.Signatures {
+		if s.Signer == signature.Signer {
+			return nil, ErrSignatureExists
+		}
+	}
+
+	tx.Signatures = append(tx.Signatures, signature)
+	if err := p.set(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Remove deletes the sent transaction
+func (p *Pool) Remove(hash types.Hash) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	tx, err := p.get(hash)
+	if err == ErrTxNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return p.delete(tx)
+}
+
+// Prune removes transactions expired by the height and ones which can't be sent anymore as the nonce of the multisig has reached theirs
+func (p *Pool) Prune(height uint64, nonce func(multisig types.Address) uint64) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	it, err := p.db.Iterator([]byte{txPrefix}, []byte{txPrefix + 1})
+	if err != nil {
+		return err
+	}
+
+	var stale []*Tx
+	for ; it.Valid(); it.Next() {
+		tx := &Tx{}
+		if err := json.Unmarshal(it.Value(), tx); err != nil {
+			it.Close()
+			return err
+		}
+		if tx.ExpireHeight <= height || tx.Nonce <= nonce(tx.Multisig) {
+			stale = append(stale, tx)
+		}
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return err
+	}
+	it.Close()
+
+	for _, tx := range stale {
+		if err := p.delete(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Txs returns transactions of the multisig collecting signatures
+func (p *Pool) Txs(multisig types.Address) ([]*Tx, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	hashes, err := p.hashes(multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*Tx, 0, len(hashes))
+	for _, hash := range hashes {
+		tx, err := p.get(hash)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// hashes returns hashes of transactions of the multisig
+func (p *Pool) hashes(multisig types.Address) ([]types.Hash, error) {
+	var lastHash types.Hash
+	for i := range lastHash {
+		lastHash[i] = 0xff
+	}
+	prefix := append([]byte{multisigPrefix}, multisig.Bytes()...)
+	it, err := p.db.Iterator(prefix, append(multisigKey(multisig, lastHash), 0))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var hashes []types.Hash
+	for ; it.Valid(); it.Next() {
+		hashes = append(hashes, types.BytesToHash(it.Key()[len(prefix):]))
+	}
+	return hashes, it.Error()
+}
+
+func (p *Pool) get(hash types.Hash) (*Tx, error) {
+	value, err := p.db.Get(txKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, ErrTxNotFound
+	}
+
+	tx := &Tx{}
+	if err := json.Unmarshal(value, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (p *Pool) set(tx *Tx) error {
+	value, err := json.Marshal(tx)
+	if err != nil {
+		return err
+	}
+
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(txKey(tx.Hash), value); err != nil {
+		return err
+	}
+	if err := batch.Set(multisigKey(tx.Multisig, tx.Hash), []byte{}); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (p *Pool) delete(tx *Tx) error {
+	batch := p.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Delete(txKey(tx.Hash)); err != nil {
+		return err
+	}
+	if err := batch.Delete(multisigKey(tx.Multisig, tx.Hash)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func txKey(hash types.Hash) []byte {
+	return append([]byte{txPrefix}, hash.Bytes()...)
+}
+
+func multisigKey(multisig types.Address, hash types.Hash) []byte {
+	return append(append([]byte{multisigPrefix}, multisig.Bytes()...), hash.Bytes()...)
+}
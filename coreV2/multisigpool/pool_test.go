@@ -0,0 +1,101 @@
+package multisigpool
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestPool(t *testing.T) {
+	t.Parallel()
+	p := NewPool(db.NewMemDB())
+
+	multisig := types.Address{1}
+	first := &Tx{Hash: types.Hash{1}, Multisig: multisig, Tx: []byte{1, 2, 3}}
+	second := &Tx{Hash: types.Hash{0xff}, Multisig: multisig, Tx: []byte{4}}
+	other := &Tx{Hash: types.Hash{2}, Multisig: types.Address{2}, Tx: []byte{5}}
+	for _, tx := range []*Tx{first, second, other} {
+		if err := p.Add(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Add(first); err != ErrTxExists {
+		t.Fatalf("Error is not correct: %v", err)
+	}
+
+	tx, err := p.AddSignature(first.Hash, &Signature{Signer: types.Address{3}, Data: []byte{6}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.Signatures) != 1 || tx.Signatures[0].Signer != (types.Address{3}) {
+		t.Fatalf("Signatures are not correct: %+v", tx.Signatures)
+	}
+	if _, err := p.AddSignature(first.Hash, &Signature{Signer: types.Address{3}, Data: []byte{7}}); err != ErrSignatureExists {
+		t.Fatalf("Error is not correct: %v", err)
+	}
+	if _, err := p.AddSignature(types.Hash{3}, &Signature{Signer: types.Address{3}}); err != ErrTxNotFound {
+		t.Fatalf("Error is not correct: %v", err)
+	}
+
+	txs, err := p.Txs(multisig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 || txs[0].Hash != first.Hash || len(txs[0].Signatures) != 1 || txs[1].Hash != second.Hash {
+		t.Fatalf("Transactions are not correct: %+v", txs)
+	}
+
+	if err := p.Remove(first.Hash); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Get(first.Hash); err != ErrTxNotFound {
+		t.Fatalf("Error is not correct: %v", err)
+	}
+	if txs, err := p.Txs(multisig); err != nil || len(txs) != 1 || txs[0].Hash != second.Hash {
+		t.Fatalf("Transactions are not correct: %+v, %v", txs, err)
+	}
+}
+
+func TestPool_Prune(t *testing.T) {
+	t.Parallel()
+	p := NewPool(db.NewMemDB())
+
+	multisig := types.Address{1}
+	expired := &Tx{Hash: types.Hash{1}, Multisig: multisig, Nonce: 5, ExpireHeight: 10}
+	sent := &Tx{Hash: types.Hash{2}, Multisig: multisig, Nonce: 2, ExpireHeight: 20}
+	pending := &Tx{Hash: types.Hash{3}, Multisig: multisig, Nonce: 3, ExpireHeight: 20}
+	for _, tx := range []*Tx{expired, sent, pending} {
+		if err := p.Add(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := p.Prune(10, func(types.Address) uint64 { return 2 }); err != nil {
+		t.Fatal(err)
+	}
+	if txs, err := p.Txs(multisig); err != nil || len(txs) != 1 || txs[0].Hash != pending.Hash {
+		t.Fatalf("Transactions are not correct: %+v, %v", txs, err)
+	}
+	if _, err := p.Get(expired.Hash); err != ErrTxNotFound {
+		t.Fatalf("Error is not correct: %v", err)
+	}
+}
+
+func TestPool_MaxTxsPerMultisig(t *testing.T) {
+	t.Parallel()
+	p := NewPool(db.NewMemDB())
+
+	multisig := types.Address{1}
+	for i := 0; i < MaxTxsPerMultisig; i++ {
+		if err := p.Add(&Tx{Hash: types.Hash{byte(i), 1}, Multisig: multisig}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Add(&Tx{Hash: types.Hash{0xff, 0xff}, Multisig: multisig}); err != ErrTooManyTxs {
+		t.Fatalf("Error is not correct: %v", err)
+	}
+	if err := p.Add(&Tx{Hash: types.Hash{0xff, 0xff}, Multisig: types.Address{2}}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,241 @@
+package rewardhistory
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"time"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+const (
+	periodPrefix    = 'p'
+	addressPrefix   = 'a'
+	candidatePrefix = 'c'
+)
+
+// Stake is the stake of the delegator at the height of rewards payment
+type Stake struct {
+	PubKey   types.Pubkey `json:"pub_key"`
+	Coin     types.CoinID `json:"coin"`
+	Value    *big.Int     `json:"value"`
+	BipValue *big.Int     `json:"bip_value"`
+}
+
+// Period is the payment of rewards, Interval is the time since the previous payment, zero for the first known payment
+type Period struct {
+	Height   uint64        `json:"height"`
+	Time     time.Time     `json:"time"`
+	Interval time.Duration `json:"interval"`
+}
+
+// AddressRecord contains stakes of the address at the payment of rewards to it
+type AddressRecord struct {
+	Period
+	Stakes []*Stake `json:"stakes"`
+}
+
+// CandidateRecord contains the total stake of the candidate at the payment of rewards
+type CandidateRecord struct {
+	Period
+	BipStake *big.Int `json:"bip_stake"`
+}
+
+// History stores stakes of delegators and candidates at every payment of rewards,
+// rewards themselves are stored by the events DB at the same heights
+type History struct {
+	lock       sync.Mutex
+	db         db.DB
+	blockTime  time.Time
+	lastTime   time.Time
+	stakes     map[types.Address][]*Stake
+	candidates map[types.Pubkey]*big.Int
+}
+
+// NewHistory creates the reward history in given DB
+func NewHistory(db db.DB) *History {
+	h := &History{db: db}
+	it, err := db.ReverseIterator([]byte{periodPrefix}, []byte{periodPrefix + 1})
+	if err != nil {
+		panic(err)
+	}
+	defer it.Close()
+	if it.Valid() {
+		period := &Period{}
+		if err := json.Unmarshal(it.Value(), period); err == nil {
+			h.lastTime = period.Time
+		}
+	}
+	return h
+}
+
+// StartBlock sets the time of the block which stakes are added next
+func (h *History) StartBlock(blockTime time.Time) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.blockTime = blockTime
+}
+
+// AddStake adds the stake rewards of the current block are paid for
+func (h *History) AddStake(owner types.Address, pubKey types.Pubkey, coin types.CoinID, value, bipValue *big.Int) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.stakes == nil {
+		h.stakes = map[types.Address][]*Stake{}
+		h.candidates = map[types.Pubkey]*big.Int{}
+	}
+	h.stakes[owner] = append(h.stakes[owner], &Stake{
+		PubKey:   pubKey,
+		Coin:     coin,
+		Value:    big.NewInt(0).Set(value),
+		BipValue: big.NewInt(0).Set(bipValue),
+	})
+	if h.candidates[pubKey] == nil {
+		h.candidates[pubKey] = big.NewInt(0)
+	}
+	h.candidates[pubKey].Add(h.candidates[pubKey], bipValue)
+}
+
+// Commit writes stakes added since the previous commit and addresses of reward events of the block at given height.
+// Nothing is written for blocks without rewards.
+func (h *History) Commit(height uint64, events eventsdb.Events) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	stakes, candidates := h.stakes, h.candidates
+	h.stakes, h.candidates = nil, nil
+
+	rewarded := map[types.Address]struct{}{}
+	for _, event := range events {
+		if reward, ok := event.(*eventsdb.RewardEvent); ok {
+			rewarded[reward.Address] = struct{}{}
+			if _, ok := candidates[reward.ValidatorPubKey]; !ok {
+				if candidates == nil {
+					candidates = map[types.Pubkey]*big.Int{}
+				}
+				candidates[reward.ValidatorPubKey] = big.NewInt(0)
+			}
+		}
+	}
+	if len(stakes) == 0 && len(rewarded) == 0 {
+		return nil
+	}
+
+	period := Period{Height: height, Time: h.blockTime}
+	if !h.lastTime.IsZero() {
+		period.Interval = h.blockTime.Sub(h.lastTime)
+	}
+
+	batch := h.db.NewBatch()
+	defer batch.Close()
+
+	value, err := json.Marshal(&period)
+	if err != nil {
+		return err
+	}
+	if err := batch.Set(periodKey(height), value); err != nil {
+		return err
+	}
+
+	if stakes == nil {
+		stakes = map[types.Address][]*Stake{}
+	}
+	for address := range rewarded {
+		if _, ok := stakes[address]; !ok {
+			stakes[address] = nil
+		}
+	}
+	for address, list := range stakes {
+		value, err := json.Marshal(&AddressRecord{Period: period, Stakes: list})
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(addressKey(address, height), value); err != nil {
+			return err
+		}
+	}
+	for pubKey, bipStake := range candidates {
+		value, err := json.Marshal(&CandidateRecord{Period: period, BipStake: bipStake})
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(candidateKey(pubKey, height), value); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	h.lastTime = h.blockTime
+	return nil
+}
+
+// AddressRecords returns records of payments of rewards to the address in the height range [from, to]
+func (h *History) AddressRecords(address types.Address, from, to uint64) ([]*AddressRecord, error) {
+	it, err := h.db.Iterator(addressKey(address, from), addressKey(address, to+1))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var records []*AddressRecord
+	for ; it.Valid(); it.Next() {
+		record := &AddressRecord{}
+		if err := json.Unmarshal(it.Value(), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, it.Error()
+}
+
+// CandidateRecords returns records of payments of rewards for the candidate in the height range [from, to]
+func (h *History) CandidateRecords(pubKey types.Pubkey, from, to uint64) ([]*CandidateRecord, error) {
+	it, err := h.db.Iterator(candidateKey(pubKey, from), candidateKey(pubKey, to+1))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var records []*CandidateRecord
+	for ; it.Valid(); it.Next() {
+		record := &CandidateRecord{}
+		if err := json.Unmarshal(it.Value(), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, it.Error()
+}
+
+func periodKey(height uint64) []byte {
+	return append([]byte{periodPrefix}, heightBytes(height)...)
+}
+
+func addressKey(address types.Address, height uint64) []byte {
+	key := make([]byte, 0, 1+types.AddressLength+8)
+	key = append(key, addressPrefix)
+	key = append(key, address.Bytes()...)
+	return append(key, heightBytes(height)...)
+}
+
+func candidateKey(pubKey types.Pubkey, height uint64) []byte {
+	key := make([]byte, 0, 1+types.PubKeyLength+8)
+	key = append(key, candidatePrefix)
+	key = append(key, pubKey.Bytes()...)
+	return append(key, heightBytes(height)...)
+}
+
+func heightBytes(height uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, height)
+	return b
+}
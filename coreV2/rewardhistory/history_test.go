@@ -0,0 +1,69 @@
+package rewardhistory
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestHistory_Records(t *testing.T) {
+	memDB := db.NewMemDB()
+	history := NewHistory(memDB)
+	delegator := types.Address{1}
+	validator := types.Address{2}
+	pubKey := types.Pubkey{3}
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	history.StartBlock(start)
+	history.AddStake(delegator, pubKey, 0, big.NewInt(100), big.NewInt(100))
+	history.AddStake(delegator, pubKey, 1, big.NewInt(10), big.NewInt(50))
+	events := eventsdb.Events{&eventsdb.RewardEvent{Role: eventsdb.RoleValidator.String(), Address: validator, Amount: "5", ValidatorPubKey: pubKey}}
+	if err := history.Commit(720, events); err != nil {
+		t.Fatal(err)
+	}
+
+	// blocks without rewards are not stored
+	history.StartBlock(start.Add(time.Minute))
+	if err := history.Commit(721, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the time of the last payment survives a restart
+	history = NewHistory(memDB)
+	history.StartBlock(start.Add(time.Hour))
+	history.AddStake(delegator, pubKey, 0, big.NewInt(200), big.NewInt(200))
+	if err := history.Commit(1440, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := history.AddressRecords(delegator, 0, 1440)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Height != 720 || len(records[0].Stakes) != 2 || records[0].Interval != 0 {
+		t.Fatalf("unexpected records %#v", records)
+	}
+	if records[1].Interval != time.Hour || records[1].Stakes[0].BipValue.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("unexpected record %#v", records[1])
+	}
+
+	records, err = history.AddressRecords(validator, 0, 1440)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || len(records[0].Stakes) != 0 {
+		t.Fatalf("unexpected records of the validator %#v", records)
+	}
+
+	candidateRecords, err := history.CandidateRecords(pubKey, 721, 1440)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidateRecords) != 1 || candidateRecords[0].BipStake.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("unexpected candidate records %#v", candidateRecords)
+	}
+}
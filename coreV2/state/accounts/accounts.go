@@ -40,6 +40,8 @@ type Accounts struct {
 	db  atomic.Value
 	bus *bus.Bus
 
+	parent *Accounts
+
 	lock sync.RWMutex
 }
 
@@ -71,6 +73,12 @@ func (a *Accounts) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
 	a.db.Store(immutableTree)
 }
 
+// SetParent makes accounts the cache-wrapped copy of the parent reading the same tree:
+// accounts missing in the cache are copied from the cache of the parent, changes are never written to the parent
+func (a *Accounts) SetParent(parent *Accounts) {
+	a.parent = parent
+}
+
 func (a *Accounts) Commit(db *iavl.MutableTree, version int64) error {
 	accounts := a.getOrderedDirtyAccounts()
 	for _, address := range accounts {
@@ -140,6 +148,10 @@ func (a *Accounts) Commit(db *iavl.MutableTree, version int64) error {
 						panic(fmt.Sprintf("Address %s has negative balance of CoinID %d: %s", account.address.String(), coin.Uint32(), balance))
 					}
 				}
+
+				if holders := a.bus.Holders(); holders != nil {
+					holders.SetBalance(address, coin, balance)
+				}
 			}
 
 			account.lock.Lock()
@@ -304,6 +316,14 @@ func (a *Accounts) get(address types.Address) *Model {
 		return account
 	}
 
+	if a.parent != nil {
+		if account := a.parent.getFromMap(address); account != nil {
+			account = account.copy(a.markDirty)
+			a.setToMap(address, account)
+			return account
+		}
+	}
+
 	path := []byte{mainPrefix}
 	path = append(path, address[:]...)
 	_, enc := a.immutableTree().Get(path)
@@ -397,6 +417,35 @@ func (a *Accounts) GetBalances(address types.Address) []Balance {
 	return balances
 }
 
+// DirtyBalances returns coins of changed balances by address, which are not committed yet
+func (a *Accounts) DirtyBalances() map[types.Address][]types.CoinID {
+	balances := map[types.Address][]types.CoinID{}
+	for _, address := range a.getOrderedDirtyAccounts() {
+		account := a.getFromMap(address)
+		if account == nil {
+			continue
+		}
+
+		account.lock.RLock()
+		coins := make([]types.CoinID, 0, len(account.dirtyBalances))
+		for coin := range account.dirtyBalances {
+			coins = append(coins, coin)
+		}
+		account.lock.RUnlock()
+
+		if len(coins) == 0 {
+			continue
+		}
+
+		sort.SliceStable(coins, func(i, j int) bool {
+			return coins[i] < coins[j]
+		})
+		balances[address] = coins
+	}
+
+	return balances
+}
+
 func (a *Accounts) markDirty(addr types.Address) {
 	a.lock.Lock()
 	defer a.lock.Unlock()
@@ -458,6 +507,18 @@ func (a *Accounts) Export(state *types.AppState) {
 	})
 }
 
+// PathAccount returns the tree key of the account info (nonce and multisig data)
+func PathAccount(address types.Address) []byte {
+	return append([]byte{mainPrefix}, address[:]...)
+}
+
+// PathBalance returns the tree key of the address balance in the coin
+func PathBalance(address types.Address, coin types.CoinID) []byte {
+	path := PathAccount(address)
+	path = append(path, balancePrefix)
+	return append(path, coin.Bytes()...)
+}
+
 func (a *Accounts) GetAccount(address types.Address) *Model {
 	return a.getOrNew(address)
 }
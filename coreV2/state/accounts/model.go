@@ -75,6 +75,39 @@ func (model *Model) setNonce(nonce uint64) {
 	model.markDirty(model.address)
 }
 
+// copy returns the deep copy of the model marking the given cache dirty
+func (model *Model) copy(markDirty func(types.Address)) *Model {
+	model.lock.RLock()
+	defer model.lock.RUnlock()
+
+	balances := make(map[types.CoinID]*big.Int, len(model.balances))
+	for coin, balance := range model.balances {
+		balances[coin] = big.NewInt(0).Set(balance)
+	}
+	dirtyBalances := make(map[types.CoinID]struct{}, len(model.dirtyBalances))
+	for coin := range model.dirtyBalances {
+		dirtyBalances[coin] = struct{}{}
+	}
+
+	return &Model{
+		Nonce: model.Nonce,
+		MultisigData: Multisig{
+			Threshold: model.MultisigData.Threshold,
+			Weights:   append([]uint32(nil), model.MultisigData.Weights...),
+			Addresses: append([]types.Address(nil), model.MultisigData.Addresses...),
+		},
+		LockStakeUntilBlock: model.LockStakeUntilBlock,
+		address:             model.address,
+		coins:               append([]types.CoinID{}, model.coins...),
+		balances:            balances,
+		hasDirtyCoins:       model.hasDirtyCoins,
+		dirtyBalances:       dirtyBalances,
+		isDirty:             model.isDirty,
+		isNew:               model.isNew,
+		markDirty:           markDirty,
+	}
+}
+
 func (model *Model) getBalance(coin types.CoinID) *big.Int {
 	model.lock.RLock()
 	defer model.lock.RUnlock()
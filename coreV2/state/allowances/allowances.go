@@ -0,0 +1,215 @@
+package allowances
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('l')
+
+type RAllowances interface {
+	Export(state *types.AppState)
+	GetAllowance(owner, spender types.Address, coin types.CoinID) *big.Int
+	GetAllowances(owner types.Address) []*Allowance
+}
+
+// Allowances keeps values of coins which spenders can transfer from balances of owners,
+// coins stay in balances of owners until they are transferred
+type Allowances struct {
+	list  map[types.Address]*Model
+	dirty map[types.Address]struct{}
+
+	bus *bus.Bus
+	db  atomic.Value
+
+	lock sync.RWMutex
+}
+
+func NewAllowances(stateBus *bus.Bus, db *iavl.ImmutableTree) *Allowances {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Allowances{
+		bus:   stateBus,
+		db:    immutableTree,
+		list:  map[types.Address]*Model{},
+		dirty: map[types.Address]struct{}{},
+	}
+}
+
+func (a *Allowances) immutableTree() *iavl.ImmutableTree {
+	db := a.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (a *Allowances) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	a.db.Store(immutableTree)
+}
+
+func (a *Allowances) Commit(db *iavl.MutableTree, version int64) error {
+	for _, owner := range a.getOrderedDirty() {
+		model := a.getFromMap(owner)
+		path := getPath(owner)
+
+		a.lock.Lock()
+		delete(a.dirty, owner)
+		if len(model.Allowances) == 0 {
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(model)
+			if err != nil {
+				a.lock.Unlock()
+				return fmt.Errorf("can't encode object at %s: %v", owner.String(), err)
+			}
+
+			db.Set(path, data)
+		}
+		a.lock.Unlock()
+	}
+
+	return nil
+}
+
+// GetAllowance returns the value of the coin which the spender can transfer from the balance of the owner
+func (a *Allowances) GetAllowance(owner, spender types.Address, coin types.CoinID) *big.Int {
+	model := a.get(owner)
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if i := model.find(spender, coin); i != -1 {
+		return big.NewInt(0).Set(model.Allowances[i].Value)
+	}
+	return big.NewInt(0)
+}
+
+// GetAllowances returns all allowances given by the owner
+func (a *Allowances) GetAllowances(owner types.Address) []*Allowance {
+	model := a.get(owner)
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	allowances := make([]*Allowance, 0, len(model.Allowances))
+	for _, allowance := range model.Allowances {
+		allowances = append(allowances, &Allowance{
+			Spender: allowance.Spender,
+			Coin:    allowance.Coin,
+			Value:   big.NewInt(0).Set(allowance.Value),
+		})
+	}
+	return allowances
+}
+
+// SetAllowance replaces the allowance of the spender, zero value removes it
+func (a *Allowances) SetAllowance(owner, spender types.Address, coin types.CoinID, value *big.Int) {
+	model := a.get(owner)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	i := model.find(spender, coin)
+	switch {
+	case value.Sign() == 0 && i != -1:
+		model.Allowances = append(model.Allowances[:i:i], model.Allowances[i+1:]...)
+	case value.Sign() == 0:
+		return
+	case i != -1:
+		model.Allowances[i].Value = big.NewInt(0).Set(value)
+	default:
+		model.Allowances = append(model.Allowances, &Allowance{
+			Spender: spender,
+			Coin:    coin,
+			Value:   big.NewInt(0).Set(value),
+		})
+	}
+	a.dirty[owner] = struct{}{}
+}
+
+// SubAllowance decreases the allowance of the spender after the transfer
+func (a *Allowances) SubAllowance(owner, spender types.Address, coin types.CoinID, value *big.Int) {
+	allowance := a.GetAllowance(owner, spender, coin)
+	a.SetAllowance(owner, spender, coin, allowance.Sub(allowance, value))
+}
+
+func (a *Allowances) Export(state *types.AppState) {
+	var owners []types.Address
+	a.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		owners = append(owners, types.BytesToAddress(key[1:]))
+		return false
+	})
+
+	for _, owner := range owners {
+		for _, allowance := range a.GetAllowances(owner) {
+			state.Allowances = append(state.Allowances, types.Allowance{
+				Owner:   owner,
+				Spender: allowance.Spender,
+				Coin:    uint64(allowance.Coin),
+				Value:   allowance.Value.String(),
+			})
+		}
+	}
+}
+
+func (a *Allowances) get(owner types.Address) *Model {
+	if model := a.getFromMap(owner); model != nil {
+		return model
+	}
+
+	model := &Model{owner: owner}
+	if _, enc := a.immutableTree().Get(getPath(owner)); len(enc) != 0 {
+		if err := rlp.DecodeBytes(enc, model); err != nil {
+			panic(fmt.Sprintf("failed to decode allowances of %s: %s", owner.String(), err))
+		}
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	// the owner may be loaded concurrently
+	if loaded, ok := a.list[owner]; ok {
+		return loaded
+	}
+	a.list[owner] = model
+
+	return model
+}
+
+func (a *Allowances) getFromMap(owner types.Address) *Model {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.list[owner]
+}
+
+func (a *Allowances) getOrderedDirty() []types.Address {
+	a.lock.RLock()
+	keys := make([]types.Address, 0, len(a.dirty))
+	for k := range a.dirty {
+		keys = append(keys, k)
+	}
+	a.lock.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == -1
+	})
+
+	return keys
+}
+
+func getPath(owner types.Address) []byte {
+	return append([]byte{mainPrefix}, owner.Bytes()...)
+}
@@ -0,0 +1,55 @@
+package allowances
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestAllowances(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	a := NewAllowances(b, mutableTree.GetLastImmutable())
+
+	owner, spender := types.Address{1}, types.Address{2}
+	a.SetAllowance(owner, spender, 0, big.NewInt(100))
+	a.SetAllowance(owner, spender, 1, big.NewInt(200))
+	a.SetAllowance(owner, types.Address{3}, 0, big.NewInt(300))
+
+	if _, _, err := mutableTree.Commit(a); err != nil {
+		t.Fatal(err)
+	}
+
+	a = NewAllowances(b, mutableTree.GetLastImmutable())
+	if allowance := a.GetAllowance(owner, spender, 1); allowance.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("Allowance is not correct: %s", allowance)
+	}
+	if allowance := a.GetAllowance(spender, owner, 1); allowance.Sign() != 0 {
+		t.Fatalf("Allowance is not correct: %s", allowance)
+	}
+
+	a.SubAllowance(owner, spender, 0, big.NewInt(40))
+	a.SetAllowance(owner, spender, 1, big.NewInt(0))
+	if allowances := a.GetAllowances(owner); len(allowances) != 2 || allowances[0].Value.Cmp(big.NewInt(60)) != 0 || allowances[1].Spender != (types.Address{3}) {
+		t.Fatalf("Allowances are not correct: %+v", allowances)
+	}
+
+	a.SetAllowance(owner, spender, 0, big.NewInt(0))
+	a.SetAllowance(owner, types.Address{3}, 0, big.NewInt(0))
+	a.SetAllowance(spender, owner, 0, big.NewInt(500))
+
+	if _, _, err := mutableTree.Commit(a); err != nil {
+		t.Fatal(err)
+	}
+
+	appState := &types.AppState{}
+	NewAllowances(b, mutableTree.GetLastImmutable()).Export(appState)
+	if len(appState.Allowances) != 1 || appState.Allowances[0].Owner != spender || appState.Allowances[0].Value != "500" {
+		t.Fatalf("Exported allowances are not correct: %+v", appState.Allowances)
+	}
+}
@@ -0,0 +1,30 @@
+package allowances
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Model keeps allowances given by the owner
+type Model struct {
+	Allowances []*Allowance
+
+	owner types.Address
+}
+
+// Allowance is the value of the coin which the spender can transfer from the balance of the owner
+type Allowance struct {
+	Spender types.Address
+	Coin    types.CoinID
+	Value   *big.Int
+}
+
+func (m *Model) find(spender types.Address, coin types.CoinID) int {
+	for i, allowance := range m.Allowances {
+		if allowance.Spender == spender && allowance.Coin == coin {
+			return i
+		}
+	}
+	return -1
+}
@@ -13,6 +13,8 @@ type Bus struct {
 	events      eventsdb.IEventsDB
 	checker     Checker
 	validators  Validators
+	holders     Holders
+	params      Params
 }
 
 func NewBus() *Bus {
@@ -98,3 +100,19 @@ func (b *Bus) SetChecker(checker Checker) {
 func (b *Bus) Checker() Checker {
 	return b.checker
 }
+
+func (b *Bus) SetHolders(holders Holders) {
+	b.holders = holders
+}
+
+func (b *Bus) Holders() Holders {
+	return b.holders
+}
+
+func (b *Bus) SetParams(params Params) {
+	b.params = params
+}
+
+func (b *Bus) Params() Params {
+	return b.params
+}
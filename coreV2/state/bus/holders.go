@@ -0,0 +1,12 @@
+package bus
+
+import (
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"math/big"
+)
+
+// Holders receives balances and stakes saved at commit, it is nil if coin holders are not indexed
+type Holders interface {
+	SetBalance(types.Address, types.CoinID, *big.Int)
+	SetCandidateStakes(candidateID uint32, stakes []*Stake)
+}
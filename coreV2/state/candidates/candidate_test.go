@@ -8,6 +8,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/waitlist"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/helpers"
@@ -698,10 +699,12 @@ func TestCandidates_Punish(t *testing.T) {
 
 type fr struct {
 	unbounds []*big.Int
+	heights  []uint64
 }
 
-func (fr *fr) AddFrozenFund(_ uint64, _ types.Address, _ *types.Pubkey, _ uint32, _ types.CoinID, value *big.Int) {
+func (fr *fr) AddFrozenFund(height uint64, _ types.Address, _ *types.Pubkey, _ uint32, _ types.CoinID, value *big.Int) {
 	fr.unbounds = append(fr.unbounds, value)
+	fr.heights = append(fr.heights, height)
 }
 func TestCandidates_PunishByzantineCandidate(t *testing.T) {
 	t.Parallel()
@@ -723,6 +726,8 @@ func TestCandidates_PunishByzantineCandidate(t *testing.T) {
 	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
 
 	coinsState := coins.NewCoins(b, mutableTree.GetLastImmutable())
+	paramsState := params.NewParams(b, mutableTree.GetLastImmutable())
+	paramsState.SetValue(params.UnbondPeriod, big.NewInt(10))
 
 	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10, 0, 0)
 	coinsState.Create(1,
@@ -784,6 +789,9 @@ func TestCandidates_PunishByzantineCandidate(t *testing.T) {
 	if frozenfunds.unbounds[1].String() != "95" {
 		t.Fatalf("frozenfunds.unbounds[1] == %s", frozenfunds.unbounds[1].String())
 	}
+	if frozenfunds.heights[0] != 10 || frozenfunds.heights[1] != 10 {
+		t.Fatalf("frozenfunds.heights == %v", frozenfunds.heights)
+	}
 }
 
 func TestCandidates_SubStake(t *testing.T) {
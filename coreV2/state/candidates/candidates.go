@@ -68,6 +68,7 @@ type RCandidates interface {
 	LoadStakes()
 	GetCandidates() []*Candidate
 	GetStakes(pubkey types.Pubkey) []*stake
+	GetUpdates(pubkey types.Pubkey) []*stake
 	IsCandidateJailed(pubkey types.Pubkey, block uint64) bool
 }
 
@@ -123,6 +124,47 @@ func NewCandidates(bus *bus.Bus, db *iavl.ImmutableTree) *Candidates {
 	return candidates
 }
 
+// SetParent makes candidates the copy of the loaded candidates of the parent with their stakes,
+// so the copy reads the same tree, changes are never written to the parent
+func (c *Candidates) SetParent(parent *Candidates) {
+	parent.lock.RLock()
+	defer parent.lock.RUnlock()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.list = make(map[uint32]*Candidate, len(parent.list))
+	for id, candidate := range parent.list {
+		c.list[id] = candidate.copy()
+	}
+	c.blockList = make(map[types.Pubkey]struct{}, len(parent.blockList))
+	for pubkey := range parent.blockList {
+		c.blockList[pubkey] = struct{}{}
+	}
+	c.pubKeyIDs = make(map[types.Pubkey]uint32, len(parent.pubKeyIDs))
+	for pubkey, id := range parent.pubKeyIDs {
+		c.pubKeyIDs[pubkey] = id
+	}
+	c.isDirty = parent.isDirty
+	c.maxID = parent.maxID
+	c.loaded = parent.loaded
+	c.isChangedPublicKeys = parent.isChangedPublicKeys
+	c.totalStakes = big.NewInt(0).Set(parent.totalStakes)
+
+	parent.muDeletedCandidates.RLock()
+	defer parent.muDeletedCandidates.RUnlock()
+
+	c.muDeletedCandidates.Lock()
+	defer c.muDeletedCandidates.Unlock()
+
+	c.deletedCandidates = make(map[types.Pubkey]*deletedID, len(parent.deletedCandidates))
+	for pubkey, deleted := range parent.deletedCandidates {
+		deletedCopy := *deleted
+		c.deletedCandidates[pubkey] = &deletedCopy
+	}
+	c.dirtyDeletedCandidates = parent.dirtyDeletedCandidates
+}
+
 func (c *Candidates) immutableTree() *iavl.ImmutableTree {
 	db := c.db.Load()
 	if db == nil {
@@ -265,6 +307,9 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 		for _, id := range deletedCandidates {
 			if id.isDirty {
 				id.isDirty = false
+				if holders := c.bus.Holders(); holders != nil {
+					holders.SetCandidateStakes(id.ID, nil)
+				}
 				db.IterateRange(append([]byte{mainPrefix}, idBytes(id.ID)...), append([]byte{mainPrefix}, idBytes(id.ID+1)...), true, func(key []byte, value []byte) bool {
 					if len(key) <= 5 || !(key[5] == stakesPrefix || key[5] == updatesPrefix || key[5] == totalStakePrefix) {
 						return false
@@ -308,6 +353,7 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 			db.Set(path, totalStakeBytes)
 		}
 
+		hasDirtyStakes := false
 		for index, stake := range candidate.stakes {
 			candidate.lock.RLock()
 			dirtyStakes := candidate.dirtyStakes[index]
@@ -315,6 +361,7 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 			if !dirtyStakes {
 				continue
 			}
+			hasDirtyStakes = true
 
 			candidate.lock.Lock()
 			candidate.dirtyStakes[index] = false
@@ -348,6 +395,23 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 			db.Set(path, data)
 		}
 
+		if holders := c.bus.Holders(); holders != nil && hasDirtyStakes {
+			var stakes []*bus.Stake
+			for _, stake := range candidate.stakes {
+				if stake == nil {
+					continue
+				}
+				stake.lock.RLock()
+				stakes = append(stakes, &bus.Stake{
+					Owner: stake.Owner,
+					Value: big.NewInt(0).Set(stake.Value),
+					Coin:  stake.Coin,
+				})
+				stake.lock.RUnlock()
+			}
+			holders.SetCandidateStakes(candidate.ID, stakes)
+		}
+
 		candidate.lock.RLock()
 		updatesDirty := candidate.isUpdatesDirty
 		candidate.lock.RUnlock()
@@ -375,6 +439,12 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 // Skips offline candidates and candidates with stake less than minValidatorBipStake
 // Result is sorted by candidates stakes and limited to valCount
 func (c *Candidates) GetNewCandidates(valCount int) []*Candidate {
+	return c.GetNewCandidatesWithMinStake(valCount, minValidatorBipStake)
+}
+
+// GetNewCandidatesWithMinStake returns list of candidates that can be the new validators like GetNewCandidates,
+// candidates with stake less than minStake are skipped
+func (c *Candidates) GetNewCandidatesWithMinStake(valCount int, minStake *big.Int) []*Candidate {
 	var result []*Candidate
 
 	candidates := c.GetCandidates()
@@ -383,7 +453,7 @@ func (c *Candidates) GetNewCandidates(valCount int) []*Candidate {
 			continue
 		}
 
-		if candidate.GetTotalBipStake().Cmp(minValidatorBipStake) == -1 {
+		if candidate.GetTotalBipStake().Cmp(minStake) == -1 {
 			continue
 		}
 
@@ -463,7 +533,7 @@ func (c *Candidates) PunishByzantineCandidate(height uint64, tmAddress types.TmA
 		})
 
 		c.bus.Checker().AddCoin(stake.Coin, big.NewInt(0).Neg(newValue))
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), stake.Owner, &candidate.PubKey, candidate.ID, stake.Coin, newValue)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.bus.Params().GetUnbondPeriod(), stake.Owner, &candidate.PubKey, candidate.ID, stake.Coin, newValue)
 		stake.setValue(big.NewInt(0))
 	}
 }
@@ -809,6 +879,41 @@ func (c *Candidates) GetStakes(pubkey types.Pubkey) []*stake {
 	return stakes
 }
 
+// GetUpdates returns list of pending stakes of candidate with given public key, which are applied at the end of the block
+func (c *Candidates) GetUpdates(pubkey types.Pubkey) []*stake {
+	candidate := c.GetCandidate(pubkey)
+	if candidate == nil {
+		return nil
+	}
+
+	candidate.lock.RLock()
+	defer candidate.lock.RUnlock()
+
+	updates := make([]*stake, len(candidate.updates))
+	copy(updates, candidate.updates)
+
+	return updates
+}
+
+// DirtyStakes returns public keys of candidates with changed stakes or pending stakes, which are not committed yet
+func (c *Candidates) DirtyStakes() []types.Pubkey {
+	var pubkeys []types.Pubkey
+	for _, candidate := range c.getOrderedCandidatesLessID() {
+		candidate.lock.RLock()
+		dirty := candidate.isUpdatesDirty
+		for _, isDirty := range candidate.dirtyStakes {
+			dirty = dirty || isDirty
+		}
+		candidate.lock.RUnlock()
+
+		if dirty {
+			pubkeys = append(pubkeys, candidate.PubKey)
+		}
+	}
+
+	return pubkeys
+}
+
 // GetStakeOfAddress returns stake of address in given candidate and in given coin
 func (c *Candidates) GetStakeOfAddress(pubkey types.Pubkey, address types.Address, coin types.CoinID) *stake {
 	candidate := c.GetCandidate(pubkey)
@@ -1326,6 +1431,17 @@ func (c *Candidates) ChangePubKey(old types.Pubkey, new types.Pubkey) {
 	c.lock.Unlock()
 }
 
+// PathCandidates returns the tree key of the candidates list
+func PathCandidates() []byte {
+	return []byte{mainPrefix}
+}
+
+// PathTotalStake returns the tree key of the candidate total stake
+func PathTotalStake(id uint32) []byte {
+	path := append([]byte{mainPrefix}, idBytes(id)...)
+	return append(path, totalStakePrefix)
+}
+
 func (c *Candidates) getOrNewID(pubKey types.Pubkey) uint32 {
 	c.lock.RLock()
 	id := c.id(pubKey)
@@ -1447,7 +1563,7 @@ func (c *Candidates) DeleteCandidate(height uint64, candidate *Candidate) {
 			Coin:            uint64(s.Coin),
 			ValidatorPubKey: &candidate.PubKey,
 		})
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), s.Owner, &candidate.PubKey, candidate.ID, s.Coin, s.Value)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.bus.Params().GetUnbondPeriod(), s.Owner, &candidate.PubKey, candidate.ID, s.Coin, s.Value)
 		c.bus.Checker().AddCoin(s.Coin, big.NewInt(0).Neg(s.Value))
 		s.setValue(big.NewInt(0))
 	}
@@ -1461,7 +1577,7 @@ func (c *Candidates) DeleteCandidate(height uint64, candidate *Candidate) {
 			Coin:            uint64(u.Coin),
 			ValidatorPubKey: &candidate.PubKey,
 		})
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), u.Owner, &candidate.PubKey, candidate.ID, u.Coin, u.Value)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.bus.Params().GetUnbondPeriod(), u.Owner, &candidate.PubKey, candidate.ID, u.Coin, u.Value)
 		c.bus.Checker().AddCoin(u.Coin, big.NewInt(0).Neg(u.Value))
 		u.setValue(big.NewInt(0))
 	}
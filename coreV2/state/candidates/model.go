@@ -39,6 +39,52 @@ type Candidate struct {
 	JailedUntil              uint64
 }
 
+// copy returns the deep copy of the candidate with its stakes and updates
+func (candidate *Candidate) copy() *Candidate {
+	candidate.lock.RLock()
+	defer candidate.lock.RUnlock()
+
+	c := &Candidate{
+		stakesCount:              candidate.stakesCount,
+		tmAddress:                candidate.tmAddress,
+		isDirty:                  candidate.isDirty,
+		isTotalStakeDirty:        candidate.isTotalStakeDirty,
+		isUpdatesDirty:           candidate.isUpdatesDirty,
+		dirtyStakes:              candidate.dirtyStakes,
+		PubKey:                   candidate.PubKey,
+		RewardAddress:            candidate.RewardAddress,
+		OwnerAddress:             candidate.OwnerAddress,
+		ControlAddress:           candidate.ControlAddress,
+		Commission:               candidate.Commission,
+		Status:                   candidate.Status,
+		ID:                       candidate.ID,
+		LastEditCommissionHeight: candidate.LastEditCommissionHeight,
+		JailedUntil:              candidate.JailedUntil,
+	}
+	if candidate.totalBipStake != nil {
+		c.totalBipStake = big.NewInt(0).Set(candidate.totalBipStake)
+	}
+	for index, stake := range candidate.stakes {
+		if stake == nil {
+			continue
+		}
+		c.stakes[index] = stake.copy(func(i int) {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			c.dirtyStakes[i] = true
+		})
+	}
+	for _, update := range candidate.updates {
+		c.updates = append(c.updates, update.copy(func(int) {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			c.isUpdatesDirty = true
+		}))
+	}
+
+	return c
+}
+
 func (candidate *Candidate) idBytes() []byte {
 	return idBytes(candidate.ID)
 }
@@ -266,6 +312,23 @@ type stake struct {
 	lock      sync.RWMutex
 }
 
+func (s *stake) copy(markDirty func(int)) *stake {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	c := &stake{
+		Owner:     s.Owner,
+		Coin:      s.Coin,
+		Value:     big.NewInt(0).Set(s.Value),
+		index:     s.index,
+		markDirty: markDirty,
+	}
+	if s.BipValue != nil {
+		c.BipValue = big.NewInt(0).Set(s.BipValue)
+	}
+	return c
+}
+
 func (stake *stake) addValue(value *big.Int) {
 	stake.markDirty(stake.index)
 
@@ -88,6 +88,8 @@ type Coins struct {
 	bus *bus.Bus
 	db  atomic.Value
 
+	parent *Coins
+
 	lock sync.RWMutex
 }
 
@@ -121,6 +123,12 @@ func (c *Coins) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
 	c.db.Store(immutableTree)
 }
 
+// SetParent makes coins the cache-wrapped copy of the parent reading the same tree:
+// coins missing in the cache are copied from the cache of the parent, changes are never written to the parent
+func (c *Coins) SetParent(parent *Coins) {
+	c.parent = parent
+}
+
 func (c *Coins) Commit(db *iavl.MutableTree, version int64) error {
 	coins := c.getOrderedDirtyCoins()
 	for _, id := range coins {
@@ -514,6 +522,14 @@ func (c *Coins) get(id types.CoinID) *Model {
 		return coin
 	}
 
+	if c.parent != nil {
+		if coin := c.parent.getFromMap(id); coin != nil {
+			coin = coin.copy(c.markDirty)
+			c.setToMap(id, coin)
+			return coin
+		}
+	}
+
 	_, enc := c.immutableTree().Get(getCoinPath(id))
 	if len(enc) == 0 {
 		return nil
@@ -552,6 +568,14 @@ func (c *Coins) getSymbolInfo(symbol types.CoinSymbol) *SymbolInfo {
 		return info
 	}
 
+	if c.parent != nil {
+		if info, ok := c.parent.getSymbolInfoFromMap(symbol); ok {
+			info = info.copy()
+			c.setSymbolInfoToMap(info, symbol)
+			return info
+		}
+	}
+
 	info := &SymbolInfo{}
 
 	_, enc := c.immutableTree().Get(getSymbolInfoPath(symbol))
@@ -573,6 +597,14 @@ func (c *Coins) getBySymbol(symbol types.CoinSymbol) []types.CoinID {
 		return coins
 	}
 
+	if c.parent != nil {
+		if coins, ok := c.parent.getSymbolFromMap(symbol); ok {
+			coins = append([]types.CoinID(nil), coins...)
+			c.setSymbolToMap(coins, symbol)
+			return coins
+		}
+	}
+
 	var coins []types.CoinID
 
 	_, enc := c.immutableTree().Get(getSymbolCoinsPath(symbol))
@@ -703,6 +735,11 @@ func getSymbolInfoPath(symbol types.CoinSymbol) []byte {
 	return append(path, []byte{infoPrefix}...)
 }
 
+// PathCoin returns the tree key of the coin model
+func PathCoin(id types.CoinID) []byte {
+	return getCoinPath(id)
+}
+
 func getCoinPath(id types.CoinID) []byte {
 	return append([]byte{mainPrefix}, id.Bytes()...)
 }
@@ -31,6 +31,38 @@ type Model struct {
 	isCreated bool
 }
 
+// copy returns the deep copy of the model marking the given cache dirty
+func (m *Model) copy(markDirty func(types.CoinID)) *Model {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	coin := &Model{
+		CName:      m.CName,
+		CCrr:       m.CCrr,
+		CMaxSupply: big.NewInt(0).Set(m.CMaxSupply),
+		CVersion:   m.CVersion,
+		CSymbol:    m.CSymbol,
+		Mintable:   m.Mintable,
+		Burnable:   m.Burnable,
+		id:         m.id,
+		symbolInfo: m.symbolInfo.copy(),
+		markDirty:  markDirty,
+		isDirty:    m.isDirty,
+		isCreated:  m.isCreated,
+	}
+	if m.info != nil {
+		m.info.lock.RLock()
+		coin.info = &Info{
+			Volume:  big.NewInt(0).Set(m.info.Volume),
+			Reserve: big.NewInt(0).Set(m.info.Reserve),
+			isDirty: m.info.isDirty,
+		}
+		m.info.lock.RUnlock()
+	}
+
+	return coin
+}
+
 func (m *Model) Name() string {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -294,6 +326,18 @@ type SymbolInfo struct {
 	lock sync.RWMutex
 }
 
+// copy returns the copy of the symbol info, it's nil for the nil info
+func (i *SymbolInfo) copy() *SymbolInfo {
+	if i == nil {
+		return nil
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return &SymbolInfo{COwnerAddress: i.COwnerAddress, isDirty: i.isDirty}
+}
+
 func (i *SymbolInfo) setOwnerAddress(address types.Address) {
 	i.lock.Lock()
 	defer i.lock.Unlock()
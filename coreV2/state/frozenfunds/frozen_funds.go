@@ -283,6 +283,11 @@ func (f *FrozenFunds) setToMap(height uint64, model *Model) {
 	f.list[height] = model
 }
 
+// PathFrozenFunds returns the tree key of the funds unfrozen at the height
+func PathFrozenFunds(height uint64) []byte {
+	return getPath(height)
+}
+
 func getPath(height uint64) []byte {
 	b := make([]byte, 8)
 	binary.BigEndian.PutUint64(b, height)
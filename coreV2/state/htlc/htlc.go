@@ -0,0 +1,231 @@
+package htlc
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('x')
+
+type RHTLCs interface {
+	Export(state *types.AppState)
+	GetHTLC(hashLock types.Hash) *Model
+	GetHTLCsBySender(sender types.Address) []*Model
+}
+
+// HTLCs keeps hash time-locked contracts by their hash locks
+type HTLCs struct {
+	list  map[types.Hash]*Model
+	dirty map[types.Hash]struct{}
+
+	bus *bus.Bus
+	db  atomic.Value
+
+	lock sync.RWMutex
+}
+
+func NewHTLCs(stateBus *bus.Bus, db *iavl.ImmutableTree) *HTLCs {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &HTLCs{
+		bus:   stateBus,
+		db:    immutableTree,
+		list:  map[types.Hash]*Model{},
+		dirty: map[types.Hash]struct{}{},
+	}
+}
+
+func (h *HTLCs) immutableTree() *iavl.ImmutableTree {
+	db := h.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (h *HTLCs) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	h.db.Store(immutableTree)
+}
+
+func (h *HTLCs) Commit(db *iavl.MutableTree, version int64) error {
+	for _, hashLock := range h.getOrderedDirty() {
+		model := h.getFromMap(hashLock)
+		path := getPath(hashLock)
+
+		h.lock.Lock()
+		delete(h.dirty, hashLock)
+		if model.deleted {
+			delete(h.list, hashLock)
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(model)
+			if err != nil {
+				h.lock.Unlock()
+				return fmt.Errorf("can't encode object at %s: %v", hashLock.String(), err)
+			}
+
+			db.Set(path, data)
+		}
+		h.lock.Unlock()
+	}
+
+	return nil
+}
+
+// GetHTLC returns the contract with the hash lock, nil if it doesn't exist
+func (h *HTLCs) GetHTLC(hashLock types.Hash) *Model {
+	model := h.get(hashLock)
+	if model == nil || model.deleted {
+		return nil
+	}
+	return model
+}
+
+// GetHTLCsBySender returns contracts created by the sender which are neither claimed nor refunded
+func (h *HTLCs) GetHTLCsBySender(sender types.Address) []*Model {
+	var models []*Model
+	h.iterate(func(model *Model) {
+		if model.Sender == sender {
+			models = append(models, model)
+		}
+	})
+	return models
+}
+
+// Create locks the value in the contract with the hash lock
+func (h *HTLCs) Create(hashLock types.Hash, sender, recipient types.Address, coin types.CoinID, value *big.Int, timeout uint64) {
+	model := &Model{
+		Sender:    sender,
+		Recipient: recipient,
+		Coin:      coin,
+		Value:     big.NewInt(0).Set(value),
+		Timeout:   timeout,
+		hashLock:  hashLock,
+	}
+
+	h.lock.Lock()
+	h.list[hashLock] = model
+	h.dirty[hashLock] = struct{}{}
+	h.lock.Unlock()
+
+	h.bus.Checker().AddCoin(coin, value)
+}
+
+// Delete removes the claimed or refunded contract, its value should be paid out by the caller
+func (h *HTLCs) Delete(hashLock types.Hash) {
+	model := h.GetHTLC(hashLock)
+	if model == nil {
+		return
+	}
+
+	h.lock.Lock()
+	model.deleted = true
+	h.dirty[hashLock] = struct{}{}
+	h.lock.Unlock()
+
+	h.bus.Checker().AddCoin(model.Coin, big.NewInt(0).Neg(model.Value))
+}
+
+func (h *HTLCs) Export(state *types.AppState) {
+	h.iterate(func(model *Model) {
+		state.HTLCs = append(state.HTLCs, types.HTLC{
+			HashLock:  model.hashLock,
+			Sender:    model.Sender,
+			Recipient: model.Recipient,
+			Coin:      uint64(model.Coin),
+			Value:     model.Value.String(),
+			Timeout:   model.Timeout,
+		})
+	})
+}
+
+// iterate calls fn for stored and created contracts ordered by hash locks
+func (h *HTLCs) iterate(fn func(model *Model)) {
+	hashLocks := map[types.Hash]struct{}{}
+	h.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		var hashLock types.Hash
+		copy(hashLock[:], key[1:])
+		hashLocks[hashLock] = struct{}{}
+		return false
+	})
+
+	h.lock.RLock()
+	for hashLock := range h.list {
+		hashLocks[hashLock] = struct{}{}
+	}
+	h.lock.RUnlock()
+
+	keys := make([]types.Hash, 0, len(hashLocks))
+	for hashLock := range hashLocks {
+		keys = append(keys, hashLock)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) == -1
+	})
+
+	for _, hashLock := range keys {
+		if model := h.GetHTLC(hashLock); model != nil {
+			fn(model)
+		}
+	}
+}
+
+func (h *HTLCs) get(hashLock types.Hash) *Model {
+	if model := h.getFromMap(hashLock); model != nil {
+		return model
+	}
+
+	_, enc := h.immutableTree().Get(getPath(hashLock))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	model := &Model{}
+	if err := rlp.DecodeBytes(enc, model); err != nil {
+		panic(fmt.Sprintf("failed to decode htlc %s: %s", hashLock.String(), err))
+	}
+	model.hashLock = hashLock
+
+	h.lock.Lock()
+	h.list[hashLock] = model
+	h.lock.Unlock()
+
+	return model
+}
+
+func (h *HTLCs) getFromMap(hashLock types.Hash) *Model {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.list[hashLock]
+}
+
+func (h *HTLCs) getOrderedDirty() []types.Hash {
+	h.lock.RLock()
+	keys := make([]types.Hash, 0, len(h.dirty))
+	for k := range h.dirty {
+		keys = append(keys, k)
+	}
+	h.lock.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) == -1
+	})
+
+	return keys
+}
+
+func getPath(hashLock types.Hash) []byte {
+	return append([]byte{mainPrefix}, hashLock[:]...)
+}
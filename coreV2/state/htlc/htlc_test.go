@@ -0,0 +1,56 @@
+package htlc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestHTLCs(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	h := NewHTLCs(b, mutableTree.GetLastImmutable())
+
+	sender, recipient := types.Address{1}, types.Address{2}
+	h.Create(types.Hash{1}, sender, recipient, 0, big.NewInt(100), 10)
+	h.Create(types.Hash{2}, sender, recipient, 1, big.NewInt(200), 20)
+	h.Create(types.Hash{3}, recipient, sender, 0, big.NewInt(300), 30)
+
+	if _, _, err := mutableTree.Commit(h); err != nil {
+		t.Fatal(err)
+	}
+
+	h = NewHTLCs(b, mutableTree.GetLastImmutable())
+	contract := h.GetHTLC(types.Hash{2})
+	if contract == nil {
+		t.Fatal("HTLC not found")
+	}
+	if contract.Sender != sender || contract.Recipient != recipient || contract.Coin != 1 || contract.Value.Cmp(big.NewInt(200)) != 0 || contract.Timeout != 20 || contract.HashLock() != (types.Hash{2}) {
+		t.Fatalf("HTLC is not correct: %+v", contract)
+	}
+
+	h.Delete(types.Hash{1})
+	if h.GetHTLC(types.Hash{1}) != nil {
+		t.Fatal("HTLC not deleted")
+	}
+	if contracts := h.GetHTLCsBySender(sender); len(contracts) != 1 || contracts[0].HashLock() != (types.Hash{2}) {
+		t.Fatalf("HTLCs of the sender are not correct: %+v", contracts)
+	}
+
+	if _, _, err := mutableTree.Commit(h); err != nil {
+		t.Fatal(err)
+	}
+
+	appState := &types.AppState{}
+	NewHTLCs(b, mutableTree.GetLastImmutable()).Export(appState)
+	if len(appState.HTLCs) != 2 || appState.HTLCs[0].HashLock != (types.Hash{2}) || appState.HTLCs[1].HashLock != (types.Hash{3}) {
+		t.Fatalf("Exported HTLCs are not correct: %+v", appState.HTLCs)
+	}
+}
@@ -0,0 +1,25 @@
+package htlc
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Model is the hash time-locked contract: the value is paid to the recipient by the preimage of the hash lock
+// until the timeout height, or refunded to the sender after it
+type Model struct {
+	Sender    types.Address
+	Recipient types.Address
+	Coin      types.CoinID
+	Value     *big.Int
+	Timeout   uint64
+
+	hashLock types.Hash
+	deleted  bool
+}
+
+// HashLock returns sha256 hash of the preimage which unlocks the contract
+func (m *Model) HashLock() types.Hash {
+	return m.hashLock
+}
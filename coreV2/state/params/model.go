@@ -0,0 +1,30 @@
+package params
+
+import (
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Model is the proposed value of the parameter with votes of validators for it
+type Model struct {
+	Name  string
+	Value string
+	Votes []types.Pubkey
+
+	height    uint64
+	markDirty func()
+
+	lock sync.Mutex
+}
+
+func (m *Model) Height() uint64 {
+	return m.height
+}
+
+func (m *Model) addVote(pubkey types.Pubkey) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.Votes = append(m.Votes, pubkey)
+	m.markDirty()
+}
@@ -0,0 +1,405 @@
+package params
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('k')
+
+const (
+	proposalsPrefix = byte('h')
+	valuePrefix     = byte('v')
+)
+
+// Names of network parameters votable by validators
+const (
+	MaxValidators      = "max_validators"
+	MinStake           = "min_stake" // replaces 1000 BIP, the only min stake required from candidates to become validators
+	UnbondPeriod       = "unbond_period"
+	ExpireOrdersPeriod = "expire_orders_period"
+	MaxGas             = "max_gas"
+)
+
+// Names lists network parameters votable by validators
+var Names = []string{MaxValidators, MinStake, UnbondPeriod, ExpireOrdersPeriod, MaxGas}
+
+// Limits of values of network parameters
+const (
+	// maxValidatorsLimit is the number of candidates slots
+	maxValidatorsLimit = 192
+	// MinValidatorsLimit keeps the network tolerant to faults of some validators,
+	// so this number of top candidates become validators regardless of the min stake
+	MinValidatorsLimit = 16
+	// MinMaxGas is the lowest max gas of the block, which fits any transaction
+	MinMaxGas = 5000
+	// MinPeriod is the lowest unbond period and order expiry period, a day of blocks
+	MinPeriod = 17280
+)
+
+// Validate returns error if the parameter is unknown or the value is not allowed for it
+func Validate(name string, value string) error {
+	v, ok := big.NewInt(0).SetString(value, 10)
+	if !ok || v.Sign() != 1 || v.String() != value {
+		return errors.New("value should be a positive integer")
+	}
+
+	switch name {
+	case MinStake:
+		return nil
+	case MaxValidators:
+		if v.Cmp(big.NewInt(MinValidatorsLimit)) == -1 || v.Cmp(big.NewInt(maxValidatorsLimit)) == 1 {
+			return fmt.Errorf("value should be from %d to %d", MinValidatorsLimit, maxValidatorsLimit)
+		}
+		return nil
+	case MaxGas:
+		if !v.IsUint64() || v.Uint64() > math.MaxInt64 {
+			return errors.New("value is too big")
+		}
+		if v.Uint64() < MinMaxGas {
+			return fmt.Errorf("value should not be less than %d", MinMaxGas)
+		}
+		return nil
+	case UnbondPeriod, ExpireOrdersPeriod:
+		if !v.IsUint64() || v.Uint64() > math.MaxInt64 {
+			return errors.New("value is too big")
+		}
+		if v.Uint64() < MinPeriod {
+			return fmt.Errorf("value should not be less than %d", MinPeriod)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown parameter %s", name)
+}
+
+type RParams interface {
+	Export(state *types.AppState)
+	GetValue(name string) *big.Int
+	GetUnbondPeriod() uint64
+	GetProposal(height uint64, name string, value string) *Model
+	GetProposals(height uint64) []*Model
+	GetActiveProposals(height uint64) []*Model
+	IsVoteExists(height uint64, name string, pubkey types.Pubkey) bool
+}
+
+// Params keeps values of network parameters changed by validators and proposals of new values by heights of voting
+type Params struct {
+	list        map[uint64][]*Model
+	dirty       map[uint64]struct{}
+	forDelete   uint64
+	values      map[string]*big.Int
+	dirtyValues map[string]struct{}
+
+	db   atomic.Value
+	lock sync.RWMutex
+}
+
+func NewParams(stateBus *bus.Bus, db *iavl.ImmutableTree) *Params {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	params := &Params{
+		db:          immutableTree,
+		list:        map[uint64][]*Model{},
+		dirty:       map[uint64]struct{}{},
+		values:      map[string]*big.Int{},
+		dirtyValues: map[string]struct{}{},
+	}
+
+	stateBus.SetParams(NewBus(params))
+
+	return params
+}
+
+func (p *Params) immutableTree() *iavl.ImmutableTree {
+	db := p.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (p *Params) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	p.db.Store(immutableTree)
+}
+
+func (p *Params) Export(state *types.AppState) {
+	for _, name := range Names {
+		if value := p.GetValue(name); value != nil {
+			state.Params = append(state.Params, types.Param{
+				Name:  name,
+				Value: value.String(),
+			})
+		}
+	}
+
+	p.immutableTree().IterateRange([]byte{mainPrefix, proposalsPrefix}, []byte{mainPrefix, proposalsPrefix + 1}, true, func(key []byte, value []byte) bool {
+		height := binary.BigEndian.Uint64(key[2:])
+		for _, proposal := range p.get(height) {
+			state.ParamsVotes = append(state.ParamsVotes, types.ParamsVote{
+				Height: height,
+				Name:   proposal.Name,
+				Value:  proposal.Value,
+				Votes:  proposal.Votes,
+			})
+		}
+		return false
+	})
+}
+
+func (p *Params) Import(state *types.AppState) {
+	for _, param := range state.Params {
+		v, _ := big.NewInt(0).SetString(param.Value, 10)
+		p.SetValue(param.Name, v)
+	}
+
+	for _, vote := range state.ParamsVotes {
+		for _, pubkey := range vote.Votes {
+			p.AddVote(vote.Height, vote.Name, vote.Value, pubkey)
+		}
+	}
+}
+
+func (p *Params) Commit(db *iavl.MutableTree, version int64) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	names := make([]string, 0, len(p.dirtyValues))
+	for name := range p.dirtyValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		delete(p.dirtyValues, name)
+		db.Set(getValuePath(name), p.values[name].Bytes())
+	}
+
+	heights := make([]uint64, 0, len(p.dirty))
+	for height := range p.dirty {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	for _, height := range heights {
+		delete(p.dirty, height)
+
+		data, err := rlp.EncodeToBytes(p.list[height])
+		if err != nil {
+			return fmt.Errorf("can't encode params proposals at %d: %v", height, err)
+		}
+
+		db.Set(getProposalsPath(height), data)
+	}
+
+	if p.forDelete != 0 {
+		db.Remove(getProposalsPath(p.forDelete))
+		delete(p.list, p.forDelete)
+		p.forDelete = 0
+	}
+
+	return nil
+}
+
+// GetValue returns the value of the parameter changed by validators, nil if the default value is used
+func (p *Params) GetValue(name string) *big.Int {
+	p.lock.RLock()
+	value, ok := p.values[name]
+	p.lock.RUnlock()
+	if ok {
+		return big.NewInt(0).Set(value)
+	}
+
+	_, enc := p.immutableTree().Get(getValuePath(name))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	value = big.NewInt(0).SetBytes(enc)
+	p.lock.Lock()
+	p.values[name] = value
+	p.lock.Unlock()
+
+	return big.NewInt(0).Set(value)
+}
+
+// GetUnbondPeriod returns the unbond period changed by validators or the default one,
+// it's used for unbonds, returned stakes of punished and removed candidates and the limit of commission changes.
+// Values are set only by votes applied since the network update V340, so the default one is used before it
+func (p *Params) GetUnbondPeriod() uint64 {
+	if value := p.GetValue(UnbondPeriod); value != nil {
+		return value.Uint64()
+	}
+	return types.GetUnbondPeriod()
+}
+
+// SetValue changes the value of the parameter
+func (p *Params) SetValue(name string, value *big.Int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.values[name] = big.NewInt(0).Set(value)
+	p.dirtyValues[name] = struct{}{}
+}
+
+// GetProposals returns proposals voted at the height
+func (p *Params) GetProposals(height uint64) []*Model {
+	return p.get(height)
+}
+
+// GetProposal returns the proposal of the value of the parameter voted at the height
+func (p *Params) GetProposal(height uint64, name string, value string) *Model {
+	for _, proposal := range p.get(height) {
+		if proposal.Name == name && proposal.Value == value {
+			return proposal
+		}
+	}
+
+	return nil
+}
+
+// GetActiveProposals returns proposals voted after the height
+func (p *Params) GetActiveProposals(height uint64) []*Model {
+	var heights []uint64
+	from := getProposalsPath(height + 1)
+	p.immutableTree().IterateRange(from, []byte{mainPrefix, proposalsPrefix + 1}, true, func(key []byte, value []byte) bool {
+		heights = append(heights, binary.BigEndian.Uint64(key[2:]))
+		return false
+	})
+
+	p.lock.RLock()
+	for h := range p.list {
+		if h > height {
+			heights = append(heights, h)
+		}
+	}
+	p.lock.RUnlock()
+
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	var proposals []*Model
+	for i, h := range heights {
+		if i > 0 && heights[i-1] == h {
+			continue
+		}
+		proposals = append(proposals, p.get(h)...)
+	}
+
+	return proposals
+}
+
+// IsVoteExists returns whether the validator voted for any value of the parameter at the height
+func (p *Params) IsVoteExists(height uint64, name string, pubkey types.Pubkey) bool {
+	for _, proposal := range p.get(height) {
+		if proposal.Name != name {
+			continue
+		}
+		for _, vote := range proposal.Votes {
+			if vote == pubkey {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// AddVote adds the vote of the validator for the value of the parameter at the height, the proposal is created by the first vote
+func (p *Params) AddVote(height uint64, name string, value string, pubkey types.Pubkey) {
+	p.getOrNew(height, name, value).addVote(pubkey)
+}
+
+// Delete removes proposals voted at the height
+func (p *Params) Delete(height uint64) {
+	if len(p.get(height)) == 0 {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.forDelete = height
+}
+
+func (p *Params) getOrNew(height uint64, name string, value string) *Model {
+	if proposal := p.GetProposal(height, name, value); proposal != nil {
+		return proposal
+	}
+
+	proposal := &Model{
+		Name:      name,
+		Value:     value,
+		height:    height,
+		markDirty: p.markDirty(height),
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.list[height] = append(p.list[height], proposal)
+
+	return proposal
+}
+
+func (p *Params) get(height uint64) []*Model {
+	p.lock.RLock()
+	proposals, ok := p.list[height]
+	p.lock.RUnlock()
+	if ok {
+		return proposals
+	}
+
+	_, enc := p.immutableTree().Get(getProposalsPath(height))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	if err := rlp.DecodeBytes(enc, &proposals); err != nil {
+		panic(fmt.Sprintf("failed to decode params proposals at height %d: %s", height, err))
+	}
+
+	for _, proposal := range proposals {
+		proposal.height = height
+		proposal.markDirty = p.markDirty(height)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.list[height] = proposals
+
+	return proposals
+}
+
+func (p *Params) markDirty(height uint64) func() {
+	return func() {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.dirty[height] = struct{}{}
+	}
+}
+
+func getProposalsPath(height uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, height)
+
+	return append([]byte{mainPrefix, proposalsPrefix}, b...)
+}
+
+func getValuePath(name string) []byte {
+	return append([]byte{mainPrefix, valuePrefix}, []byte(name)...)
+}
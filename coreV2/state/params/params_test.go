@@ -0,0 +1,94 @@
+package params
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestParams(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	p := NewParams(bus.NewBus(), mutableTree.GetLastImmutable())
+
+	if value := p.GetValue(MaxGas); value != nil {
+		t.Fatalf("Value of not changed param is %s", value)
+	}
+
+	p.AddVote(10, MaxGas, "50000", types.Pubkey{1})
+	p.AddVote(10, MaxGas, "50000", types.Pubkey{2})
+	p.AddVote(10, MaxValidators, "100", types.Pubkey{1})
+	p.AddVote(20, UnbondPeriod, "1000", types.Pubkey{3})
+	p.SetValue(MinStake, big.NewInt(100))
+
+	if !p.IsVoteExists(10, MaxGas, types.Pubkey{2}) || p.IsVoteExists(10, UnbondPeriod, types.Pubkey{2}) {
+		t.Fatal("Votes are not correct")
+	}
+
+	if _, _, err := mutableTree.Commit(p); err != nil {
+		t.Fatal(err)
+	}
+
+	p = NewParams(bus.NewBus(), mutableTree.GetLastImmutable())
+	if proposal := p.GetProposal(10, MaxGas, "50000"); proposal == nil || len(proposal.Votes) != 2 || proposal.Height() != 10 {
+		t.Fatalf("Proposal is not correct: %+v", proposal)
+	}
+	if proposals := p.GetActiveProposals(9); len(proposals) != 3 {
+		t.Fatalf("Active proposals are not correct: %d", len(proposals))
+	}
+	if proposals := p.GetActiveProposals(10); len(proposals) != 1 || proposals[0].Name != UnbondPeriod {
+		t.Fatalf("Active proposals are not correct: %+v", proposals)
+	}
+	if value := p.GetValue(MinStake); value == nil || value.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("Value is not correct: %s", value)
+	}
+
+	p.Delete(10)
+	if _, _, err := mutableTree.Commit(p); err != nil {
+		t.Fatal(err)
+	}
+
+	appState := &types.AppState{}
+	NewParams(bus.NewBus(), mutableTree.GetLastImmutable()).Export(appState)
+	if len(appState.ParamsVotes) != 1 || appState.ParamsVotes[0].Height != 20 {
+		t.Fatalf("Exported votes are not correct: %+v", appState.ParamsVotes)
+	}
+	if len(appState.Params) != 1 || appState.Params[0].Name != MinStake || appState.Params[0].Value != "100" {
+		t.Fatalf("Exported params are not correct: %+v", appState.Params)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	for _, c := range []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{MaxGas, "50000", true},
+		{MaxGas, "0", false},
+		{MaxGas, "-1", false},
+		{MaxGas, "01", false},
+		{MaxGas, "4999", false},
+		{MaxGas, "5000", true},
+		{MaxValidators, "193", false},
+		{MaxValidators, "15", false},
+		{MaxValidators, "16", true},
+		{MinStake, "100000000000000000000000000000", true},
+		{UnbondPeriod, "100000000000000000000000000000", false},
+		{UnbondPeriod, "1", false},
+		{UnbondPeriod, "17279", false},
+		{UnbondPeriod, "17280", true},
+		{ExpireOrdersPeriod, "1", false},
+		{ExpireOrdersPeriod, "17280", true},
+		{"unknown", "1", false},
+	} {
+		if err := Validate(c.name, c.value); (err == nil) != c.valid {
+			t.Errorf("Validate(%s, %s) error: %v", c.name, c.value, err)
+		}
+	}
+}
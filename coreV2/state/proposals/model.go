@@ -0,0 +1,30 @@
+package proposals
+
+import (
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Model is the transaction proposed by the member on behalf of the multisig, it's executed
+// once the total weight of members approved it reaches the threshold of the multisig
+type Model struct {
+	ID        uint64
+	Multisig  types.Address
+	Proposer  types.Address
+	GasCoin   types.CoinID
+	Type      byte
+	Data      []byte
+	Approvals []types.Address
+	Height    uint64
+
+	deleted bool
+}
+
+// IsApprovedBy reports whether the member approved the proposal
+func (m *Model) IsApprovedBy(member types.Address) bool {
+	for _, address := range m.Approvals {
+		if address == member {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,295 @@
+package proposals
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('m')
+
+const (
+	proposalPrefix = byte('p')
+	nextIDPrefix   = byte('n')
+)
+
+type RProposals interface {
+	Export(state *types.AppState)
+	GetProposal(id uint64) *Model
+	GetProposals(multisig types.Address) []*Model
+}
+
+// Proposals keeps transactions proposed on behalf of multisigs by their IDs
+type Proposals struct {
+	list        map[uint64]*Model
+	dirty       map[uint64]struct{}
+	nextID      uint64
+	dirtyNextID bool
+
+	bus *bus.Bus
+	db  atomic.Value
+
+	lock sync.RWMutex
+}
+
+func NewProposals(stateBus *bus.Bus, db *iavl.ImmutableTree) *Proposals {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Proposals{
+		bus:   stateBus,
+		db:    immutableTree,
+		list:  map[uint64]*Model{},
+		dirty: map[uint64]struct{}{},
+	}
+}
+
+func (p *Proposals) immutableTree() *iavl.ImmutableTree {
+	db := p.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (p *Proposals) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	p.db.Store(immutableTree)
+}
+
+func (p *Proposals) Commit(db *iavl.MutableTree, version int64) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	ids := make([]uint64, 0, len(p.dirty))
+	for id := range p.dirty {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		model := p.list[id]
+		path := getPath(id)
+
+		delete(p.dirty, id)
+		if model.deleted {
+			delete(p.list, id)
+			db.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(model)
+		if err != nil {
+			return fmt.Errorf("can't encode multisig proposal %d: %v", id, err)
+		}
+		db.Set(path, data)
+	}
+
+	if p.dirtyNextID {
+		p.dirtyNextID = false
+		data, err := rlp.EncodeToBytes(p.nextID)
+		if err != nil {
+			return fmt.Errorf("can't encode next multisig proposal ID: %v", err)
+		}
+		db.Set([]byte{mainPrefix, nextIDPrefix}, data)
+	}
+
+	return nil
+}
+
+// GetProposal returns the proposal by its ID or nil if it's not exists
+func (p *Proposals) GetProposal(id uint64) *Model {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	model := p.get(id)
+	if model == nil {
+		return nil
+	}
+	proposal := *model
+	proposal.Approvals = append([]types.Address(nil), model.Approvals...)
+	return &proposal
+}
+
+// GetProposals returns proposals of the multisig from the last committed state
+func (p *Proposals) GetProposals(multisig types.Address) []*Model {
+	var ids []uint64
+	p.immutableTree().IterateRange([]byte{mainPrefix, proposalPrefix}, []byte{mainPrefix, proposalPrefix + 1}, true, func(key []byte, value []byte) bool {
+		model := &Model{}
+		if err := rlp.DecodeBytes(value, model); err != nil {
+			panic(fmt.Sprintf("failed to decode multisig proposal: %s", err))
+		}
+		if model.Multisig == multisig {
+			ids = append(ids, model.ID)
+		}
+		return false
+	})
+
+	proposals := make([]*Model, 0, len(ids))
+	for _, id := range ids {
+		if proposal := p.GetProposal(id); proposal != nil {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals
+}
+
+// Create adds the proposal of the transaction approved by its proposer and returns its ID
+func (p *Proposals) Create(multisig, proposer types.Address, gasCoin types.CoinID, txType byte, data []byte, height uint64) uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	id := p.loadNextID()
+	p.nextID = id + 1
+	p.dirtyNextID = true
+
+	p.list[id] = &Model{
+		ID:        id,
+		Multisig:  multisig,
+		Proposer:  proposer,
+		GasCoin:   gasCoin,
+		Type:      txType,
+		Data:      append([]byte(nil), data...),
+		Approvals: []types.Address{proposer},
+		Height:    height,
+	}
+	p.dirty[id] = struct{}{}
+
+	return id
+}
+
+// Approve adds the approval of the member to the proposal
+func (p *Proposals) Approve(id uint64, member types.Address) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	model := p.get(id)
+	if model == nil || model.IsApprovedBy(member) {
+		return
+	}
+
+	model.Approvals = append(model.Approvals, member)
+	p.dirty[id] = struct{}{}
+}
+
+// Delete removes the executed or canceled proposal
+func (p *Proposals) Delete(id uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	model := p.get(id)
+	if model == nil {
+		return
+	}
+
+	model.deleted = true
+	p.dirty[id] = struct{}{}
+}
+
+func (p *Proposals) Export(state *types.AppState) {
+	p.immutableTree().IterateRange([]byte{mainPrefix, proposalPrefix}, []byte{mainPrefix, proposalPrefix + 1}, true, func(key []byte, value []byte) bool {
+		model := &Model{}
+		if err := rlp.DecodeBytes(value, model); err != nil {
+			panic(fmt.Sprintf("failed to decode multisig proposal: %s", err))
+		}
+
+		state.MultisigProposals = append(state.MultisigProposals, types.MultisigProposal{
+			ID:        model.ID,
+			Multisig:  model.Multisig,
+			Proposer:  model.Proposer,
+			GasCoin:   uint64(model.GasCoin),
+			Type:      uint64(model.Type),
+			Data:      hex.EncodeToString(model.Data),
+			Approvals: model.Approvals,
+			Height:    model.Height,
+		})
+		return false
+	})
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if id := p.loadNextID(); id > 1 {
+		state.NextProposalID = id
+	}
+}
+
+func (p *Proposals) Import(state *types.AppState) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, proposal := range state.MultisigProposals {
+		data, _ := hex.DecodeString(proposal.Data)
+		p.list[proposal.ID] = &Model{
+			ID:        proposal.ID,
+			Multisig:  proposal.Multisig,
+			Proposer:  proposal.Proposer,
+			GasCoin:   types.CoinID(proposal.GasCoin),
+			Type:      byte(proposal.Type),
+			Data:      data,
+			Approvals: append([]types.Address(nil), proposal.Approvals...),
+			Height:    proposal.Height,
+		}
+		p.dirty[proposal.ID] = struct{}{}
+	}
+
+	if state.NextProposalID > 1 {
+		p.nextID = state.NextProposalID
+		p.dirtyNextID = true
+	}
+}
+
+func (p *Proposals) get(id uint64) *Model {
+	if model, ok := p.list[id]; ok {
+		if model.deleted {
+			return nil
+		}
+		return model
+	}
+
+	_, enc := p.immutableTree().Get(getPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	model := &Model{}
+	if err := rlp.DecodeBytes(enc, model); err != nil {
+		panic(fmt.Sprintf("failed to decode multisig proposal %d: %s", id, err))
+	}
+	p.list[id] = model
+
+	return model
+}
+
+func (p *Proposals) loadNextID() uint64 {
+	if p.nextID != 0 {
+		return p.nextID
+	}
+
+	_, value := p.immutableTree().Get([]byte{mainPrefix, nextIDPrefix})
+	if len(value) == 0 {
+		return 1
+	}
+
+	var id uint64
+	if err := rlp.DecodeBytes(value, &id); err != nil {
+		panic(err)
+	}
+	return id
+}
+
+func getPath(id uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, id)
+	return append([]byte{mainPrefix, proposalPrefix}, b...)
+}
@@ -0,0 +1,70 @@
+package proposals
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestProposals(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	p := NewProposals(b, mutableTree.GetLastImmutable())
+
+	multisig := types.Address{1}
+	first := p.Create(multisig, types.Address{2}, 0, 1, []byte{1, 2, 3}, 10)
+	second := p.Create(multisig, types.Address{3}, 1, 2, []byte{4}, 11)
+	third := p.Create(types.Address{4}, types.Address{5}, 0, 1, nil, 12)
+	if first != 1 || second != 2 || third != 3 {
+		t.Fatalf("IDs are not correct: %d, %d, %d", first, second, third)
+	}
+
+	p.Approve(first, types.Address{3})
+	p.Approve(first, types.Address{3})
+	if proposal := p.GetProposal(first); len(proposal.Approvals) != 2 || !proposal.IsApprovedBy(types.Address{3}) {
+		t.Fatalf("Approvals are not correct: %+v", proposal.Approvals)
+	}
+
+	if _, _, err := mutableTree.Commit(p); err != nil {
+		t.Fatal(err)
+	}
+
+	p = NewProposals(b, mutableTree.GetLastImmutable())
+	if proposals := p.GetProposals(multisig); len(proposals) != 2 || proposals[0].ID != first || proposals[1].GasCoin != 1 || string(proposals[0].Data) != string([]byte{1, 2, 3}) {
+		t.Fatalf("Proposals are not correct: %+v", proposals)
+	}
+
+	p.Delete(second)
+	if proposal := p.GetProposal(second); proposal != nil {
+		t.Fatalf("Deleted proposal exists: %+v", proposal)
+	}
+
+	if _, _, err := mutableTree.Commit(p); err != nil {
+		t.Fatal(err)
+	}
+
+	appState := &types.AppState{}
+	NewProposals(b, mutableTree.GetLastImmutable()).Export(appState)
+	if len(appState.MultisigProposals) != 2 || appState.MultisigProposals[0].Data != "010203" || len(appState.MultisigProposals[0].Approvals) != 2 || appState.NextProposalID != 4 {
+		t.Fatalf("Exported proposals are not correct: %+v, next ID %d", appState.MultisigProposals, appState.NextProposalID)
+	}
+
+	p = NewProposals(b, nil)
+	mutableTree, _ = tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	p.SetImmutableTree(mutableTree.GetLastImmutable())
+	p.Import(appState)
+	if _, _, err := mutableTree.Commit(p); err != nil {
+		t.Fatal(err)
+	}
+	p = NewProposals(b, mutableTree.GetLastImmutable())
+	if proposal := p.GetProposal(third); proposal == nil || proposal.Proposer != (types.Address{5}) || proposal.Height != 12 {
+		t.Fatalf("Imported proposal is not correct: %+v", proposal)
+	}
+	if id := p.Create(multisig, types.Address{2}, 0, 1, nil, 20); id != 4 {
+		t.Fatalf("ID is not correct: %d", id)
+	}
+}
@@ -0,0 +1,36 @@
+package recurring
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Model is the standing order of the payer to transfer Value of Coin to the recipient every Period blocks,
+// Remaining payments are left and the next one is made at the end of NextHeight block
+type Model struct {
+	ID         uint64
+	Payer      types.Address
+	Recipient  types.Address
+	Coin       types.CoinID
+	Value      *big.Int
+	Period     uint64
+	Remaining  uint64
+	NextHeight uint64
+
+	deleted bool
+}
+
+// dueList keeps IDs of payments due at the height
+type dueList struct {
+	IDs []uint64
+}
+
+func (l *dueList) remove(id uint64) {
+	for i, item := range l.IDs {
+		if item == id {
+			l.IDs = append(l.IDs[:i:i], l.IDs[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package recurring
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('y')
+
+const (
+	paymentPrefix = byte('p')
+	duePrefix     = byte('h')
+	nextIDPrefix  = byte('n')
+)
+
+type RRecurringPayments interface {
+	Export(state *types.AppState)
+	GetPayment(id uint64) *Model
+	GetPaymentsByPayer(payer types.Address) []*Model
+}
+
+// RecurringPayments keeps standing orders of payers by IDs and lists of IDs by heights of their next payments
+type RecurringPayments struct {
+	list        map[uint64]*Model
+	dirty       map[uint64]struct{}
+	due         map[uint64]*dueList
+	dirtyDue    map[uint64]struct{}
+	nextID      uint64
+	dirtyNextID bool
+
+	bus *bus.Bus
+	db  atomic.Value
+
+	lock sync.RWMutex
+}
+
+func NewRecurringPayments(stateBus *bus.Bus, db *iavl.ImmutableTree) *RecurringPayments {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &RecurringPayments{
+		bus:      stateBus,
+		db:       immutableTree,
+		list:     map[uint64]*Model{},
+		dirty:    map[uint64]struct{}{},
+		due:      map[uint64]*dueList{},
+		dirtyDue: map[uint64]struct{}{},
+	}
+}
+
+func (r *RecurringPayments) immutableTree() *iavl.ImmutableTree {
+	db := r.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (r *RecurringPayments) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	r.db.Store(immutableTree)
+}
+
+func (r *RecurringPayments) Commit(db *iavl.MutableTree, version int64) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, id := range orderedKeys(r.dirty) {
+		model := r.list[id]
+		path := getPaymentPath(id)
+
+		delete(r.dirty, id)
+		if model.deleted {
+			delete(r.list, id)
+			db.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(model)
+		if err != nil {
+			return fmt.Errorf("can't encode recurring payment %d: %v", id, err)
+		}
+		db.Set(path, data)
+	}
+
+	for _, height := range orderedKeys(r.dirtyDue) {
+		list := r.due[height]
+		path := getDuePath(height)
+
+		delete(r.dirtyDue, height)
+		if len(list.IDs) == 0 {
+			delete(r.due, height)
+			db.Remove(path)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(list)
+		if err != nil {
+			return fmt.Errorf("can't encode recurring payments due at %d: %v", height, err)
+		}
+		db.Set(path, data)
+	}
+
+	if r.dirtyNextID {
+		r.dirtyNextID = false
+		data, err := rlp.EncodeToBytes(r.nextID)
+		if err != nil {
+			return fmt.Errorf("can't encode next recurring payment ID: %v", err)
+		}
+		db.Set([]byte{mainPrefix, nextIDPrefix}, data)
+	}
+
+	return nil
+}
+
+// GetPayment returns the payment by its ID or nil if it's not exists
+func (r *RecurringPayments) GetPayment(id uint64) *Model {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	model := r.get(id)
+	if model == nil {
+		return nil
+	}
+	payment := *model
+	payment.Value = big.NewInt(0).Set(model.Value)
+	return &payment
+}
+
+// GetPaymentsByPayer returns not finished payments of the payer from the last committed state
+func (r *RecurringPayments) GetPaymentsByPayer(payer types.Address) []*Model {
+	var ids []uint64
+	r.immutableTree().IterateRange([]byte{mainPrefix, paymentPrefix}, []byte{mainPrefix, paymentPrefix + 1}, true, func(key []byte, value []byte) bool {
+		model := &Model{}
+		if err := rlp.DecodeBytes(value, model); err != nil {
+			panic(fmt.Sprintf("failed to decode recurring payment: %s", err))
+		}
+		if model.Payer == payer {
+			ids = append(ids, model.ID)
+		}
+		return false
+	})
+
+	payments := make([]*Model, 0, len(ids))
+	for _, id := range ids {
+		if payment := r.GetPayment(id); payment != nil {
+			payments = append(payments, payment)
+		}
+	}
+	return payments
+}
+
+// GetDuePayments returns payments to be made at the height
+func (r *RecurringPayments) GetDuePayments(height uint64) []*Model {
+	r.lock.Lock()
+	list, ok := r.due[height]
+	if !ok {
+		// the list is read every block, so it's cached only when changed
+		list = r.loadDue(height)
+	}
+	ids := append([]uint64(nil), list.IDs...)
+	r.lock.Unlock()
+
+	payments := make([]*Model, 0, len(ids))
+	for _, id := range ids {
+		if payment := r.GetPayment(id); payment != nil {
+			payments = append(payments, payment)
+		}
+	}
+	return payments
+}
+
+// Create registers the payment of the payer with the first payment at the height and returns its ID
+func (r *RecurringPayments) Create(payer, recipient types.Address, coin types.CoinID, value *big.Int, period, times, height uint64) uint64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := r.loadNextID()
+	r.nextID = id + 1
+	r.dirtyNextID = true
+
+	r.add(&Model{
+		ID:         id,
+		Payer:      payer,
+		Recipient:  recipient,
+		Coin:       coin,
+		Value:      big.NewInt(0).Set(value),
+		Period:     period,
+		Remaining:  times,
+		NextHeight: height,
+	})
+
+	return id
+}
+
+// Cancel removes the payment with remaining payments
+func (r *RecurringPayments) Cancel(id uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	model := r.get(id)
+	if model == nil {
+		return
+	}
+
+	r.getDue(model.NextHeight).remove(id)
+	r.dirtyDue[model.NextHeight] = struct{}{}
+
+	model.deleted = true
+	r.dirty[id] = struct{}{}
+}
+
+// Processed counts the payment made or failed at its next height and schedules the following one,
+// the payment without remaining payments is removed
+func (r *RecurringPayments) Processed(id uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	model := r.get(id)
+	if model == nil {
+		return
+	}
+
+	r.getDue(model.NextHeight).remove(id)
+	r.dirtyDue[model.NextHeight] = struct{}{}
+	r.dirty[id] = struct{}{}
+
+	model.Remaining--
+	if model.Remaining == 0 {
+		model.deleted = true
+		return
+	}
+
+	model.NextHeight += model.Period
+	due := r.getDue(model.NextHeight)
+	due.IDs = append(due.IDs, id)
+	r.dirtyDue[model.NextHeight] = struct{}{}
+}
+
+func (r *RecurringPayments) Export(state *types.AppState) {
+	r.immutableTree().IterateRange([]byte{mainPrefix, paymentPrefix}, []byte{mainPrefix, paymentPrefix + 1}, true, func(key []byte, value []byte) bool {
+		model := &Model{}
+		if err := rlp.DecodeBytes(value, model); err != nil {
+			panic(fmt.Sprintf("failed to decode recurring payment: %s", err))
+		}
+
+		state.RecurringPayments = append(state.RecurringPayments, types.RecurringPayment{
+			ID:         model.ID,
+			Payer:      model.Payer,
+			Recipient:  model.Recipient,
+			Coin:       uint64(model.Coin),
+			Value:      model.Value.String(),
+			Period:     model.Period,
+			Remaining:  model.Remaining,
+			NextHeight: model.NextHeight,
+		})
+		return false
+	})
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if id := r.loadNextID(); id > 1 {
+		state.NextRecurringID = id
+	}
+}
+
+func (r *RecurringPayments) Import(state *types.AppState) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, payment := range state.RecurringPayments {
+		r.add(&Model{
+			ID:         payment.ID,
+			Payer:      payment.Payer,
+			Recipient:  payment.Recipient,
+			Coin:       types.CoinID(payment.Coin),
+			Value:      helpers.StringToBigInt(payment.Value),
+			Period:     payment.Period,
+			Remaining:  payment.Remaining,
+			NextHeight: payment.NextHeight,
+		})
+	}
+
+	if state.NextRecurringID > 1 {
+		r.nextID = state.NextRecurringID
+		r.dirtyNextID = true
+	}
+}
+
+func (r *RecurringPayments) add(model *Model) {
+	r.list[model.ID] = model
+	r.dirty[model.ID] = struct{}{}
+
+	due := r.getDue(model.NextHeight)
+	due.IDs = append(due.IDs, model.ID)
+	r.dirtyDue[model.NextHeight] = struct{}{}
+}
+
+func (r *RecurringPayments) get(id uint64) *Model {
+	if model, ok := r.list[id]; ok {
+		if model.deleted {
+			return nil
+		}
+		return model
+	}
+
+	_, enc := r.immutableTree().Get(getPaymentPath(id))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	model := &Model{}
+	if err := rlp.DecodeBytes(enc, model); err != nil {
+		panic(fmt.Sprintf("failed to decode recurring payment %d: %s", id, err))
+	}
+	r.list[id] = model
+
+	return model
+}
+
+func (r *RecurringPayments) getDue(height uint64) *dueList {
+	if list, ok := r.due[height]; ok {
+		return list
+	}
+
+	list := r.loadDue(height)
+	r.due[height] = list
+
+	return list
+}
+
+func (r *RecurringPayments) loadDue(height uint64) *dueList {
+	list := &dueList{}
+	if _, enc := r.immutableTree().Get(getDuePath(height)); len(enc) != 0 {
+		if err := rlp.DecodeBytes(enc, list); err != nil {
+			panic(fmt.Sprintf("failed to decode recurring payments due at %d: %s", height, err))
+		}
+	}
+
+	return list
+}
+
+func (r *RecurringPayments) loadNextID() uint64 {
+	if r.nextID != 0 {
+		return r.nextID
+	}
+
+	_, value := r.immutableTree().Get([]byte{mainPrefix, nextIDPrefix})
+	if len(value) == 0 {
+		return 1
+	}
+
+	var id uint64
+	if err := rlp.DecodeBytes(value, &id); err != nil {
+		panic(err)
+	}
+	return id
+}
+
+func orderedKeys(m map[uint64]struct{}) []uint64 {
+	keys := make([]uint64, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func getPaymentPath(id uint64) []byte {
+	return append([]byte{mainPrefix, paymentPrefix}, uint64ToBytes(id)...)
+}
+
+func getDuePath(height uint64) []byte {
+	return append([]byte{mainPrefix, duePrefix}, uint64ToBytes(height)...)
+}
+
+func uint64ToBytes(value uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, value)
+	return b
+}
@@ -0,0 +1,81 @@
+package recurring
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestRecurringPayments(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	r := NewRecurringPayments(b, mutableTree.GetLastImmutable())
+
+	payer := types.Address{1}
+	first := r.Create(payer, types.Address{2}, 0, big.NewInt(100), 10, 2, 15)
+	second := r.Create(payer, types.Address{3}, 1, big.NewInt(200), 5, 3, 15)
+	third := r.Create(types.Address{4}, payer, 0, big.NewInt(300), 1, 1, 20)
+	if first != 1 || second != 2 || third != 3 {
+		t.Fatalf("IDs are not correct: %d, %d, %d", first, second, third)
+	}
+
+	if _, _, err := mutableTree.Commit(r); err != nil {
+		t.Fatal(err)
+	}
+
+	r = NewRecurringPayments(b, mutableTree.GetLastImmutable())
+	if payments := r.GetPaymentsByPayer(payer); len(payments) != 2 || payments[1].Recipient != (types.Address{3}) || payments[1].Value.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("Payments are not correct: %+v", payments)
+	}
+	if payments := r.GetDuePayments(15); len(payments) != 2 {
+		t.Fatalf("Due payments are not correct: %+v", payments)
+	}
+
+	r.Processed(first)
+	r.Cancel(second)
+	if payments := r.GetDuePayments(15); len(payments) != 0 {
+		t.Fatalf("Due payments are not correct: %+v", payments)
+	}
+	if payments := r.GetDuePayments(25); len(payments) != 1 || payments[0].ID != first || payments[0].Remaining != 1 {
+		t.Fatalf("Due payments are not correct: %+v", payments)
+	}
+	if payment := r.GetPayment(second); payment != nil {
+		t.Fatalf("Canceled payment exists: %+v", payment)
+	}
+
+	if _, _, err := mutableTree.Commit(r); err != nil {
+		t.Fatal(err)
+	}
+
+	r = NewRecurringPayments(b, mutableTree.GetLastImmutable())
+	r.Processed(first)
+	if _, _, err := mutableTree.Commit(r); err != nil {
+		t.Fatal(err)
+	}
+
+	appState := &types.AppState{}
+	NewRecurringPayments(b, mutableTree.GetLastImmutable()).Export(appState)
+	if len(appState.RecurringPayments) != 1 || appState.RecurringPayments[0].ID != third || appState.NextRecurringID != 4 {
+		t.Fatalf("Exported payments are not correct: %+v, next ID %d", appState.RecurringPayments, appState.NextRecurringID)
+	}
+
+	r = NewRecurringPayments(b, nil)
+	mutableTree, _ = tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	r.SetImmutableTree(mutableTree.GetLastImmutable())
+	r.Import(appState)
+	if _, _, err := mutableTree.Commit(r); err != nil {
+		t.Fatal(err)
+	}
+	r = NewRecurringPayments(b, mutableTree.GetLastImmutable())
+	if payments := r.GetDuePayments(20); len(payments) != 1 || payments[0].Payer != (types.Address{4}) {
+		t.Fatalf("Imported payments are not correct: %+v", payments)
+	}
+	if id := r.Create(payer, payer, 0, big.NewInt(1), 1, 1, 30); id != 4 {
+		t.Fatalf("ID is not correct: %d", id)
+	}
+}
@@ -2,12 +2,14 @@ package state
 
 import (
 	"encoding/hex"
+	"errors"
 	"log"
 	"math/big"
 	"sync"
 
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/allowances"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/app"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
@@ -17,9 +19,14 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/frozenfunds"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/halts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/htlc"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/proposals"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/recurring"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/update"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/validators"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/vesting"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/waitlist"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/helpers"
@@ -49,20 +56,39 @@ func (cs *CheckState) Export() types.AppState {
 	cs.Candidates().Export(appState)
 	cs.WaitList().Export(appState)
 	cs.FrozenFunds().Export(appState, uint64(cs.state.height))
-	cs.Accounts().Export(appState)
+	cs.AccountsWithLocked().Export(appState)
 	cs.Coins().Export(appState)
 	cs.Checks().Export(appState)
+	cs.HTLCs().Export(appState)
+	cs.Vestings().Export(appState, uint64(cs.state.height))
+	cs.Allowances().Export(appState)
+	cs.Recurring().Export(appState)
+	cs.Proposals().Export(appState)
 	cs.Halts().Export(appState)
 	cs.Swap().Export(appState)
 	cs.Commission().Export(appState)
 	cs.Updates().Export(appState)
+	cs.Params().Export(appState)
 
 	return *appState
 }
 
+// Fork returns deliver state on top of the last committed version of the state, uncommitted changes are not visible in the fork.
+// Unlike State.Fork it doesn't change the state, so it's used to check transactions.
+func (cs *CheckState) Fork(withCandidates bool) (*State, error) {
+	if cs.state.tree == nil {
+		return nil, errors.New("state has no tree to fork")
+	}
+	return cs.state.fork(cs.state.tree.GetLastImmutable(), withCandidates)
+}
+
 func (cs *CheckState) Updates() update.RUpdate {
 	return cs.state.Updates
 }
+
+func (cs *CheckState) Params() params.RParams {
+	return cs.state.Params
+}
 func (cs *CheckState) Validators() validators.RValidators {
 	return cs.state.Validators
 }
@@ -81,7 +107,18 @@ func (cs *CheckState) InitialHeight() int64 {
 func (cs *CheckState) Halts() halts.RHalts {
 	return cs.state.Halts
 }
+
+// Accounts returns accounts with spendable balances, coins locked by vesting schedules at the next block are excluded
 func (cs *CheckState) Accounts() accounts.RAccounts {
+	return &spendableAccounts{
+		RAccounts: cs.state.Accounts,
+		vestings:  cs.state.Vestings,
+		height:    uint64(cs.state.height) + 1,
+	}
+}
+
+// AccountsWithLocked returns accounts with whole balances including coins locked by vesting schedules
+func (cs *CheckState) AccountsWithLocked() accounts.RAccounts {
 	return cs.state.Accounts
 }
 func (cs *CheckState) Coins() coins.RCoins {
@@ -90,6 +127,21 @@ func (cs *CheckState) Coins() coins.RCoins {
 func (cs *CheckState) Checks() checks.RChecks {
 	return cs.state.Checks
 }
+func (cs *CheckState) HTLCs() htlc.RHTLCs {
+	return cs.state.HTLCs
+}
+func (cs *CheckState) Vestings() vesting.RVestings {
+	return cs.state.Vestings
+}
+func (cs *CheckState) Allowances() allowances.RAllowances {
+	return cs.state.Allowances
+}
+func (cs *CheckState) Recurring() recurring.RRecurringPayments {
+	return cs.state.Recurring
+}
+func (cs *CheckState) Proposals() proposals.RProposals {
+	return cs.state.Proposals
+}
 func (cs *CheckState) WaitList() waitlist.RWaitList {
 	return cs.state.Waitlist
 }
@@ -105,6 +157,35 @@ func (cs *CheckState) Commission() commission.RCommission {
 	return cs.state.Commission
 }
 
+// spendableAccounts excludes coins locked by vesting schedules from balances checked by transactions.
+// Both GetBalance and GetBalances return spendable values, the model of GetAccount doesn't expose balances.
+type spendableAccounts struct {
+	accounts.RAccounts
+	vestings *vesting.Vestings
+	height   uint64
+}
+
+func (a *spendableAccounts) GetBalance(address types.Address, coin types.CoinID) *big.Int {
+	balance := a.RAccounts.GetBalance(address, coin)
+	locked := a.vestings.GetLocked(address, coin, a.height)
+	if locked.Sign() == 0 {
+		return balance
+	}
+
+	if balance.Cmp(locked) <= 0 {
+		return big.NewInt(0)
+	}
+	return balance.Sub(balance, locked)
+}
+
+func (a *spendableAccounts) GetBalances(address types.Address) []accounts.Balance {
+	balances := a.RAccounts.GetBalances(address)
+	for i, balance := range balances {
+		balances[i].Value = a.GetBalance(address, balance.Coin.ID)
+	}
+	return balances
+}
+
 type State struct {
 	App         *app.App
 	Validators  *validators.Validators
@@ -114,12 +195,18 @@ type State struct {
 	Accounts    *accounts.Accounts
 	Coins       *coins.Coins
 	Checks      *checks.Checks
+	HTLCs       *htlc.HTLCs
+	Vestings    *vesting.Vestings
+	Allowances  *allowances.Allowances
+	Recurring   *recurring.RecurringPayments
+	Proposals   *proposals.Proposals
 	Checker     *checker.Checker
 	Waitlist    *waitlist.WaitList
 	Swap        *swap.Swap
 	SwapV2      *swap.SwapV2
 	Commission  *commission.Commission
 	Updates     *update.Update
+	Params      *params.Params
 
 	db     db.DB
 	events eventsdb.IEventsDB
@@ -196,6 +283,78 @@ func NewCheckStateAtHeightV3(height uint64, db db.DB) (*CheckState, error) {
 	return newCheckStateForTreeV2(iavlTree, nil, db, 0)
 }
 
+// NewCheckStateForImmutableTree returns read-only state on top of the given immutable tree.
+func NewCheckStateForImmutableTree(immutableTree *iavl.ImmutableTree) (*CheckState, error) {
+	return newCheckStateForTreeV2(immutableTree, nil, nil, 0)
+}
+
+// NewSimulationState returns deliver state on top of the given immutable tree committed at the height.
+// It has no mutable tree and must not be committed, all changes are kept in memory and dropped with the state.
+func NewSimulationState(immutableTree *iavl.ImmutableTree, height uint64, events eventsdb.IEventsDB) (*State, error) {
+	state, err := newStateForTreeV2(immutableTree, events, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	state.height = int64(height)
+
+	state.Candidates.LoadCandidatesDeliver()
+	state.Candidates.LoadStakes()
+	state.Validators.LoadValidators()
+
+	return state, nil
+}
+
+// Fork returns deliver state on top of the current state including its uncommitted changes.
+// The fork reads the same committed tree and copies models changed by the state from its caches on first access,
+// neither the state nor its tree are changed by the fork. Changes of the fork are kept in memory and dropped with it.
+// Candidates and stakes are copied to the fork, and validators are loaded, only if withCandidates is true.
+func (s *State) Fork(withCandidates bool) (*State, error) {
+	if s.tree == nil {
+		return nil, errors.New("state has no tree to fork")
+	}
+
+	fork, err := s.fork(s.tree.GetLastImmutable(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	fork.Accounts.SetParent(s.Accounts)
+	fork.Coins.SetParent(s.Coins)
+	fork.Waitlist.SetParent(s.Waitlist)
+	fork.Vestings.SetParent(s.Vestings)
+	// pairs of the deprecated swap are read from the tree only, batches are accepted after SwapV2
+	if s.SwapV2 != nil {
+		fork.SwapV2.SetParent(s.SwapV2)
+	}
+	if withCandidates {
+		fork.Candidates.SetParent(s.Candidates)
+		fork.Validators.LoadValidators()
+	}
+
+	return fork, nil
+}
+
+func (s *State) fork(immutableTree *iavl.ImmutableTree, withCandidates bool) (*State, error) {
+	newStateForVersion := newStateForTreeV2
+	if s.SwapV2 == nil {
+		newStateForVersion = newStateForTree
+	}
+	fork, err := newStateForVersion(immutableTree, &eventsdb.MockEvents{}, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	fork.height = s.height
+	fork.InitialVersion = s.InitialVersion
+
+	if withCandidates {
+		fork.Candidates.LoadCandidatesDeliver()
+		fork.Candidates.LoadStakes()
+		fork.Validators.LoadValidators()
+	}
+
+	return fork, nil
+}
+
 func (s *State) Tree() tree.MTree {
 	return s.tree
 }
@@ -261,12 +420,18 @@ func (s *State) Commit() ([]byte, error) {
 		s.Candidates,
 		s.Validators,
 		s.Checks,
+		s.HTLCs,
+		s.Vestings,
+		s.Allowances,
+		s.Recurring,
+		s.Proposals,
 		s.FrozenFunds,
 		s.Halts,
 		s.Waitlist,
 		s.GetSwap(),
 		s.Commission,
 		s.Updates,
+		s.Params,
 	)
 	if err != nil {
 		return hash, err
@@ -376,6 +541,21 @@ func (s *State) Import(state types.AppState, version string) error {
 		s.Checks.UseCheckHash(hash)
 	}
 
+	for _, h := range state.HTLCs {
+		s.HTLCs.Create(h.HashLock, h.Sender, h.Recipient, types.CoinID(h.Coin), helpers.StringToBigInt(h.Value), h.Timeout)
+	}
+
+	for _, v := range state.Vestings {
+		s.Vestings.AddSchedule(v.Address, types.CoinID(v.Coin), helpers.StringToBigInt(v.Value), v.StartHeight, v.CliffHeight, v.EndHeight, v.Steps)
+	}
+
+	for _, a := range state.Allowances {
+		s.Allowances.SetAllowance(a.Owner, a.Spender, types.CoinID(a.Coin), helpers.StringToBigInt(a.Value))
+	}
+
+	s.Recurring.Import(&state)
+	s.Proposals.Import(&state)
+
 	for _, ff := range state.FrozenFunds {
 		coinID := types.CoinID(ff.Coin)
 		value := helpers.StringToBigInt(ff.Value)
@@ -521,6 +701,8 @@ func (s *State) Import(state types.AppState, version string) error {
 		}
 	}
 
+	s.Params.Import(&state)
+
 	return nil
 }
 
@@ -581,6 +763,12 @@ func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsD
 
 	checksState := checks.NewChecks(immutableTree)
 
+	htlcsState := htlc.NewHTLCs(stateBus, immutableTree)
+	vestingsState := vesting.NewVestings(stateBus, immutableTree)
+	allowancesState := allowances.NewAllowances(stateBus, immutableTree)
+	recurringState := recurring.NewRecurringPayments(stateBus, immutableTree)
+	proposalsState := proposals.NewProposals(stateBus, immutableTree)
+
 	haltsState := halts.NewHalts(stateBus, immutableTree)
 
 	waitlistState := waitlist.NewWaitList(stateBus, immutableTree)
@@ -591,6 +779,8 @@ func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsD
 
 	update := update.New(immutableTree)
 
+	paramsState := params.NewParams(stateBus, immutableTree)
+
 	state := &State{
 		Validators:  validatorsState,
 		App:         appState,
@@ -599,12 +789,18 @@ func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsD
 		Accounts:    accountsState,
 		Coins:       coinsState,
 		Checks:      checksState,
+		HTLCs:       htlcsState,
+		Vestings:    vestingsState,
+		Allowances:  allowancesState,
+		Recurring:   recurringState,
+		Proposals:   proposalsState,
 		Checker:     stateChecker,
 		Halts:       haltsState,
 		Waitlist:    waitlistState,
 		Swap:        pool,
 		Commission:  commission,
 		Updates:     update,
+		Params:      paramsState,
 
 		height:         immutableTree.Version(),
 		bus:            stateBus,
@@ -635,6 +831,12 @@ func newStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEvent
 
 	checksState := checks.NewChecks(immutableTree)
 
+	htlcsState := htlc.NewHTLCs(stateBus, immutableTree)
+	vestingsState := vesting.NewVestings(stateBus, immutableTree)
+	allowancesState := allowances.NewAllowances(stateBus, immutableTree)
+	recurringState := recurring.NewRecurringPayments(stateBus, immutableTree)
+	proposalsState := proposals.NewProposals(stateBus, immutableTree)
+
 	haltsState := halts.NewHalts(stateBus, immutableTree)
 
 	waitlistState := waitlist.NewWaitList(stateBus, immutableTree)
@@ -645,6 +847,8 @@ func newStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEvent
 
 	update := update.New(immutableTree)
 
+	paramsState := params.NewParams(stateBus, immutableTree)
+
 	state := &State{
 		Validators:  validatorsState,
 		App:         appState,
@@ -653,12 +857,18 @@ func newStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEvent
 		Accounts:    accountsState,
 		Coins:       coinsState,
 		Checks:      checksState,
+		HTLCs:       htlcsState,
+		Vestings:    vestingsState,
+		Allowances:  allowancesState,
+		Recurring:   recurringState,
+		Proposals:   proposalsState,
 		Checker:     stateChecker,
 		Halts:       haltsState,
 		Waitlist:    waitlistState,
 		SwapV2:      poolV2,
 		Commission:  commission,
 		Updates:     update,
+		Params:      paramsState,
 
 		height:         immutableTree.Version(),
 		bus:            stateBus,
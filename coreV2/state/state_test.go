@@ -354,3 +354,67 @@ func TestStateExport(t *testing.T) {
 		t.Fatal("Invalid waitlist data")
 	}
 }
+
+func TestState_Fork(t *testing.T) {
+	t.Parallel()
+	state, err := NewStateV3(0, db.NewMemDB(), &eventsdb.MockEvents{}, 1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, coin, pubkey := types.Address{1}, types.GetBaseCoinID(), types.Pubkey{1}
+	state.Accounts.SetBalance(address, coin, big.NewInt(100))
+	if _, err := state.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// uncommitted changes are visible in the fork
+	state.Accounts.AddBalance(address, coin, big.NewInt(50))
+	state.SwapV2.PairCreate(coin, 1, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(1000)))
+	state.Vestings.AddSchedule(address, coin, big.NewInt(10), 0, 10, 20, 0)
+	state.Candidates.Create(address, address, address, pubkey, 10, 0, 0)
+
+	fork, err := state.Fork(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance := fork.Accounts.GetBalance(address, coin); balance.Cmp(big.NewInt(150)) != 0 {
+		t.Fatalf("balance in the fork is not correct: %s", balance)
+	}
+	if fork.SwapV2.Pair(coin, 1) == nil {
+		t.Fatal("uncommitted pair is not found in the fork")
+	}
+	if len(fork.Vestings.GetSchedules(address)) != 1 {
+		t.Fatal("uncommitted schedule is not found in the fork")
+	}
+	if fork.Candidates.GetCandidate(pubkey) == nil {
+		t.Fatal("uncommitted candidate is not found in the fork")
+	}
+
+	// changes of the fork are not visible in the state
+	reserve0, reserve1 := state.SwapV2.Pair(coin, 1).Reserves()
+	fork.Accounts.SubBalance(address, coin, big.NewInt(150))
+	fork.SwapV2.PairSell(coin, 1, helpers.BipToPip(big.NewInt(10)), big.NewInt(0))
+	if balance := state.Accounts.GetBalance(address, coin); balance.Cmp(big.NewInt(150)) != 0 {
+		t.Fatalf("balance in the state is changed by the fork: %s", balance)
+	}
+	if newReserve0, newReserve1 := state.SwapV2.Pair(coin, 1).Reserves(); newReserve0.Cmp(reserve0) != 0 || newReserve1.Cmp(reserve1) != 0 {
+		t.Fatal("reserves in the state are changed by the fork")
+	}
+
+	// uncommitted changes of the state are kept until the commit
+	if _, err := state.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	committed, err := NewCheckStateAtHeightV3(uint64(state.Tree().Version()), state.db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance := committed.Accounts().GetBalance(address, coin); balance.Cmp(big.NewInt(140)) != 0 {
+		t.Fatalf("committed spendable balance is not correct: %s", balance)
+	}
+	committed.Candidates().LoadCandidates()
+	if !committed.Swap().SwapPoolExist(coin, 1) || !committed.Candidates().Exists(pubkey) {
+		t.Fatal("uncommitted changes are lost after the fork")
+	}
+}
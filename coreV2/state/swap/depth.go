@@ -0,0 +1,144 @@
+package swap
+
+import (
+	"math/big"
+)
+
+// DepthLevel is the price level of the order book of the pair.
+// Orders with prices within one tick from Price are aggregated into it,
+// prices are amounts of Coin1 per unit of Coin0 of the pair.
+type DepthLevel struct {
+	Price *big.Rat
+	// Orders is the number of limit orders at the level
+	Orders int
+	// Volume0 and Volume1 are the amounts of limit orders at the level
+	Volume0 *big.Int
+	Volume1 *big.Int
+	// CumulativeVolume0 is the amount of Coin0 of limit orders from the pool price to the level inclusive
+	CumulativeVolume0 *big.Int
+	// PoolVolume0 is the amount of Coin0 exchanged by the pool to move its price to the level
+	PoolVolume0 *big.Int
+}
+
+// Depth is the order book of the pair, Bids are orders buying Coin0 and Asks are orders selling Coin0,
+// both sides start from the pool price.
+type Depth struct {
+	Price *big.Rat
+	Bids  []*DepthLevel
+	Asks  []*DepthLevel
+}
+
+// CalculateDepth aggregates limit orders of the pair into levels of given tick size and adds the liquidity of the pool.
+// The pair is the swapper of Coin0 and Coin1, levels is the number of levels of each side,
+// ordersLimit is the maximum number of orders of each side to aggregate.
+func CalculateDepth(pair EditableChecker, tick *big.Rat, levels int, ordersLimit uint32) *Depth {
+	price := pair.PriceRat()
+	depth := &Depth{Price: price}
+	if tick.Sign() != 1 || levels <= 0 {
+		return depth
+	}
+
+	bidTop := floorDiv(price, tick)
+	for i := 0; i < levels; i++ {
+		levelPrice := new(big.Rat).Mul(tick, new(big.Rat).SetInt(new(big.Int).Sub(bidTop, big.NewInt(int64(i)))))
+		if levelPrice.Sign() != 1 {
+			break
+		}
+		level := newDepthLevel(levelPrice)
+		if levelPrice.Cmp(price) == -1 {
+			if amount0, _ := pair.CalculateAddAmountsForPrice(ratToFloat(levelPrice)); amount0 != nil {
+				level.PoolVolume0 = amount0
+			}
+		}
+		depth.Bids = append(depth.Bids, level)
+	}
+
+	if ordersLimit > 0 {
+		for _, order := range pair.OrdersSell(ordersLimit) {
+			if order == nil || order.isEmpty() {
+				continue
+			}
+			index := new(big.Int).Sub(bidTop, floorDiv(order.PriceRat(), tick))
+			if !index.IsInt64() || index.Int64() < 0 || index.Int64() >= int64(len(depth.Bids)) {
+				continue
+			}
+			depth.Bids[index.Int64()].add(order.WantBuy, order.WantSell)
+		}
+	}
+
+	reversed := pair.Reverse()
+	askBottom := ceilDiv(price, tick)
+	for i := 0; i < levels; i++ {
+		levelPrice := new(big.Rat).Mul(tick, new(big.Rat).SetInt(new(big.Int).Add(askBottom, big.NewInt(int64(i)))))
+		level := newDepthLevel(levelPrice)
+		if levelPrice.Cmp(price) == 1 && price.Sign() == 1 {
+			if _, amount0 := reversed.CalculateAddAmountsForPrice(ratToFloat(new(big.Rat).Inv(levelPrice))); amount0 != nil {
+				level.PoolVolume0 = amount0
+			}
+		}
+		depth.Asks = append(depth.Asks, level)
+	}
+
+	if ordersLimit > 0 {
+		for _, order := range reversed.OrdersSell(ordersLimit) {
+			if order == nil || order.isEmpty() {
+				continue
+			}
+			index := new(big.Int).Sub(ceilDiv(new(big.Rat).Inv(order.PriceRat()), tick), askBottom)
+			if !index.IsInt64() || index.Int64() < 0 || index.Int64() >= int64(len(depth.Asks)) {
+				continue
+			}
+			depth.Asks[index.Int64()].add(order.WantSell, order.WantBuy)
+		}
+	}
+
+	accumulateDepth(depth.Bids)
+	accumulateDepth(depth.Asks)
+
+	return depth
+}
+
+func newDepthLevel(price *big.Rat) *DepthLevel {
+	return &DepthLevel{
+		Price:             price,
+		Volume0:           big.NewInt(0),
+		Volume1:           big.NewInt(0),
+		CumulativeVolume0: big.NewInt(0),
+		PoolVolume0:       big.NewInt(0),
+	}
+}
+
+func (l *DepthLevel) add(volume0, volume1 *big.Int) {
+	l.Orders++
+	l.Volume0.Add(l.Volume0, volume0)
+	l.Volume1.Add(l.Volume1, volume1)
+}
+
+func accumulateDepth(levels []*DepthLevel) {
+	cumulative := big.NewInt(0)
+	for _, level := range levels {
+		cumulative.Add(cumulative, level.Volume0)
+		level.CumulativeVolume0.Set(cumulative)
+	}
+}
+
+// floorDiv returns the largest integer not greater than a/b
+func floorDiv(a, b *big.Rat) *big.Int {
+	q := new(big.Rat).Quo(a, b)
+	result, _ := new(big.Int).DivMod(q.Num(), q.Denom(), new(big.Int))
+	return result
+}
+
+// ceilDiv returns the smallest integer not less than a/b
+func ceilDiv(a, b *big.Rat) *big.Int {
+	q := new(big.Rat).Quo(a, b)
+	result, m := new(big.Int).DivMod(q.Num(), q.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	return result
+}
+
+func ratToFloat(rat *big.Rat) *big.Float {
+	return new(big.Float).SetPrec(Precision).SetRat(rat)
+}
@@ -0,0 +1,61 @@
+package swap
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestCalculateDepth(t *testing.T) {
+	immutableTree, err := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	_, _, _, _ = swap.PairCreate(0, 1, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(2000)))
+	if _, _, err := immutableTree.Commit(swap); err != nil {
+		t.Fatal(err)
+	}
+
+	swap.Pair(0, 1).AddOrder(helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(19)), types.Address{1}, 1)
+	swap.Pair(0, 1).AddOrder(helpers.BipToPip(big.NewInt(20)), helpers.BipToPip(big.NewInt(38)), types.Address{2}, 1)
+	swap.Pair(1, 0).AddOrder(helpers.BipToPip(big.NewInt(21)), helpers.BipToPip(big.NewInt(10)), types.Address{3}, 1)
+	if _, _, err := immutableTree.Commit(swap); err != nil {
+		t.Fatal(err)
+	}
+
+	depth := CalculateDepth(swap.Pair(0, 1), big.NewRat(1, 10), 3, 100)
+	if depth.Price.Cmp(big.NewRat(2, 1)) != 0 {
+		t.Fatalf("unexpected price %s", depth.Price.FloatString(2))
+	}
+	if len(depth.Bids) != 3 || len(depth.Asks) != 3 {
+		t.Fatalf("unexpected levels %d, %d", len(depth.Bids), len(depth.Asks))
+	}
+
+	bid := depth.Bids[1]
+	if bid.Price.Cmp(big.NewRat(19, 10)) != 0 || bid.Orders != 2 || bid.Volume0.Cmp(helpers.BipToPip(big.NewInt(30))) != 0 ||
+		bid.Volume1.Cmp(helpers.BipToPip(big.NewInt(57))) != 0 || bid.CumulativeVolume0.Cmp(bid.Volume0) != 0 {
+		t.Fatalf("unexpected bid level %#v", bid)
+	}
+	if depth.Bids[0].PoolVolume0.Sign() != 0 || bid.PoolVolume0.Sign() != 1 || depth.Bids[2].PoolVolume0.Cmp(bid.PoolVolume0) != 1 {
+		t.Fatal("pool volume should grow with the distance from the pool price")
+	}
+	if depth.Bids[2].CumulativeVolume0.Cmp(bid.CumulativeVolume0) != 0 {
+		t.Fatal("cumulative volume should include previous levels")
+	}
+
+	ask := depth.Asks[1]
+	if ask.Price.Cmp(big.NewRat(21, 10)) != 0 || ask.Orders != 1 || ask.Volume0.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 ||
+		ask.Volume1.Cmp(helpers.BipToPip(big.NewInt(21))) != 0 || ask.PoolVolume0.Sign() != 1 {
+		t.Fatalf("unexpected ask level %#v", ask)
+	}
+}
@@ -707,6 +707,10 @@ type limits struct {
 	ids []uint32
 }
 
+func (l *limits) copy() *limits {
+	return &limits{ids: append([]uint32(nil), l.ids...)}
+}
+
 type orderList struct {
 	mu   sync.RWMutex
 	list map[uint32]*Limit
@@ -717,6 +721,17 @@ type orderDirties struct {
 	list map[uint32]struct{}
 }
 
+func (d *orderDirties) copy() *orderDirties {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	list := make(map[uint32]struct{}, len(d.list))
+	for id := range d.list {
+		list[id] = struct{}{}
+	}
+	return &orderDirties{list: list}
+}
+
 const (
 	Precision = 53 // todo: 0 // supported precision
 )
@@ -520,6 +520,17 @@ func (pk PairKey) pathData() []byte {
 func (pk PairKey) pathOrders() []byte {
 	return append([]byte{pairOrdersPrefix}, pk.sort().bytes()...)
 }
+
+// PathPair returns the tree key of the pair reserves
+func PathPair(coin0, coin1 types.CoinID) []byte {
+	return append([]byte{mainPrefix}, PairKey{Coin0: coin0, Coin1: coin1}.sort().pathData()...)
+}
+
+// PathOrder returns the tree key of the limit order
+func PathOrder(id uint32) []byte {
+	return pathOrder(id)
+}
+
 func pathOrder(id uint32) []byte {
 	byteID := id2Bytes(id)
 	return append([]byte{pairLimitOrderPrefix}, byteID...)
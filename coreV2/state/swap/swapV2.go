@@ -48,6 +48,8 @@ type SwapV2 struct {
 	loadedPools bool
 
 	trader trader
+
+	parent *SwapV2
 }
 
 func (s *SwapV2) GetBestTradeExactIn(ctx context.Context, outId, inId uint64, inAmount *big.Int, maxHops int32) *Trade {
@@ -220,6 +222,15 @@ func (s *SwapV2) getOrderedDirtyPairs() []PairKey {
 	return keys
 }
 
+// DirtyPairs returns sorted keys of pools with changed reserves, which are not committed yet
+func (s *SwapV2) DirtyPairs() []PairKey {
+	s.muPairs.RLock()
+	keys := s.getOrderedDirtyPairs()
+	s.muPairs.RUnlock()
+
+	return keys
+}
+
 func (s *SwapV2) getOrderedDirtyOrderPairs() []PairKey {
 	keys := make([]PairKey, 0, len(s.dirtiesOrders))
 	for k := range s.dirtiesOrders {
@@ -526,6 +537,21 @@ func (s *SwapV2) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
 	s.db.Store(immutableTree)
 }
 
+// SetParent makes the swap the cache-wrapped copy of the parent reading the same tree:
+// pairs missing in the cache are copied with their orders from the cache of the parent,
+// changes are never written to the parent
+func (s *SwapV2) SetParent(parent *SwapV2) {
+	parent.muNextID.Lock()
+	s.nextID = parent.nextID
+	parent.muNextID.Unlock()
+
+	parent.muNextOrdersID.Lock()
+	s.nextOrderID = parent.nextOrderID
+	parent.muNextOrdersID.Unlock()
+
+	s.parent = parent
+}
+
 func (s *SwapV2) SwapPoolExist(coin0, coin1 types.CoinID) bool {
 	return s.Pair(coin0, coin1) != nil
 }
@@ -564,6 +590,24 @@ func (s *SwapV2) Pair(coin0, coin1 types.CoinID) *PairV2 {
 		return pair
 	}
 
+	if s.parent != nil {
+		s.parent.muPairs.RLock()
+		parentPair, ok := s.parent.pairs[key.sort()]
+		s.parent.muPairs.RUnlock()
+		if ok {
+			if parentPair == nil {
+				s.pairs[key.sort()] = nil
+				return nil
+			}
+
+			pair = s.copyPair(parentPair)
+			if !key.isSorted() {
+				return pair.reverse()
+			}
+			return pair
+		}
+	}
+
 	pathPair := append([]byte{mainPrefix}, key.sort().pathData()...)
 	_, data := s.immutableTree().Get(pathPair)
 	if len(data) == 0 {
@@ -757,6 +801,53 @@ func (s *SwapV2) addPair(key PairKey) *PairV2 {
 	return pair
 }
 
+// copyPair adds the deep copy of the sorted pair of the parent swap
+func (s *SwapV2) copyPair(parent *PairV2) *PairV2 {
+	key := parent.PairKey
+	parent.pairData.mu.RLock()
+	data := &pairData{
+		mu:        &sync.RWMutex{},
+		Reserve0:  new(big.Int).Set(parent.Reserve0),
+		Reserve1:  new(big.Int).Set(parent.Reserve1),
+		ID:        new(uint32),
+		markDirty: s.markDirty(key),
+	}
+	*data.ID = *parent.ID
+	parent.pairData.mu.RUnlock()
+
+	parent.orders.mu.RLock()
+	orders := &orderList{list: make(map[uint32]*Limit, len(parent.orders.list))}
+	for id, order := range parent.orders.list {
+		orders.list[id] = order.clone()
+	}
+	parent.orders.mu.RUnlock()
+
+	pair := &PairV2{
+		lockOrders:              &sync.Mutex{},
+		PairKey:                 key,
+		pairData:                data,
+		sellOrders:              parent.sellOrders.copy(),
+		buyOrders:               parent.buyOrders.copy(),
+		orders:                  orders,
+		dirtyOrders:             parent.dirtyOrders.copy(),
+		deletedSellOrders:       parent.deletedSellOrders.copy(),
+		deletedBuyOrders:        parent.deletedBuyOrders.copy(),
+		markDirtyOrders:         s.markDirtyOrders(key),
+		loadBuyOrders:           s.loadBuyOrders,
+		loadSellOrders:          s.loadSellOrders,
+		loadedSellOrders:        parent.loadedSellOrders.copy(),
+		loadedBuyOrders:         parent.loadedBuyOrders.copy(),
+		unsortedDirtyBuyOrders:  parent.unsortedDirtyBuyOrders.copy(),
+		unsortedDirtySellOrders: parent.unsortedDirtySellOrders.copy(),
+		getLastTotalOrderID:     s.incOrdersID,
+		loadOrder:               s.loadOrder,
+	}
+
+	s.pairs[key] = pair
+
+	return pair
+}
+
 func (s *SwapV2) incID() uint32 {
 	s.muNextID.Lock()
 	defer s.muNextID.Unlock()
@@ -0,0 +1,59 @@
+package vesting
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Model keeps vesting schedules of the address
+type Model struct {
+	Schedules []*Schedule
+
+	address types.Address
+}
+
+// Schedule locks the value in the balance of the address: nothing is spendable before the cliff height,
+// then the spendable part grows linearly from the start height to the end height,
+// per block or by Steps equal parts if Steps is not zero
+type Schedule struct {
+	Coin        types.CoinID
+	Value       *big.Int
+	StartHeight uint64
+	CliffHeight uint64
+	EndHeight   uint64
+	Steps       uint64
+}
+
+// Locked returns the part of the value which isn't spendable at the height
+func (s *Schedule) Locked(height uint64) *big.Int {
+	if height < s.CliffHeight || height <= s.StartHeight {
+		return big.NewInt(0).Set(s.Value)
+	}
+	if height >= s.EndHeight {
+		return big.NewInt(0)
+	}
+
+	elapsed, duration := height-s.StartHeight, s.EndHeight-s.StartHeight
+	vested := big.NewInt(0).Mul(s.Value, big.NewInt(0).SetUint64(elapsed))
+	vested.Quo(vested, big.NewInt(0).SetUint64(duration))
+	if s.Steps != 0 {
+		steps := big.NewInt(0).Mul(big.NewInt(0).SetUint64(elapsed), big.NewInt(0).SetUint64(s.Steps))
+		steps.Quo(steps, big.NewInt(0).SetUint64(duration))
+		vested.Mul(s.Value, steps)
+		vested.Quo(vested, big.NewInt(0).SetUint64(s.Steps))
+	}
+
+	return vested.Sub(s.Value, vested)
+}
+
+// Finished reports whether the whole value is spendable at the height
+func (s *Schedule) Finished(height uint64) bool {
+	return height >= s.EndHeight
+}
+
+// IsValid checks that heights are ordered and steps are not shorter than a block
+func (s *Schedule) IsValid() bool {
+	return s.StartHeight < s.EndHeight && s.StartHeight <= s.CliffHeight && s.CliffHeight <= s.EndHeight &&
+		s.Steps <= s.EndHeight-s.StartHeight && s.Value != nil && s.Value.Sign() == 1
+}
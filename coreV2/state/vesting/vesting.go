@@ -0,0 +1,230 @@
+package vesting
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('g')
+
+// MaxSchedules is the max number of unfinished vesting schedules of an address
+const MaxSchedules = 32
+
+type RVestings interface {
+	Export(state *types.AppState, height uint64)
+	GetSchedules(address types.Address) []*Schedule
+	GetLocked(address types.Address, coin types.CoinID, height uint64) *big.Int
+}
+
+// Vestings keeps vesting schedules by addresses, vested coins stay in account balances
+type Vestings struct {
+	list  map[types.Address]*Model
+	dirty map[types.Address]struct{}
+
+	bus *bus.Bus
+	db  atomic.Value
+
+	parent *Vestings
+
+	lock sync.RWMutex
+}
+
+func NewVestings(stateBus *bus.Bus, db *iavl.ImmutableTree) *Vestings {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Vestings{
+		bus:   stateBus,
+		db:    immutableTree,
+		list:  map[types.Address]*Model{},
+		dirty: map[types.Address]struct{}{},
+	}
+}
+
+func (v *Vestings) immutableTree() *iavl.ImmutableTree {
+	db := v.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (v *Vestings) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	v.db.Store(immutableTree)
+}
+
+// SetParent makes vestings the cache-wrapped copy of the parent reading the same tree:
+// schedules missing in the cache are copied from the cache of the parent, changes are never written to the parent
+func (v *Vestings) SetParent(parent *Vestings) {
+	v.parent = parent
+}
+
+func (v *Vestings) Commit(db *iavl.MutableTree, version int64) error {
+	for _, address := range v.getOrderedDirty() {
+		model := v.getFromMap(address)
+		path := getPath(address)
+
+		v.lock.Lock()
+		delete(v.dirty, address)
+		if len(model.Schedules) == 0 {
+			db.Remove(path)
+		} else {
+			data, err := rlp.EncodeToBytes(model)
+			if err != nil {
+				v.lock.Unlock()
+				return fmt.Errorf("can't encode object at %s: %v", address.String(), err)
+			}
+
+			db.Set(path, data)
+		}
+		v.lock.Unlock()
+	}
+
+	return nil
+}
+
+// GetSchedules returns vesting schedules of the address
+func (v *Vestings) GetSchedules(address types.Address) []*Schedule {
+	model := v.get(address)
+
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	return append([]*Schedule(nil), model.Schedules...)
+}
+
+// GetLocked returns the value of the coin which isn't spendable by the address at the height
+func (v *Vestings) GetLocked(address types.Address, coin types.CoinID, height uint64) *big.Int {
+	locked := big.NewInt(0)
+	for _, schedule := range v.GetSchedules(address) {
+		if schedule.Coin == coin {
+			locked.Add(locked, schedule.Locked(height))
+		}
+	}
+	return locked
+}
+
+// AddSchedule locks the value already added to the balance of the address by the schedule,
+// schedules finished by the start height are removed
+func (v *Vestings) AddSchedule(address types.Address, coin types.CoinID, value *big.Int, startHeight, cliffHeight, endHeight, steps uint64) {
+	model := v.get(address)
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	schedules := make([]*Schedule, 0, len(model.Schedules)+1)
+	for _, schedule := range model.Schedules {
+		if !schedule.Finished(startHeight) {
+			schedules = append(schedules, schedule)
+		}
+	}
+	model.Schedules = append(schedules, &Schedule{
+		Coin:        coin,
+		Value:       big.NewInt(0).Set(value),
+		StartHeight: startHeight,
+		CliffHeight: cliffHeight,
+		EndHeight:   endHeight,
+		Steps:       steps,
+	})
+	v.dirty[address] = struct{}{}
+}
+
+// Export adds schedules which aren't finished by the height
+func (v *Vestings) Export(state *types.AppState, height uint64) {
+	var addresses []types.Address
+	v.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		addresses = append(addresses, types.BytesToAddress(key[1:]))
+		return false
+	})
+
+	for _, address := range addresses {
+		for _, schedule := range v.GetSchedules(address) {
+			if schedule.Finished(height) {
+				continue
+			}
+			state.Vestings = append(state.Vestings, types.Vesting{
+				Address:     address,
+				Coin:        uint64(schedule.Coin),
+				Value:       schedule.Value.String(),
+				StartHeight: schedule.StartHeight,
+				CliffHeight: schedule.CliffHeight,
+				EndHeight:   schedule.EndHeight,
+				Steps:       schedule.Steps,
+			})
+		}
+	}
+}
+
+func (v *Vestings) get(address types.Address) *Model {
+	if model := v.getFromMap(address); model != nil {
+		return model
+	}
+
+	model := &Model{address: address}
+	if v.parent != nil {
+		// schedules are never changed in place, so copying the list is enough
+		if parentModel := v.parent.getFromMap(address); parentModel != nil {
+			v.parent.lock.RLock()
+			model.Schedules = append([]*Schedule(nil), parentModel.Schedules...)
+			v.parent.lock.RUnlock()
+			return v.setLoaded(model)
+		}
+	}
+
+	if _, enc := v.immutableTree().Get(getPath(address)); len(enc) != 0 {
+		if err := rlp.DecodeBytes(enc, model); err != nil {
+			panic(fmt.Sprintf("failed to decode vesting schedules of %s: %s", address.String(), err))
+		}
+	}
+
+	return v.setLoaded(model)
+}
+
+func (v *Vestings) setLoaded(model *Model) *Model {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	// the address may be loaded concurrently
+	if loaded, ok := v.list[model.address]; ok {
+		return loaded
+	}
+	v.list[model.address] = model
+
+	return model
+}
+
+func (v *Vestings) getFromMap(address types.Address) *Model {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+
+	return v.list[address]
+}
+
+func (v *Vestings) getOrderedDirty() []types.Address {
+	v.lock.RLock()
+	keys := make([]types.Address, 0, len(v.dirty))
+	for k := range v.dirty {
+		keys = append(keys, k)
+	}
+	v.lock.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == -1
+	})
+
+	return keys
+}
+
+func getPath(address types.Address) []byte {
+	return append([]byte{mainPrefix}, address.Bytes()...)
+}
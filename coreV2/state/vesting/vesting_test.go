@@ -0,0 +1,84 @@
+package vesting
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestSchedule_Locked(t *testing.T) {
+	t.Parallel()
+	linear := &Schedule{Value: big.NewInt(1000), StartHeight: 100, CliffHeight: 150, EndHeight: 200}
+	stepped := &Schedule{Value: big.NewInt(1000), StartHeight: 100, CliffHeight: 100, EndHeight: 200, Steps: 4}
+
+	tests := []struct {
+		schedule *Schedule
+		height   uint64
+		locked   int64
+	}{
+		{linear, 50, 1000},
+		{linear, 100, 1000},
+		{linear, 149, 1000},
+		{linear, 150, 500},
+		{linear, 175, 250},
+		{linear, 199, 10},
+		{linear, 200, 0},
+		{linear, 300, 0},
+		{stepped, 100, 1000},
+		{stepped, 124, 1000},
+		{stepped, 125, 750},
+		{stepped, 149, 750},
+		{stepped, 150, 500},
+		{stepped, 199, 250},
+		{stepped, 200, 0},
+	}
+	for _, test := range tests {
+		if locked := test.schedule.Locked(test.height); locked.Cmp(big.NewInt(test.locked)) != 0 {
+			t.Errorf("Locked at %d of %+v is %s, expected %d", test.height, test.schedule, locked, test.locked)
+		}
+	}
+}
+
+func TestVestings(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	v := NewVestings(b, mutableTree.GetLastImmutable())
+
+	address := types.Address{1}
+	v.AddSchedule(address, 0, big.NewInt(100), 10, 10, 20, 0)
+	v.AddSchedule(address, 1, big.NewInt(200), 10, 30, 40, 2)
+	v.AddSchedule(types.Address{2}, 0, big.NewInt(300), 10, 10, 20, 0)
+
+	if _, _, err := mutableTree.Commit(v); err != nil {
+		t.Fatal(err)
+	}
+
+	v = NewVestings(b, mutableTree.GetLastImmutable())
+	if schedules := v.GetSchedules(address); len(schedules) != 2 || schedules[1].Coin != 1 || schedules[1].Value.Cmp(big.NewInt(200)) != 0 || schedules[1].Steps != 2 {
+		t.Fatalf("Schedules are not correct: %+v", schedules)
+	}
+	if locked := v.GetLocked(address, 0, 15); locked.Cmp(big.NewInt(50)) != 0 {
+		t.Fatalf("Locked value is not correct: %s", locked)
+	}
+
+	// the finished schedule is removed by the next one
+	v.AddSchedule(address, 0, big.NewInt(400), 25, 25, 50, 0)
+	if schedules := v.GetSchedules(address); len(schedules) != 2 || schedules[0].Coin != 1 || schedules[1].Value.Cmp(big.NewInt(400)) != 0 {
+		t.Fatalf("Schedules are not correct: %+v", schedules)
+	}
+
+	if _, _, err := mutableTree.Commit(v); err != nil {
+		t.Fatal(err)
+	}
+
+	appState := &types.AppState{}
+	NewVestings(b, mutableTree.GetLastImmutable()).Export(appState, 20)
+	if len(appState.Vestings) != 2 || appState.Vestings[0].Address != address || appState.Vestings[1].Value != "400" {
+		t.Fatalf("Exported vestings are not correct: %+v", appState.Vestings)
+	}
+}
@@ -20,6 +20,23 @@ type Model struct {
 	lock      sync.RWMutex
 }
 
+// copy returns the deep copy of the model marking the given waitlist dirty
+func (m *Model) copy(markDirty func(address types.Address)) *Model {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	list := make([]*Item, 0, len(m.List))
+	for _, item := range m.List {
+		list = append(list, &Item{
+			CandidateId: item.CandidateId,
+			Coin:        item.Coin,
+			Value:       new(big.Int).Set(item.Value),
+		})
+	}
+
+	return &Model{List: list, address: m.address, markDirty: markDirty}
+}
+
 func (m *Model) AddToList(candidateId uint32, coin types.CoinID, value *big.Int) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -35,6 +35,8 @@ type WaitList struct {
 
 	bus *bus.Bus
 
+	parent *WaitList
+
 	lock sync.RWMutex
 }
 
@@ -66,6 +68,12 @@ func (wl *WaitList) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
 	wl.db.Store(immutableTree)
 }
 
+// SetParent makes the waitlist the cache-wrapped copy of the parent reading the same tree:
+// waitlists missing in the cache are copied from the cache of the parent, changes are never written to the parent
+func (wl *WaitList) SetParent(parent *WaitList) {
+	wl.parent = parent
+}
+
 func (wl *WaitList) Export(state *types.AppState) {
 	wl.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
 		address := types.BytesToAddress(key[1:])
@@ -104,7 +112,7 @@ func (wl *WaitList) ExportV1(state *types.AppState, droppedIDs []uint32, height
 			for _, w := range model.List {
 				if _, ok := dropped[w.CandidateId]; ok {
 					state.FrozenFunds = append(state.FrozenFunds, types.FrozenFund{
-						Height:       height + types.GetUnbondPeriod(),
+						Height:       height + wl.bus.Params().GetUnbondPeriod(),
 						CandidateID:  0,
 						CandidateKey: nil,
 						Address:      address,
@@ -258,11 +266,24 @@ func (wl *WaitList) getOrNew(address types.Address) *Model {
 	return w
 }
 
+// PathWaitList returns the tree key of the address waitlist
+func PathWaitList(address types.Address) []byte {
+	return append([]byte{mainPrefix}, address.Bytes()...)
+}
+
 func (wl *WaitList) get(address types.Address) *Model {
 	if ff := wl.getFromMap(address); ff != nil {
 		return ff
 	}
 
+	if wl.parent != nil {
+		if ff := wl.parent.getFromMap(address); ff != nil {
+			ff = ff.copy(wl.markDirty)
+			wl.setToMap(address, ff)
+			return ff
+		}
+	}
+
 	path := append([]byte{mainPrefix}, address.Bytes()...)
 	_, enc := wl.immutableTree().Get(path)
 	if len(enc) == 0 {
@@ -0,0 +1,315 @@
+package swaphistory
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+const (
+	swapPrefix     = 's'
+	reservesPrefix = 'r'
+
+	pricePrecision = 18
+)
+
+// Swap is an exchange through the pool executed by a transaction.
+// Coin0 is the pool coin with the lower id, the price is the amount of Coin1 per unit of Coin0.
+type Swap struct {
+	Height     uint64       `json:"height"`
+	Time       time.Time    `json:"time"`
+	Coin0      types.CoinID `json:"coin0"`
+	Coin1      types.CoinID `json:"coin1"`
+	Amount0In  *big.Int     `json:"amount0_in"`
+	Amount1In  *big.Int     `json:"amount1_in"`
+	Amount0Out *big.Int     `json:"amount0_out"`
+	Amount1Out *big.Int     `json:"amount1_out"`
+	Orders     int          `json:"orders"`
+}
+
+// Volume0 returns the traded amount of Coin0
+func (s *Swap) Volume0() *big.Int {
+	return new(big.Int).Add(s.Amount0In, s.Amount0Out)
+}
+
+// Volume1 returns the traded amount of Coin1
+func (s *Swap) Volume1() *big.Int {
+	return new(big.Int).Add(s.Amount1In, s.Amount1Out)
+}
+
+// Price returns the executed price, nil if the swap is empty
+func (s *Swap) Price() *big.Float {
+	volume0 := s.Volume0()
+	if volume0.Sign() == 0 {
+		return nil
+	}
+	price := new(big.Float).SetPrec(128).SetInt(s.Volume1())
+	return price.Quo(price, new(big.Float).SetPrec(128).SetInt(volume0))
+}
+
+// Reserves are reserves of the pool at the end of the block
+type Reserves struct {
+	Height   uint64       `json:"height"`
+	Time     time.Time    `json:"time"`
+	Coin0    types.CoinID `json:"coin0"`
+	Coin1    types.CoinID `json:"coin1"`
+	Reserve0 *big.Int     `json:"reserve0"`
+	Reserve1 *big.Int     `json:"reserve1"`
+}
+
+// Candle is the OHLCV candle of the pool, prices are amounts of Coin1 per unit of Coin0
+type Candle struct {
+	Time    time.Time
+	Open    string
+	High    string
+	Low     string
+	Close   string
+	Volume0 *big.Int
+	Volume1 *big.Int
+	Trades  int
+}
+
+// Stats is the trading volume of the pool over the period and its last reserves
+type Stats struct {
+	Reserves *Reserves
+	Volume0  *big.Int
+	Volume1  *big.Int
+	Trades   int
+}
+
+// ReservesFunc returns current reserves of the pool in the order of given coins
+type ReservesFunc func(coin0, coin1 types.CoinID) (reserve0, reserve1 *big.Int, id uint32)
+
+// History stores swaps executed through the pools
+type History struct {
+	lock      sync.Mutex
+	db        db.DB
+	blockTime time.Time
+	pending   []pendingSwap
+}
+
+type pendingSwap struct {
+	poolID uint32
+	swap   *Swap
+}
+
+// NewHistory creates the swap history in given DB
+func NewHistory(db db.DB) *History {
+	return &History{db: db}
+}
+
+// StartBlock sets the time of the block which transactions are added next
+func (h *History) StartBlock(blockTime time.Time) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.blockTime = blockTime
+}
+
+// AddSwaps adds exchanges through the pools of the current block transaction
+func (h *History) AddSwaps(changes []transaction.PoolChange) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for _, change := range changes {
+		swap := &Swap{
+			Time:       h.blockTime,
+			Coin0:      change.CoinIn,
+			Coin1:      change.CoinOut,
+			Amount0In:  change.ValueIn,
+			Amount1In:  big.NewInt(0),
+			Amount0Out: big.NewInt(0),
+			Amount1Out: change.ValueOut,
+			Orders:     len(change.Orders),
+		}
+		if swap.Coin0 > swap.Coin1 {
+			swap.Coin0, swap.Coin1 = swap.Coin1, swap.Coin0
+			swap.Amount0In, swap.Amount1In = swap.Amount1In, swap.Amount0In
+			swap.Amount0Out, swap.Amount1Out = swap.Amount1Out, swap.Amount0Out
+		}
+		h.pending = append(h.pending, pendingSwap{poolID: change.PoolID, swap: swap})
+	}
+}
+
+// Commit writes swaps added since the previous commit as the block of given height
+// and saves reserves of the pools they changed.
+func (h *History) Commit(height uint64, reserves ReservesFunc) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	batch := h.db.NewBatch()
+	defer batch.Close()
+
+	changed := map[uint32]*Swap{}
+	for i, pending := range h.pending {
+		poolID, swap := pending.poolID, pending.swap
+		swap.Height = height
+		value, err := json.Marshal(swap)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(swapKey(poolID, swap.Time, height, uint32(i)), value); err != nil {
+			return err
+		}
+		changed[poolID] = swap
+	}
+
+	for poolID, swap := range changed {
+		reserve0, reserve1, _ := reserves(swap.Coin0, swap.Coin1)
+		if reserve0 == nil || reserve1 == nil {
+			continue
+		}
+		value, err := json.Marshal(&Reserves{
+			Height:   height,
+			Time:     h.blockTime,
+			Coin0:    swap.Coin0,
+			Coin1:    swap.Coin1,
+			Reserve0: reserve0,
+			Reserve1: reserve1,
+		})
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(reservesKey(poolID), value); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	h.pending = nil
+	return nil
+}
+
+// Swaps returns swaps of the pool executed in the time range [from, to)
+func (h *History) Swaps(poolID uint32, from, to time.Time) ([]*Swap, error) {
+	it, err := h.db.Iterator(swapKey(poolID, from, 0, 0), swapKey(poolID, to, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var swaps []*Swap
+	for ; it.Valid(); it.Next() {
+		swap := &Swap{}
+		if err := json.Unmarshal(it.Value(), swap); err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, swap)
+	}
+
+	return swaps, it.Error()
+}
+
+// Candles returns candles of the pool of given interval in the time range [from, to).
+// Intervals without swaps are omitted.
+func (h *History) Candles(poolID uint32, interval time.Duration, from, to time.Time) ([]*Candle, error) {
+	if interval <= 0 {
+		return nil, errors.New("interval should be positive")
+	}
+
+	swaps, err := h.Swaps(poolID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var candles []*Candle
+	var candle *Candle
+	var high, low *big.Float
+	for _, swap := range swaps {
+		price := swap.Price()
+		if price == nil {
+			continue
+		}
+
+		start := from.Add(swap.Time.Sub(from) / interval * interval)
+		if candle == nil || !candle.Time.Equal(start) {
+			candle = &Candle{
+				Time:    start,
+				Open:    price.Text('f', pricePrecision),
+				Volume0: big.NewInt(0),
+				Volume1: big.NewInt(0),
+			}
+			candles = append(candles, candle)
+			high, low = price, price
+		}
+
+		if price.Cmp(high) > 0 {
+			high = price
+		}
+		if price.Cmp(low) < 0 {
+			low = price
+		}
+		candle.High = high.Text('f', pricePrecision)
+		candle.Low = low.Text('f', pricePrecision)
+		candle.Close = price.Text('f', pricePrecision)
+		candle.Volume0.Add(candle.Volume0, swap.Volume0())
+		candle.Volume1.Add(candle.Volume1, swap.Volume1())
+		candle.Trades++
+	}
+
+	return candles, nil
+}
+
+// Stats returns the volume of the pool in the period before the latest block and its last reserves.
+// Returns nil if the pool has no swaps.
+func (h *History) Stats(poolID uint32, period time.Duration) (*Stats, error) {
+	value, err := h.db.Get(reservesKey(poolID))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	reserves := &Reserves{}
+	if err := json.Unmarshal(value, reserves); err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	last := h.blockTime
+	h.lock.Unlock()
+	if last.Before(reserves.Time) {
+		last = reserves.Time
+	}
+
+	swaps, err := h.Swaps(poolID, last.Add(-period), last.Add(time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{Reserves: reserves, Volume0: big.NewInt(0), Volume1: big.NewInt(0), Trades: len(swaps)}
+	for _, swap := range swaps {
+		stats.Volume0.Add(stats.Volume0, swap.Volume0())
+		stats.Volume1.Add(stats.Volume1, swap.Volume1())
+	}
+
+	return stats, nil
+}
+
+func swapKey(poolID uint32, t time.Time, height uint64, index uint32) []byte {
+	key := make([]byte, 1+4+8+8+4)
+	key[0] = swapPrefix
+	binary.BigEndian.PutUint32(key[1:], poolID)
+	binary.BigEndian.PutUint64(key[5:], uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(key[13:], height)
+	binary.BigEndian.PutUint32(key[21:], index)
+	return key
+}
+
+func reservesKey(poolID uint32) []byte {
+	key := make([]byte, 1+4)
+	key[0] = reservesPrefix
+	binary.BigEndian.PutUint32(key[1:], poolID)
+	return key
+}
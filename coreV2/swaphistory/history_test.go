@@ -0,0 +1,67 @@
+package swaphistory
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestHistory_Candles(t *testing.T) {
+	history := NewHistory(db.NewMemDB())
+	reserves := func(coin0, coin1 types.CoinID) (*big.Int, *big.Int, uint32) {
+		return big.NewInt(1000), big.NewInt(2000), 1
+	}
+	start := time.Unix(1600000000, 0).UTC()
+
+	history.StartBlock(start)
+	history.AddSwaps([]transaction.PoolChange{
+		{PoolID: 1, CoinIn: 0, ValueIn: big.NewInt(10), CoinOut: 1, ValueOut: big.NewInt(20)},
+		{PoolID: 1, CoinIn: 1, ValueIn: big.NewInt(30), CoinOut: 0, ValueOut: big.NewInt(10)},
+	})
+	if err := history.Commit(1, reserves); err != nil {
+		t.Fatal(err)
+	}
+
+	history.StartBlock(start.Add(90 * time.Second))
+	history.AddSwaps([]transaction.PoolChange{
+		{PoolID: 1, CoinIn: 0, ValueIn: big.NewInt(10), CoinOut: 1, ValueOut: big.NewInt(25), Orders: []transaction.FilledOrder{{}}},
+		{PoolID: 2, CoinIn: 0, ValueIn: big.NewInt(1), CoinOut: 2, ValueOut: big.NewInt(1)},
+	})
+	if err := history.Commit(2, reserves); err != nil {
+		t.Fatal(err)
+	}
+
+	candles, err := history.Candles(1, time.Minute, start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 2 {
+		t.Fatalf("expected 2 candles, got %d", len(candles))
+	}
+
+	first := candles[0]
+	if !first.Time.Equal(start) || first.Open != "2.000000000000000000" || first.High != "3.000000000000000000" ||
+		first.Low != "2.000000000000000000" || first.Close != "3.000000000000000000" ||
+		first.Volume0.Int64() != 20 || first.Volume1.Int64() != 50 || first.Trades != 2 {
+		t.Fatalf("unexpected first candle %#v", first)
+	}
+	if second := candles[1]; !second.Time.Equal(start.Add(time.Minute)) || second.Open != "2.500000000000000000" || second.Trades != 1 {
+		t.Fatalf("unexpected second candle %#v", second)
+	}
+
+	stats, err := history.Stats(1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats == nil || stats.Trades != 1 || stats.Volume0.Int64() != 10 || stats.Reserves.Height != 2 || stats.Reserves.Reserve1.Int64() != 2000 {
+		t.Fatalf("unexpected stats %#v", stats)
+	}
+
+	if stats, err := history.Stats(3, time.Minute); err != nil || stats != nil {
+		t.Fatalf("unexpected stats of unknown pool %#v, %v", stats, err)
+	}
+}
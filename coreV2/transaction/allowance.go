@@ -0,0 +1,407 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// ApproveData sets the value of the coin which the spender can transfer from the balance of the sender,
+// the previous allowance of the spender is replaced
+type ApproveData struct {
+	Spender types.Address
+	Coin    types.CoinID
+	Value   *big.Int
+}
+
+func (data ApproveData) TxType() TxType {
+	return TypeApprove
+}
+
+func (data ApproveData) Gas() int64 {
+	return gasApprove
+}
+
+func (data ApproveData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	sender, _ := tx.Sender()
+	if data.Spender == sender {
+		return &Response{
+			Code: code.WrongAllowance,
+			Log:  "Spender should differ from the sender",
+			Info: EncodeError(code.NewCustomCode(code.WrongAllowance)),
+		}
+	}
+
+	if data.Value == nil || data.Value.Sign() != 1 {
+		return &Response{
+			Code: code.WrongAllowance,
+			Log:  "Allowance should be positive",
+			Info: EncodeError(code.NewCustomCode(code.WrongAllowance)),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data ApproveData) String() string {
+	return fmt.Sprintf("APPROVE spender:%s coin:%s value:%s",
+		data.Spender.String(), data.Coin.String(), data.Value.String())
+}
+
+func (data ApproveData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data ApproveData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Allowances.SetAllowance(sender, data.Spender, data.Coin, data.Value)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.spender"), Value: []byte(hex.EncodeToString(data.Spender[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// RevokeData removes the allowance of the spender given by the sender
+type RevokeData struct {
+	Spender types.Address
+	Coin    types.CoinID
+}
+
+func (data RevokeData) TxType() TxType {
+	return TypeRevoke
+}
+
+func (data RevokeData) Gas() int64 {
+	return gasRevoke
+}
+
+func (data RevokeData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	sender, _ := tx.Sender()
+	if context.Allowances().GetAllowance(sender, data.Spender, data.Coin).Sign() == 0 {
+		return &Response{
+			Code: code.AllowanceNotExists,
+			Log:  "Allowance not exists",
+			Info: EncodeError(code.NewAllowanceNotExists(sender.String(), data.Spender.String(), data.Coin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data RevokeData) String() string {
+	return fmt.Sprintf("REVOKE spender:%s coin:%s",
+		data.Spender.String(), data.Coin.String())
+}
+
+func (data RevokeData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data RevokeData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Allowances.SetAllowance(sender, data.Spender, data.Coin, big.NewInt(0))
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.spender"), Value: []byte(hex.EncodeToString(data.Spender[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// TransferFromData transfers the value from the balance of the owner to the recipient within the allowance
+// given to the sender, the sender pays the commission
+type TransferFromData struct {
+	From  types.Address
+	To    types.Address
+	Coin  types.CoinID
+	Value *big.Int
+}
+
+func (data TransferFromData) TxType() TxType {
+	return TypeTransferFrom
+}
+
+func (data TransferFromData) Gas() int64 {
+	return gasTransferFrom
+}
+
+func (data TransferFromData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 {
+		return &Response{
+			Code: code.WrongAllowance,
+			Log:  "Value should be positive",
+			Info: EncodeError(code.NewCustomCode(code.WrongAllowance)),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	allowance := context.Allowances().GetAllowance(data.From, sender, data.Coin)
+	if allowance.Sign() == 0 {
+		return &Response{
+			Code: code.AllowanceNotExists,
+			Log:  "Allowance not exists",
+			Info: EncodeError(code.NewAllowanceNotExists(data.From.String(), sender.String(), data.Coin.String())),
+		}
+	}
+
+	if allowance.Cmp(data.Value) < 0 {
+		return &Response{
+			Code: code.InsufficientAllowance,
+			Log:  fmt.Sprintf("Insufficient allowance of %s. Allowed %s, wanted %s", data.From.String(), allowance.String(), data.Value.String()),
+			Info: EncodeError(code.NewInsufficientAllowance(data.From.String(), sender.String(), data.Coin.String(), allowance.String(), data.Value.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data TransferFromData) String() string {
+	return fmt.Sprintf("TRANSFER FROM from:%s to:%s coin:%s value:%s",
+		data.From.String(), data.To.String(), data.Coin.String(), data.Value.String())
+}
+
+func (data TransferFromData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data TransferFromData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(data.From, data.Coin).Cmp(data.Value) < 0 {
+		coin := checkState.Coins().GetCoin(data.Coin)
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for owner account: %s. Wanted %s %s", data.From.String(), data.Value.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(data.From.String(), data.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Allowances.SubAllowance(data.From, sender, data.Coin, data.Value)
+		deliverState.Accounts.SubBalance(data.From, data.Coin, data.Value)
+		deliverState.Accounts.AddBalance(data.To, data.Coin, data.Value)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.owner"), Value: []byte(hex.EncodeToString(data.From[:])), Index: true},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.To[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,115 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestAllowanceTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	ownerKey, _ := crypto.GenerateKey()
+	owner := crypto.PubkeyToAddress(ownerKey.PublicKey)
+	coin := createTestCoin(cState)
+	cState.Accounts.SubBalance(types.Address{}, coin, helpers.BipToPip(big.NewInt(100)))
+	cState.Accounts.AddBalance(owner, coin, helpers.BipToPip(big.NewInt(100)))
+	cState.Accounts.AddBalance(owner, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	cState.Accounts.AddBalance(spender, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	encodedTx := signedTestTx(t, ownerKey, 1, TypeApprove, ApproveData{Spender: spender, Coin: coin, Value: helpers.BipToPip(big.NewInt(30))})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	to := types.Address{1}
+	encodedTx = signedTestTx(t, spenderKey, 1, TypeTransferFrom, TransferFromData{From: owner, To: to, Coin: coin, Value: helpers.BipToPip(big.NewInt(20))})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(owner, coin); balance.Cmp(helpers.BipToPip(big.NewInt(80))) != 0 {
+		t.Errorf("Owner balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(80)), balance)
+	}
+	if balance := cState.Accounts.GetBalance(to, coin); balance.Cmp(helpers.BipToPip(big.NewInt(20))) != 0 {
+		t.Errorf("Recipient balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(20)), balance)
+	}
+	if allowance := cState.Allowances.GetAllowance(owner, spender, coin); allowance.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Errorf("Allowance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(10)), allowance)
+	}
+
+	encodedTx = signedTestTx(t, spenderKey, 2, TypeTransferFrom, TransferFromData{From: owner, To: to, Coin: coin, Value: helpers.BipToPip(big.NewInt(20))})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 3, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientAllowance {
+		t.Fatalf("Response code is not %d. Error: %s", code.InsufficientAllowance, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, spenderKey, 2, TypeTransferFrom, TransferFromData{From: owner, To: to, Coin: coin, Value: big.NewInt(0)})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 3, &sync.Map{}, 0, false)
+	if response.Code != code.WrongAllowance {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongAllowance, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, ownerKey, 2, TypeRevoke, RevokeData{Spender: spender, Coin: coin})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 3, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	encodedTx = signedTestTx(t, spenderKey, 2, TypeTransferFrom, TransferFromData{From: owner, To: to, Coin: coin, Value: helpers.BipToPip(big.NewInt(5))})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 4, &sync.Map{}, 0, false)
+	if response.Code != code.AllowanceNotExists {
+		t.Fatalf("Response code is not %d. Error: %s", code.AllowanceNotExists, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAllowanceTxInsufficientOwnerFunds(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	ownerKey, _ := crypto.GenerateKey()
+	owner := crypto.PubkeyToAddress(ownerKey.PublicKey)
+	cState.Accounts.AddBalance(owner, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	cState.Accounts.AddBalance(spender, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	encodedTx := signedTestTx(t, ownerKey, 1, TypeApprove, ApproveData{Spender: owner, Coin: types.GetBaseCoinID(), Value: helpers.BipToPip(big.NewInt(10))})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongAllowance {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongAllowance, response.Log)
+	}
+
+	value := helpers.BipToPip(big.NewInt(2000000))
+	encodedTx = signedTestTx(t, ownerKey, 1, TypeApprove, ApproveData{Spender: spender, Coin: types.GetBaseCoinID(), Value: value})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	encodedTx = signedTestTx(t, spenderKey, 1, TypeTransferFrom, TransferFromData{From: owner, To: spender, Coin: types.GetBaseCoinID(), Value: value})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error: %s", code.InsufficientFunds, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
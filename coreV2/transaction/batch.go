@@ -0,0 +1,241 @@
+package transaction
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+const (
+	maxBatchSize = 10
+	// batchCommissionDiscount is the discount in percent of the sum of commissions of transactions of the batch
+	batchCommissionDiscount = 10
+)
+
+// BatchData is the list of transactions executed one by one on behalf of the sender of the batch.
+// If any of them fails, the whole batch fails and none of them is applied.
+type BatchData struct {
+	Txs []BatchTx
+}
+
+// BatchTx is the type and the encoded data of a transaction of the batch
+type BatchTx struct {
+	Type TxType
+	Data RawData
+}
+
+func (data BatchData) TxType() TxType {
+	return TypeBatch
+}
+
+func (data BatchData) Gas() int64 {
+	gas := int64(gasBatch)
+	txs, _ := data.DecodedTxs()
+	for _, d := range txs {
+		gas += d.Gas()
+	}
+	return gas
+}
+
+// DecodedTxs returns the decoded data of transactions of the batch
+func (data BatchData) DecodedTxs() ([]Data, error) {
+	txs := make([]Data, 0, len(data.Txs))
+	for i, batchTx := range data.Txs {
+		switch batchTx.Type {
+		case TypeSend, TypeSellSwapPool, TypeBuySwapPool, TypeSellAllSwapPool, TypeAddLiquidity, TypeDelegate, TypeAddLimitOrder:
+		default:
+			return nil, fmt.Errorf("transaction %d of type %s can't be batched", i, batchTx.Type)
+		}
+
+		d, _ := GetDataV3(batchTx.Type)
+		if err := rlp.DecodeBytes(batchTx.Data, d); err != nil {
+			return nil, fmt.Errorf("transaction %d: %s", i, err)
+		}
+		txs = append(txs, d)
+	}
+	return txs, nil
+}
+
+func (data BatchData) basicCheck() ([]Data, *Response) {
+	if len(data.Txs) == 0 || len(data.Txs) > maxBatchSize {
+		return nil, &Response{
+			Code: code.WrongBatch,
+			Log:  fmt.Sprintf("Batch should contain from 1 to %d transactions", maxBatchSize),
+			Info: EncodeError(code.NewCustomCode(code.WrongBatch)),
+		}
+	}
+
+	txs, err := data.DecodedTxs()
+	if err != nil {
+		return nil, &Response{
+			Code: code.WrongBatch,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewCustomCode(code.WrongBatch)),
+		}
+	}
+
+	return txs, nil
+}
+
+func (data BatchData) String() string {
+	return fmt.Sprintf("BATCH txs:%d", len(data.Txs))
+}
+
+func (data BatchData) CommissionData(price *commission.Price) *big.Int {
+	sum := big.NewInt(0)
+	txs, _ := data.DecodedTxs()
+	for _, d := range txs {
+		sum.Add(sum, d.CommissionData(price))
+	}
+	sum.Mul(sum, big.NewInt(100-batchCommissionDiscount))
+	return sum.Div(sum, big.NewInt(100))
+}
+
+func (data BatchData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	txs, response := data.basicCheck()
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	// The batch is tried on a fork of the state first, so the state is changed only if all transactions succeed.
+	// The fork of the deliver state copies its uncommitted changes on access without touching the state or its tree,
+	// transactions are checked on the fork of the last committed state.
+	// The deliver state without a tree is a throwaway simulation state itself.
+	deliverState, isDeliver := context.(*state.State)
+	if !isDeliver || deliverState.Tree() != nil {
+		var fork *state.State
+		var err error
+		if isDeliver {
+			fork, err = deliverState.Fork(hasBatchDelegate(txs))
+		} else {
+			fork, err = checkState.Fork(hasBatchDelegate(txs))
+		}
+		if err != nil {
+			return Response{
+				Code: code.Unavailable,
+				Log:  fmt.Sprintf("Batch can't be checked: %s", err),
+				Info: EncodeError(code.NewCustomCode(code.Unavailable)),
+			}
+		}
+		if _, _, _, response := data.execute(tx, txs, fork, commission, commissionInBaseCoin, isGasCommissionFromPoolSwap, big.NewInt(0), currentBlock); response != nil {
+			return *response
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if isDeliver {
+		commissionInBaseCoin, tagsCom, txsTags, response := data.execute(tx, txs, deliverState, commission, commissionInBaseCoin, isGasCommissionFromPoolSwap, rewardPool, currentBlock)
+		if response != nil {
+			return *response
+		}
+
+		tags = append([]abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+		}, txsTags...)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// hasBatchDelegate returns whether the batch delegates to candidates, which are loaded to the fork only in this case
+func hasBatchDelegate(txs []Data) bool {
+	for _, d := range txs {
+		if d.TxType() == TypeDelegate {
+			return true
+		}
+	}
+	return false
+}
+
+// execute charges the commission of the batch and runs its transactions one by one against the deliver state,
+// transactions of the batch pay no commissions of their own
+func (data BatchData) execute(tx *Transaction, txs []Data, deliverState *state.State, commission, commissionInBaseCoin *big.Int, isGasCommissionFromPoolSwap gasMethod, rewardPool *big.Int, currentBlock uint64) (*big.Int, *tagPoolChange, []abcTypes.EventAttribute, *Response) {
+	sender, _ := tx.Sender()
+
+	var tagsCom *tagPoolChange
+	if isGasCommissionFromPoolSwap {
+		var (
+			poolIDCom  uint32
+			detailsCom *swap.ChangeDetailsWithOrders
+			ownersCom  []*swap.OrderDetail
+		)
+		commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+		tagsCom = &tagPoolChange{
+			PoolID:   poolIDCom,
+			CoinIn:   tx.CommissionCoin(),
+			ValueIn:  commission.String(),
+			CoinOut:  types.GetBaseCoinID(),
+			ValueOut: commissionInBaseCoin.String(),
+			Orders:   detailsCom,
+		}
+		for _, value := range ownersCom {
+			deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+		}
+	} else if !tx.GasCoin.IsBaseCoin() {
+		deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+		deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+	}
+	deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+	rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+	var tags []abcTypes.EventAttribute
+	for i, d := range txs {
+		batchTx := *tx
+		batchTx.Type = d.TxType()
+		batchTx.decodedData = d
+		batchTx.sender = &sender
+
+		txResponse := d.Run(&batchTx, deliverState, rewardPool, currentBlock, big.NewInt(0))
+		if txResponse.Code != code.OK {
+			txResponse.Log = fmt.Sprintf("Transaction %d of the batch failed: %s", i, txResponse.Log)
+			return nil, nil, nil, &txResponse
+		}
+
+		for _, tag := range txResponse.Tags {
+			if !bytes.HasPrefix(tag.Key, []byte("tx.commission_")) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+	return commissionInBaseCoin, tagsCom, tags, nil
+}
@@ -0,0 +1,323 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func signedTestTx(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64, txType TxType, data interface{}) []byte {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encodedTx
+}
+
+func batchData(t *testing.T, txs ...Data) BatchData {
+	var data BatchData
+	for _, d := range txs {
+		encodedData, err := rlp.EncodeToBytes(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data.Txs = append(data.Txs, BatchTx{Type: d.TxType(), Data: encodedData})
+	}
+	return data
+}
+
+// createBatchTestPool creates the pool of the base coin and a new token by the sender with the first nonce
+func createBatchTestPool(t *testing.T, cState *state.State, privateKey *ecdsa.PrivateKey) types.CoinID {
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+	coin := createNonReserveCoin(cState)
+	cState.Accounts.SubBalance(types.Address{}, coin, helpers.BipToPip(big.NewInt(10000)))
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(10000)))
+
+	encodedTx := signedTestTx(t, privateKey, 1, TypeCreateSwapPool, CreateSwapPoolData{
+		Coin0:   types.GetBaseCoinID(),
+		Volume0: helpers.BipToPip(big.NewInt(10000)),
+		Coin1:   coin,
+		Volume1: helpers.BipToPip(big.NewInt(10000)),
+	})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+	return coin
+}
+
+func TestBatchTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := createBatchTestPool(t, cState, privateKey)
+	initialBalance := cState.Accounts.GetBalance(addr, types.GetBaseCoinID())
+
+	to := types.Address{2}
+	encodedTx := signedTestTx(t, privateKey, 2, TypeBatch, batchData(t,
+		&SellSwapPoolDataV260{
+			Coins:             []types.CoinID{types.GetBaseCoinID(), coin},
+			ValueToSell:       helpers.BipToPip(big.NewInt(100)),
+			MinimumValueToBuy: big.NewInt(1),
+		},
+		&SendData{
+			Coin:  coin,
+			To:    to,
+			Value: helpers.BipToPip(big.NewInt(90)),
+		},
+	))
+
+	rewards := big.NewInt(0)
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, rewards, 0, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(to, coin); balance.Cmp(helpers.BipToPip(big.NewInt(90))) != 0 {
+		t.Errorf("Recipient balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(90)), balance)
+	}
+
+	commissions := cState.Commission.GetCommissions()
+	discounted := big.NewInt(0).Add(commissions.SellPoolBase, commissions.Send)
+	discounted.Mul(discounted, big.NewInt(100-batchCommissionDiscount))
+	discounted.Div(discounted, big.NewInt(100))
+	if rewards.Cmp(discounted) != 0 {
+		t.Errorf("Commission is not correct. Expected %s, got %s", discounted, rewards)
+	}
+
+	expectedBalance := big.NewInt(0).Sub(initialBalance, helpers.BipToPip(big.NewInt(100)))
+	expectedBalance.Sub(expectedBalance, rewards)
+	if balance := cState.Accounts.GetBalance(addr, types.GetBaseCoinID()); balance.Cmp(expectedBalance) != 0 {
+		t.Errorf("Sender balance is not correct. Expected %s, got %s", expectedBalance, balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchTxRevert(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := createBatchTestPool(t, cState, privateKey)
+
+	reserve0, reserve1, _ := cState.Swap.SwapPool(types.GetBaseCoinID(), coin)
+
+	// the swap succeeds, but its result is not enough for the send
+	encodedTx := signedTestTx(t, privateKey, 2, TypeBatch, batchData(t,
+		&SellSwapPoolDataV260{
+			Coins:             []types.CoinID{types.GetBaseCoinID(), coin},
+			ValueToSell:       helpers.BipToPip(big.NewInt(100)),
+			MinimumValueToBuy: big.NewInt(1),
+		},
+		&SendData{
+			Coin:  coin,
+			To:    types.Address{2},
+			Value: helpers.BipToPip(big.NewInt(1000)),
+		},
+	))
+
+	for _, context := range []state.Interface{state.NewCheckState(cState), cState} {
+		response := NewExecutorV340(GetDataV340).RunTx(context, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+		if response.Code != code.InsufficientFunds {
+			t.Fatalf("Response code is not %d. Error: %s", code.InsufficientFunds, response.Log)
+		}
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Sign() != 0 {
+		t.Errorf("Swap of the failed batch is applied, balance %s", balance)
+	}
+	if newReserve0, newReserve1, _ := cState.Swap.SwapPool(types.GetBaseCoinID(), coin); newReserve0.Cmp(reserve0) != 0 || newReserve1.Cmp(reserve1) != 0 {
+		t.Errorf("Reserves of the pool are changed by the failed batch")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchTxRevertUncommitted(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := createBatchTestPool(t, cState, privateKey)
+	cState.Accounts.SubBalance(types.Address{}, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	// the uncommitted send leaves 400 coins, the committed state has enough coins for the batch
+	response := NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey, 2, TypeSend, SendData{
+		Coin:  coin,
+		To:    types.Address{3},
+		Value: helpers.BipToPip(big.NewInt(600)),
+	}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	reserve0, reserve1, _ := cState.Swap.SwapPool(types.GetBaseCoinID(), coin)
+
+	batchTx := func(nonce uint64, value int64) []byte {
+		return signedTestTx(t, privateKey, nonce, TypeBatch, batchData(t,
+			&SellSwapPoolDataV260{
+				Coins:             []types.CoinID{types.GetBaseCoinID(), coin},
+				ValueToSell:       helpers.BipToPip(big.NewInt(100)),
+				MinimumValueToBuy: big.NewInt(1),
+			},
+			&SendData{
+				Coin:  coin,
+				To:    types.Address{2},
+				Value: helpers.BipToPip(big.NewInt(value)),
+			},
+		))
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, batchTx(3, 500), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error: %s", code.InsufficientFunds, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(helpers.BipToPip(big.NewInt(400))) != 0 {
+		t.Errorf("Swap of the failed batch is applied, balance %s", balance)
+	}
+	if newReserve0, newReserve1, _ := cState.Swap.SwapPool(types.GetBaseCoinID(), coin); newReserve0.Cmp(reserve0) != 0 || newReserve1.Cmp(reserve1) != 0 {
+		t.Errorf("Reserves of the pool are changed by the failed batch")
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, batchTx(cState.Accounts.GetNonce(addr)+1, 450), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	if balance := cState.Accounts.GetBalance(types.Address{3}, coin); balance.Cmp(helpers.BipToPip(big.NewInt(600))) != 0 {
+		t.Errorf("Uncommitted send is lost, balance %s", balance)
+	}
+	if balance := cState.Accounts.GetBalance(types.Address{2}, coin); balance.Cmp(helpers.BipToPip(big.NewInt(450))) != 0 {
+		t.Errorf("Send of the batch is not applied, balance %s", balance)
+	}
+}
+
+func TestBatchTxWrongType(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	encodedTx := signedTestTx(t, privateKey, 1, TypeBatch, batchData(t, &LockData{
+		DueBlock: 100,
+		Coin:     types.GetBaseCoinID(),
+		Value:    big.NewInt(1),
+	}))
+
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 0, &sync.Map{}, 0, false)
+	if response.Code != code.WrongBatch {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongBatch, response.Log)
+	}
+}
+
+func TestBatchTxOrderEvents(t *testing.T) {
+	t.Parallel()
+	events := &eventsdb.MockEvents{}
+	cState := getState(events)
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := createBatchTestPool(t, cState, privateKey)
+
+	encodedTx := signedTestTx(t, privateKey, 2, TypeBatch, batchData(t,
+		&SendData{
+			Coin:  types.GetBaseCoinID(),
+			To:    types.Address{2},
+			Value: helpers.BipToPip(big.NewInt(1)),
+		},
+		&AddLimitOrderData{
+			CoinToSell:  types.GetBaseCoinID(),
+			ValueToSell: helpers.BipToPip(big.NewInt(10)),
+			CoinToBuy:   coin,
+			ValueToBuy:  helpers.BipToPip(big.NewInt(20)),
+		},
+		&AddLimitOrderData{
+			CoinToSell:  types.GetBaseCoinID(),
+			ValueToSell: helpers.BipToPip(big.NewInt(10)),
+			CoinToBuy:   coin,
+			ValueToBuy:  helpers.BipToPip(big.NewInt(30)),
+		},
+	))
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	var created []*eventsdb.OrderCreatedEvent
+	for _, event := range events.LoadEvents(1) {
+		if e, ok := event.(*eventsdb.OrderCreatedEvent); ok {
+			created = append(created, e)
+		}
+	}
+	if len(created) != 2 {
+		t.Fatalf("Expected 2 created orders, got %d", len(created))
+	}
+	if created[0].Address != addr || created[0].ValueBuy != helpers.BipToPip(big.NewInt(20)).String() ||
+		created[1].ValueBuy != helpers.BipToPip(big.NewInt(30)).String() || created[0].ID == created[1].ID {
+		t.Fatalf("Created orders are not correct: %+v %+v", created[0], created[1])
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range created {
+		if order := cState.Swap.GetOrder(uint32(e.ID)); order == nil || order.Owner != addr {
+			t.Fatalf("Order %d of the event is not found", e.ID)
+		}
+	}
+}
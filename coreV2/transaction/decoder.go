@@ -79,7 +79,7 @@ func GetDataV1(txType TxType) (Data, bool) {
 }
 
 func GetData(txType TxType) (Data, bool) {
-	return GetDataV3(txType)
+	return GetDataV340(txType)
 }
 
 func GetDataV260(txType TxType) (Data, bool) {
@@ -108,6 +108,48 @@ func GetDataV260(txType TxType) (Data, bool) {
 		return GetDataV250(txType)
 	}
 }
+func GetDataV340(txType TxType) (Data, bool) {
+	switch txType {
+	case TypeBatch:
+		return &BatchData{}, true
+	case TypeCreateHTLC:
+		return &CreateHTLCData{}, true
+	case TypeClaimHTLC:
+		return &ClaimHTLCData{}, true
+	case TypeRefundHTLC:
+		return &RefundHTLCData{}, true
+	case TypeVesting:
+		return &VestingData{}, true
+	case TypeApprove:
+		return &ApproveData{}, true
+	case TypeRevoke:
+		return &RevokeData{}, true
+	case TypeTransferFrom:
+		return &TransferFromData{}, true
+	case TypeCreateRecurringPayment:
+		return &CreateRecurringPaymentData{}, true
+	case TypeCancelRecurringPayment:
+		return &CancelRecurringPaymentData{}, true
+	case TypeCreateMultisigProposal:
+		return &CreateMultisigProposalData{}, true
+	case TypeApproveMultisigProposal:
+		return &ApproveMultisigProposalData{}, true
+	case TypeExecuteMultisigProposal:
+		return &ExecuteMultisigProposalData{}, true
+	case TypeCancelMultisigProposal:
+		return &CancelMultisigProposalData{}, true
+	case TypeRedeemChecks:
+		return &RedeemChecksData{}, true
+	case TypeCancelCheck:
+		return &CancelCheckData{}, true
+	case TypeProposeParams:
+		return &ProposeParamsData{}, true
+	case TypeVoteParams:
+		return &VoteParamsData{}, true
+	default:
+		return GetDataV3(txType)
+	}
+}
 func GetDataV3(txType TxType) (Data, bool) {
 	switch txType {
 	case TypeUnbond:
@@ -173,6 +215,10 @@ func (e *Executor) DecodeFromBytes(buf []byte) (*Transaction, error) {
 		return nil, err
 	}
 
+	if tx.SignatureType == SigTypeSponsored && !e.sinceV340 {
+		return nil, errors.New("unknown signature type")
+	}
+
 	tx, err = DecodeSig(tx)
 	if err != nil {
 		return nil, err
@@ -197,6 +243,14 @@ func DecodeSig(tx *Transaction) (*Transaction, error) {
 				return nil, err
 			}
 		}
+	case SigTypeSponsored:
+		{
+			tx.sponsored = &SignatureSponsored{}
+			if err := rlp.DecodeBytes(tx.SignatureData, tx.sponsored); err != nil {
+				return nil, err
+			}
+			tx.sig = &tx.sponsored.Sender
+		}
 	default:
 		return nil, errors.New("unknown signature type")
 	}
@@ -212,6 +266,10 @@ func (e *Executor) DecodeFromBytesWithoutSig(buf []byte) (*Transaction, error) {
 		return nil, err
 	}
 
+	if tx.ValidUntil != 0 && !e.sinceV340 {
+		return nil, errors.New("unknown transaction encoding version")
+	}
+
 	if tx.Data == nil {
 		return nil, errors.New("incorrect tx data")
 	}
@@ -53,11 +53,11 @@ func (data EditCandidateCommission) basicCheck(tx *Transaction, context *state.C
 		}
 	}
 
-	if candidate.LastEditCommissionHeight+3*types.GetUnbondPeriod() > block {
+	if period := 3 * context.Params().GetUnbondPeriod(); candidate.LastEditCommissionHeight+period > block {
 		return &Response{
 			Code: code.PeriodLimitReached,
-			Log:  fmt.Sprintf("You cannot change the commission more than once every %d blocks, the last change was on block %d", 3*types.GetUnbondPeriod(), candidate.LastEditCommissionHeight),
-			Info: EncodeError(code.NewPeriodLimitReached(strconv.Itoa(int(candidate.LastEditCommissionHeight+3*types.GetUnbondPeriod())), strconv.Itoa(int(candidate.LastEditCommissionHeight)))),
+			Log:  fmt.Sprintf("You cannot change the commission more than once every %d blocks, the last change was on block %d", period, candidate.LastEditCommissionHeight),
+			Info: EncodeError(code.NewPeriodLimitReached(strconv.Itoa(int(candidate.LastEditCommissionHeight+period)), strconv.Itoa(int(candidate.LastEditCommissionHeight)))),
 		}
 	}
 
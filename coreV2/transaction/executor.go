@@ -32,10 +32,17 @@ type Response struct {
 	GasUsed   int64                     `json:"gas_used,omitempty"`
 	Tags      []abcTypes.EventAttribute `json:"tags,omitempty"`
 	GasPrice  uint32                    `json:"gas_price"`
+	// Replaced is the pending transaction replaced by the checked one
+	Replaced *PendingTx `json:"-"`
+
+	// feeCharged is set if the commission of the failed transaction is charged already
+	feeCharged bool
 }
 
 type Executor struct {
 	decodeTxFunc func(txType TxType) (Data, bool)
+	// sinceV340 accepts the sponsored signature and the encoding version 2 of transactions
+	sinceV340 bool
 }
 
 func NewExecutor(decodeTxFunc func(txType TxType) (Data, bool)) ExecutorTx {
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"crypto/ecdsa"
 	"math/big"
 	"math/rand"
 	"sync"
@@ -741,6 +742,161 @@ func TestTwoTxFormOneSenderOneBlock(t *testing.T) {
 		}
 	}
 }
+func TestSequenceTxsFromOneSenderOneBlockV3(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, 0, helpers.BipToPip(big.NewInt(1000000)))
+	mempool := &sync.Map{}
+
+	encodedData, _ := rlp.EncodeToBytes(SendData{
+		Coin:  types.GetBaseCoinID(),
+		To:    types.Address{1},
+		Value: big.NewInt(1),
+	})
+	checkTx := func(nonce uint64) Response {
+		tx := Transaction{
+			Nonce:         nonce,
+			GasPrice:      1,
+			ChainID:       types.CurrentChainID,
+			GasCoin:       types.GetBaseCoinID(),
+			Type:          TypeSend,
+			Data:          encodedData,
+			SignatureType: SigTypeSingle,
+		}
+		if err := tx.Sign(privateKey); err != nil {
+			t.Fatalf("Error %s", err.Error())
+		}
+		txBytes, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			t.Fatalf("Error %s", err.Error())
+		}
+		return NewExecutorV340(GetDataV340).RunTx(state.NewCheckState(cState), txBytes, nil, 0, mempool, 0, false)
+	}
+
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		if response := checkTx(nonce); response.Code != code.OK {
+			t.Fatalf("Error code is not %d, got %d: %s", code.OK, response.Code, response.Log)
+		}
+	}
+
+	if response := checkTx(2); response.Code != code.TxFromSenderAlreadyInMempool {
+		t.Fatalf("Error code is not %d, got %d", code.TxFromSenderAlreadyInMempool, response.Code)
+	}
+	if response := checkTx(5); response.Code != code.WrongNonce {
+		t.Fatalf("Error code is not %d, got %d", code.WrongNonce, response.Code)
+	}
+}
+
+func TestReplaceByFeeV3(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, 0, helpers.BipToPip(big.NewInt(1000000)))
+	mempool := &sync.Map{}
+
+	checkTx := func(nonce uint64, gasPrice uint32, value int64) Response {
+		txBytes := replaceByFeeTestTx(t, privateKey, nonce, gasPrice, value)
+		return NewExecutorV340(GetDataV340).RunTx(state.NewCheckState(cState), txBytes, nil, 0, mempool, 0, false)
+	}
+
+	if response := checkTx(1, 10, 1); response.Code != code.OK || response.Replaced != nil {
+		t.Fatalf("Error code is not %d, got %d: %s", code.OK, response.Code, response.Log)
+	}
+	if response := checkTx(2, 10, 1); response.Code != code.OK {
+		t.Fatalf("Error code is not %d, got %d: %s", code.OK, response.Code, response.Log)
+	}
+
+	// the replacement should pay at least 10% more for gas
+	if response := checkTx(2, 10, 2); response.Code != code.TxFromSenderAlreadyInMempool {
+		t.Fatalf("Error code is not %d, got %d", code.TxFromSenderAlreadyInMempool, response.Code)
+	}
+	response := checkTx(2, 11, 2)
+	if response.Code != code.OK {
+		t.Fatalf("Error code is not %d, got %d: %s", code.OK, response.Code, response.Log)
+	}
+	if response.Replaced == nil || response.Replaced.Nonce != 2 || response.Replaced.GasPrice != 10 {
+		t.Fatalf("unexpected replaced tx %#v", response.Replaced)
+	}
+
+	// the replacement doesn't change the last pending nonce and is replaced itself
+	if response := checkTx(2, 20, 3); response.Code != code.OK || response.Replaced.GasPrice != 11 {
+		t.Fatalf("unexpected response %#v", response)
+	}
+}
+
+func TestReplaceByFeeV3WithLaterNonces(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, 0, helpers.BipToPip(big.NewInt(1000000)))
+	mempool := &sync.Map{}
+
+	checkTx := func(nonce uint64, gasPrice uint32) Response {
+		txBytes := replaceByFeeTestTx(t, privateKey, nonce, gasPrice, 1)
+		return NewExecutorV340(GetDataV340).RunTx(state.NewCheckState(cState), txBytes, nil, 0, mempool, 0, false)
+	}
+
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		if response := checkTx(nonce, 10); response.Code != code.OK {
+			t.Fatalf("Error code is not %d, got %d: %s", code.OK, response.Code, response.Log)
+		}
+	}
+
+	// the replacement of nonces 1 and 2 would be placed in mempool after the pending nonce 3
+	for _, nonce := range []uint64{1, 2} {
+		if response := checkTx(nonce, 100); response.Code != code.TxFromSenderAlreadyInMempool {
+			t.Fatalf("Error code is not %d, got %d: %s", code.TxFromSenderAlreadyInMempool, response.Code, response.Log)
+		}
+	}
+
+	response := checkTx(3, 100)
+	if response.Code != code.OK {
+		t.Fatalf("Error code is not %d, got %d: %s", code.OK, response.Code, response.Log)
+	}
+	if response.Replaced == nil || response.Replaced.Nonce != 3 {
+		t.Fatalf("unexpected replaced tx %#v", response.Replaced)
+	}
+}
+
+func replaceByFeeTestTx(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64, gasPrice uint32, value int64) []byte {
+	t.Helper()
+	encodedData, _ := rlp.EncodeToBytes(SendData{
+		Coin:  types.GetBaseCoinID(),
+		To:    types.Address{1},
+		Value: big.NewInt(value),
+	})
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      gasPrice,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          TypeSend,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatalf("Error %s", err.Error())
+	}
+	txBytes, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatalf("Error %s", err.Error())
+	}
+	return txBytes
+}
+
+func TestPendingTx_ReplacementGasPrice(t *testing.T) {
+	t.Parallel()
+	for gasPrice, want := range map[uint32]uint64{1: 2, 10: 11, 11: 13, 100: 110} {
+		if got, ok := (&PendingTx{GasPrice: gasPrice}).ReplacementGasPrice(); !ok || got != want {
+			t.Errorf("replacement gas price of %d is %d, want %d", gasPrice, got, want)
+		}
+	}
+}
+
 func TestTwoTxFormOneSenderAnyBlock(t *testing.T) {
 	t.Parallel()
 	cState := getState()
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -25,6 +26,11 @@ func NewExecutorV3(decodeTxFunc func(txType TxType) (Data, bool)) ExecutorTx {
 	return &ExecutorV3{decodeTxFunc: decodeTxFunc, Executor: &Executor{decodeTxFunc: decodeTxFunc}}
 }
 
+// NewExecutorV340 returns ExecutorV3 accepting the sponsored signature and the encoding version 2 of transactions
+func NewExecutorV340(decodeTxFunc func(txType TxType) (Data, bool)) ExecutorTx {
+	return &ExecutorV3{decodeTxFunc: decodeTxFunc, Executor: &Executor{decodeTxFunc: decodeTxFunc, sinceV340: true}}
+}
+
 func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *big.Int, currentBlock uint64, currentMempool *sync.Map, minGasPrice uint32, notSaveTags bool) Response {
 	lenRawTx := len(rawTx)
 	if lenRawTx > maxTxLength {
@@ -44,6 +50,49 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 		}
 	}
 
+	response := e.runTx(context, tx, true, rewardPool, currentBlock, currentMempool, minGasPrice, notSaveTags)
+	if deliverState, ok := context.(*state.State); ok && !notSaveTags {
+		addOrderEvents(deliverState, tx, rawTx, response)
+	}
+	if _, isCheck := context.(*state.CheckState); isCheck && response.Code == code.OK {
+		sender, _ := tx.Sender()
+		currentMempool.Store(pendingKey{sender: sender, nonce: tx.Nonce}, &PendingTx{
+			Hash:     sha256.Sum256(rawTx),
+			Sender:   sender,
+			Nonce:    tx.Nonce,
+			GasPrice: tx.GasPrice,
+		})
+	}
+
+	return response
+}
+
+// RunUnsignedTx executes transaction without signature on behalf of the sender.
+// Signatures are not verified, so it must be used only with a throwaway state, e.g. to simulate transaction.
+func (e *ExecutorV3) RunUnsignedTx(context state.Interface, rawTx []byte, sender types.Address, rewardPool *big.Int, currentBlock uint64, currentMempool *sync.Map, minGasPrice uint32, notSaveTags bool) Response {
+	lenRawTx := len(rawTx)
+	if lenRawTx > maxTxLength {
+		return Response{
+			Code: code.TxTooLarge,
+			Log:  fmt.Sprintf("TX length is over %d bytes", maxTxLength),
+			Info: EncodeError(code.NewTxTooLarge(fmt.Sprintf("%d", maxTxLength), fmt.Sprintf("%d", lenRawTx))),
+		}
+	}
+
+	tx, err := e.DecodeFromBytesWithoutSig(rawTx)
+	if err != nil {
+		return Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+	tx.sender = &sender
+
+	return e.runTx(context, tx, false, rewardPool, currentBlock, currentMempool, minGasPrice, notSaveTags)
+}
+
+func (e *ExecutorV3) runTx(context state.Interface, tx *Transaction, verifySignature bool, rewardPool *big.Int, currentBlock uint64, currentMempool *sync.Map, minGasPrice uint32, notSaveTags bool) Response {
 	if tx.Type == TypeLockStake && currentBlock <= 10197360 {
 		return Response{
 			Code: code.Unavailable,
@@ -60,6 +109,14 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 		}
 	}
 
+	if tx.ValidUntil != 0 && currentBlock > tx.ValidUntil {
+		return Response{
+			Code: code.TxExpired,
+			Log:  fmt.Sprintf("Transaction is valid until block %d", tx.ValidUntil),
+			Info: EncodeError(code.NewTxExpired(strconv.FormatUint(tx.ValidUntil, 10), strconv.FormatUint(currentBlock, 10))),
+		}
+	}
+
 	var checkState *state.CheckState
 	var isCheck bool
 	if checkState, isCheck = context.(*state.CheckState); !isCheck {
@@ -110,7 +167,7 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 	}
 
 	// check multi-signature
-	if tx.SignatureType == SigTypeMulti {
+	if tx.SignatureType == SigTypeMulti && verifySignature {
 		multisig := checkState.Accounts().GetAccount(tx.multisig.Multisig)
 
 		if !multisig.IsMultisig() {
@@ -167,7 +224,48 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 
 	}
 
-	if expectedNonce := checkState.Accounts().GetNonce(sender) + 1; expectedNonce != tx.Nonce {
+	// the commission of the sponsored transaction is paid by the fee payer,
+	// the unsigned one is simulated as an ordinary transaction of the sender
+	feePayer := sender
+	isSponsored := tx.SignatureType == SigTypeSponsored && verifySignature
+	if isSponsored {
+		var resp *Response
+		if feePayer, resp = checkFeePayer(tx, sender); resp != nil {
+			return *resp
+		}
+	}
+
+	stateNonce := checkState.Accounts().GetNonce(sender)
+	expectedNonce := stateNonce + 1
+	var replaced *PendingTx
+	if isCheck {
+		// the sender may have a sequence of transactions in mempool, the next one continues it
+		if pendingNonce, has := currentMempool.Load(sender); has {
+			expectedNonce = pendingNonce.(uint64) + 1
+		}
+		// or replaces the last pending one paying more for gas, the earlier ones can't be replaced
+		// as the replacement would be placed in mempool after the later nonces of the sender
+		if tx.Nonce > stateNonce && tx.Nonce < expectedNonce {
+			if pending, has := currentMempool.Load(pendingKey{sender: sender, nonce: tx.Nonce}); has {
+				replaced = pending.(*PendingTx)
+				if minGasPrice, ok := replaced.ReplacementGasPrice(); !ok || tx.Nonce != expectedNonce-1 || uint64(tx.GasPrice) < minGasPrice {
+					log := fmt.Sprintf("Tx from %s with nonce %d already exists in mempool", sender.String(), tx.Nonce)
+					if tx.Nonce != expectedNonce-1 {
+						log += fmt.Sprintf(", only the last pending tx with nonce %d can be replaced", expectedNonce-1)
+					} else if ok {
+						log += fmt.Sprintf(", replacement should have gas price at least %d", minGasPrice)
+					}
+					return Response{
+						Code: code.TxFromSenderAlreadyInMempool,
+						Log:  log,
+						Info: EncodeError(code.NewTxFromSenderAlreadyInMempool(sender.String(), strconv.Itoa(int(currentBlock)))),
+					}
+				}
+				expectedNonce = tx.Nonce
+			}
+		}
+	}
+	if expectedNonce != tx.Nonce {
 		return Response{
 			Code: code.WrongNonce,
 			Log:  fmt.Sprintf("Unexpected nonce. Expected: %d, got %d.", expectedNonce, tx.Nonce),
@@ -197,20 +295,22 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 		}
 	}
 
-	response := tx.decodedData.Run(tx, context, rewardPool, currentBlock, price)
+	var response Response
+	if isSponsored {
+		response = runSponsored(tx, feePayer, context, rewardPool, currentBlock, price)
+	} else {
+		response = tx.decodedData.Run(tx, context, rewardPool, currentBlock, price)
+	}
 	if response.Code == code.OK && isCheck {
-		// check if mempool already has transactions from this address
-		if _, has := currentMempool.LoadOrStore(sender, true); has {
-			return Response{
-				Code: code.TxFromSenderAlreadyInMempool,
-				Log:  fmt.Sprintf("Tx from %s already exists in mempool", sender.String()),
-				Info: EncodeError(code.NewTxFromSenderAlreadyInMempool(sender.String(), strconv.Itoa(int(currentBlock)))),
-			}
+		// remember the last nonce of pending transactions of the sender
+		if replaced == nil {
+			currentMempool.Store(sender, tx.Nonce)
 		}
+		response.Replaced = replaced
 	}
 
 	if !isCheck {
-		if response.Code != 0 {
+		if response.Code != 0 && !response.feeCharged {
 			commissionInBaseCoin := big.NewInt(0).Add(commissions.FailedTx, big.NewInt(0).Mul(big.NewInt(tx.payloadAndServiceDataLen()), commissions.PayloadByte))
 			commissionInBaseCoin = tx.MulGasPrice(commissionInBaseCoin)
 
@@ -236,7 +336,7 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 				return *errResp
 			}
 
-			var intruder = sender
+			var intruder = feePayer
 			if tx.Type == TypeRedeemCheck {
 				decodedCheck, err := check.DecodeFromBytes(tx.decodedData.(*RedeemCheckData).RawCheck)
 				if err != nil {
@@ -366,6 +466,9 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 		if tx.Type != TypeRedeemCheck {
 			response.Tags = append(response.Tags, abcTypes.EventAttribute{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:])), Index: true})
 		}
+		if tx.ValidUntil != 0 {
+			response.Tags = append(response.Tags, abcTypes.EventAttribute{Key: []byte("tx.valid_until"), Value: []byte(strconv.FormatUint(tx.ValidUntil, 10))})
+		}
 	}
 
 	response.GasUsed = tx.Gas()
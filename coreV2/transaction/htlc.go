@@ -0,0 +1,339 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/htlc"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CreateHTLCData locks the value in the hash time-locked contract, which pays it to the recipient
+// by the preimage of the sha256 hash lock until the timeout height, or refunds it to the sender after it
+type CreateHTLCData struct {
+	Recipient types.Address
+	Coin      types.CoinID
+	Value     *big.Int
+	HashLock  types.Hash
+	Timeout   uint64
+}
+
+func (data CreateHTLCData) TxType() TxType {
+	return TypeCreateHTLC
+}
+
+func (data CreateHTLCData) Gas() int64 {
+	return gasCreateHTLC
+}
+
+func (data CreateHTLCData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 || data.Recipient == (types.Address{}) {
+		return &Response{
+			Code: code.WrongHTLC,
+			Log:  "Value of the HTLC should be positive and the recipient should be set",
+			Info: EncodeError(code.NewCustomCode(code.WrongHTLC)),
+		}
+	}
+
+	if data.Timeout <= currentBlock {
+		return &Response{
+			Code: code.WrongDueHeight,
+			Log:  fmt.Sprintf("Current height is higher than the timeout"),
+			Info: EncodeError(code.NewCustomCode(code.WrongDueHeight)),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	if context.HTLCs().GetHTLC(data.HashLock) != nil {
+		return &Response{
+			Code: code.HTLCAlreadyExists,
+			Log:  fmt.Sprintf("HTLC with hash lock %s already exists", data.HashLock.String()),
+			Info: EncodeError(code.NewHTLCAlreadyExists(data.HashLock.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateHTLCData) String() string {
+	return fmt.Sprintf("CREATE HTLC to:%s coin:%s value:%s hash_lock:%s timeout:%d",
+		data.Recipient.String(), data.Coin.String(), data.Value.String(), data.HashLock.String(), data.Timeout)
+}
+
+func (data CreateHTLCData) CommissionData(price *commission.Price) *big.Int {
+	return price.Lock
+}
+
+func (data CreateHTLCData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	needValue := big.NewInt(0).Set(commission)
+	if tx.GasCoin == data.Coin {
+		needValue.Add(data.Value, needValue)
+	} else {
+		if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(data.Value) < 0 {
+			coin := checkState.Coins().GetCoin(data.Coin)
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), data.Value.String(), coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), data.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+			}
+		}
+	}
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), needValue.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), needValue.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, data.Coin, data.Value)
+
+		deliverState.HTLCs.Create(data.HashLock, sender, data.Recipient, data.Coin, data.Value, data.Timeout)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.Recipient[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+			{Key: []byte("tx.hash_lock"), Value: []byte(hex.EncodeToString(data.HashLock[:])), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// ClaimHTLCData reveals the preimage of the hash lock and pays the value of the contract to its recipient,
+// the transaction may be sent by anyone before the timeout
+type ClaimHTLCData struct {
+	Preimage []byte
+}
+
+func (data ClaimHTLCData) TxType() TxType {
+	return TypeClaimHTLC
+}
+
+func (data ClaimHTLCData) Gas() int64 {
+	return gasClaimHTLC
+}
+
+func (data ClaimHTLCData) hashLock() types.Hash {
+	return sha256.Sum256(data.Preimage)
+}
+
+func (data ClaimHTLCData) String() string {
+	return fmt.Sprintf("CLAIM HTLC hash_lock:%s", data.hashLock().String())
+}
+
+func (data ClaimHTLCData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data ClaimHTLCData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	hashLock := data.hashLock()
+	return runHTLCPayout(tx, context, rewardPool, currentBlock, price, hashLock, func(contract *htlc.Model) *Response {
+		if currentBlock >= contract.Timeout {
+			return &Response{
+				Code: code.HTLCExpired,
+				Log:  fmt.Sprintf("HTLC with hash lock %s is expired at height %d", hashLock.String(), contract.Timeout),
+				Info: EncodeError(code.NewHTLCExpired(hashLock.String(), strconv.FormatUint(contract.Timeout, 10))),
+			}
+		}
+		return nil
+	}, func(contract *htlc.Model) types.Address {
+		return contract.Recipient
+	}, abcTypes.EventAttribute{Key: []byte("tx.preimage"), Value: []byte(hex.EncodeToString(data.Preimage))})
+}
+
+// RefundHTLCData returns the value of the expired contract to its sender,
+// the transaction may be sent by anyone after the timeout
+type RefundHTLCData struct {
+	HashLock types.Hash
+}
+
+func (data RefundHTLCData) TxType() TxType {
+	return TypeRefundHTLC
+}
+
+func (data RefundHTLCData) Gas() int64 {
+	return gasRefundHTLC
+}
+
+func (data RefundHTLCData) String() string {
+	return fmt.Sprintf("REFUND HTLC hash_lock:%s", data.HashLock.String())
+}
+
+func (data RefundHTLCData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data RefundHTLCData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return runHTLCPayout(tx, context, rewardPool, currentBlock, price, data.HashLock, func(contract *htlc.Model) *Response {
+		if currentBlock < contract.Timeout {
+			return &Response{
+				Code: code.HTLCNotExpired,
+				Log:  fmt.Sprintf("HTLC with hash lock %s can be refunded from height %d", data.HashLock.String(), contract.Timeout),
+				Info: EncodeError(code.NewHTLCNotExpired(data.HashLock.String(), strconv.FormatUint(contract.Timeout, 10))),
+			}
+		}
+		return nil
+	}, func(contract *htlc.Model) types.Address {
+		return contract.Sender
+	})
+}
+
+// runHTLCPayout charges the commission from the sender of the transaction and pays the value of the contract
+// with the hash lock to the receiver chosen by payee, if check allows it
+func runHTLCPayout(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int, hashLock types.Hash, check func(contract *htlc.Model) *Response, payee func(contract *htlc.Model) types.Address, extraTags ...abcTypes.EventAttribute) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	contract := checkState.HTLCs().GetHTLC(hashLock)
+	if contract == nil {
+		return Response{
+			Code: code.HTLCNotExists,
+			Log:  fmt.Sprintf("HTLC with hash lock %s not exists", hashLock.String()),
+			Info: EncodeError(code.NewHTLCNotExists(hashLock.String())),
+		}
+	}
+	if response := check(contract); response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		to := payee(contract)
+		deliverState.HTLCs.Delete(hashLock)
+		deliverState.Accounts.AddBalance(to, contract.Coin, contract.Value)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = append([]abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(to[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(contract.Coin.String()), Index: true},
+			{Key: []byte("tx.value"), Value: []byte(contract.Value.String())},
+			{Key: []byte("tx.hash_lock"), Value: []byte(hex.EncodeToString(hashLock[:])), Index: true},
+		}, extraTags...)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,150 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestHTLCTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	claimerKey, _ := crypto.GenerateKey()
+	claimer := crypto.PubkeyToAddress(claimerKey.PublicKey)
+	cState.Accounts.AddBalance(claimer, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	recipient := types.Address{2}
+	value := helpers.BipToPip(big.NewInt(100))
+	preimage := []byte("secret")
+	hashLock := types.Hash(sha256.Sum256(preimage))
+
+	encodedTx := signedTestTx(t, privateKey, 1, TypeCreateHTLC, CreateHTLCData{
+		Recipient: recipient,
+		Coin:      types.GetBaseCoinID(),
+		Value:     value,
+		HashLock:  hashLock,
+		Timeout:   100,
+	})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 10, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	// the contract can't be refunded before the timeout
+	encodedTx = signedTestTx(t, privateKey, 2, TypeRefundHTLC, RefundHTLCData{HashLock: hashLock})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 99, &sync.Map{}, 0, false)
+	if response.Code != code.HTLCNotExpired {
+		t.Fatalf("Response code is not %d. Error: %s", code.HTLCNotExpired, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, claimerKey, 1, TypeClaimHTLC, ClaimHTLCData{Preimage: []byte("wrong")})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 99, &sync.Map{}, 0, false)
+	if response.Code != code.HTLCNotExists {
+		t.Fatalf("Response code is not %d. Error: %s", code.HTLCNotExists, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, claimerKey, 1, TypeClaimHTLC, ClaimHTLCData{Preimage: preimage})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 99, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(recipient, types.GetBaseCoinID()); balance.Cmp(value) != 0 {
+		t.Errorf("Recipient balance is not correct. Expected %s, got %s", value, balance)
+	}
+	if cState.HTLCs.GetHTLC(hashLock) != nil {
+		t.Errorf("Claimed HTLC is not deleted")
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHTLCTxRefund(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	coin := createTestCoin(cState)
+	cState.Accounts.SubBalance(types.Address{}, coin, helpers.BipToPip(big.NewInt(1000)))
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	preimage := []byte("secret")
+	hashLock := types.Hash(sha256.Sum256(preimage))
+
+	encodedTx := signedTestTx(t, privateKey, 1, TypeCreateHTLC, CreateHTLCData{
+		Recipient: types.Address{2},
+		Coin:      coin,
+		Value:     value,
+		HashLock:  hashLock,
+		Timeout:   100,
+	})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 10, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx = signedTestTx(t, privateKey, 2, TypeClaimHTLC, ClaimHTLCData{Preimage: preimage})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 100, &sync.Map{}, 0, false)
+	if response.Code != code.HTLCExpired {
+		t.Fatalf("Response code is not %d. Error: %s", code.HTLCExpired, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, privateKey, 2, TypeRefundHTLC, RefundHTLCData{HashLock: hashLock})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 100, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(helpers.BipToPip(big.NewInt(1000))) != 0 {
+		t.Errorf("Sender balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(1000)), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHTLCTxWrongData(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	hashLock := types.Hash(sha256.Sum256([]byte("secret")))
+	for _, data := range []CreateHTLCData{
+		{Recipient: types.Address{2}, Coin: types.GetBaseCoinID(), Value: big.NewInt(0), HashLock: hashLock, Timeout: 100},
+		{Recipient: types.Address{}, Coin: types.GetBaseCoinID(), Value: big.NewInt(1), HashLock: hashLock, Timeout: 100},
+	} {
+		response := NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey, 1, TypeCreateHTLC, data), big.NewInt(0), 10, &sync.Map{}, 0, false)
+		if response.Code != code.WrongHTLC {
+			t.Fatalf("Response code is not %d. Error: %s", code.WrongHTLC, response.Log)
+		}
+	}
+}
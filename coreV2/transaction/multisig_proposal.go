@@ -0,0 +1,653 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/proposals"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CreateMultisigProposalData proposes the transaction on behalf of the multisig instead of collecting signatures offline.
+// The proposal is approved by its proposer, members approve it with ApproveMultisigProposalData and the transaction
+// is executed as soon as the total weight of approvals reaches the threshold of the multisig.
+// The multisig pays the commission of the proposed transaction in GasCoin.
+type CreateMultisigProposalData struct {
+	Multisig types.Address
+	GasCoin  types.CoinID
+	Type     TxType
+	Data     RawData
+}
+
+func (data CreateMultisigProposalData) TxType() TxType {
+	return TypeCreateMultisigProposal
+}
+
+func (data CreateMultisigProposalData) Gas() int64 {
+	return gasCreateMultisigProposal
+}
+
+// DecodedTx returns the decoded data of the proposed transaction
+func (data CreateMultisigProposalData) DecodedTx() (Data, error) {
+	return decodeMultisigProposal(data.Type, data.Data)
+}
+
+func (data CreateMultisigProposalData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if !context.Accounts().GetAccount(data.Multisig).IsMultisig() {
+		return &Response{
+			Code: code.MultisigNotExists,
+			Log:  "Multisig does not exists",
+			Info: EncodeError(code.NewMultisigNotExists(data.Multisig.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	multisig := context.Accounts().GetAccount(data.Multisig).Multisig()
+	if multisig.GetWeight(sender) == 0 {
+		return &Response{
+			Code: code.IsNotMultisigMember,
+			Log:  "Sender is not a member of the multisig",
+			Info: EncodeError(code.NewIsNotMultisigMember(data.Multisig.String(), sender.String())),
+		}
+	}
+
+	if _, err := data.DecodedTx(); err != nil {
+		return &Response{
+			Code: code.WrongMultisigProposal,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewCustomCode(code.WrongMultisigProposal)),
+		}
+	}
+
+	if !context.Coins().Exists(data.GasCoin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.GasCoin),
+			Info: EncodeError(code.NewCoinNotExists("", data.GasCoin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateMultisigProposalData) String() string {
+	return fmt.Sprintf("CREATE MULTISIG PROPOSAL multisig:%s type:%s", data.Multisig.String(), data.Type)
+}
+
+func (data CreateMultisigProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data CreateMultisigProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		id := deliverState.Proposals.Create(data.Multisig, sender, data.GasCoin, byte(data.Type), data.Data, currentBlock)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = append([]abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+		}, executeApprovedMultisigProposal(deliverState, deliverState.Proposals.GetProposal(id), rewardPool, currentBlock)...)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// ApproveMultisigProposalData adds the approval of the sender to the proposal,
+// the proposed transaction is executed if the total weight of approvals reaches the threshold of the multisig
+type ApproveMultisigProposalData struct {
+	ID uint64
+}
+
+func (data ApproveMultisigProposalData) TxType() TxType {
+	return TypeApproveMultisigProposal
+}
+
+func (data ApproveMultisigProposalData) Gas() int64 {
+	return gasApproveMultisigProposal
+}
+
+func (data ApproveMultisigProposalData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	proposal, response := checkMultisigProposalMember(tx, context, data.ID)
+	if response != nil {
+		return response
+	}
+
+	sender, _ := tx.Sender()
+	if proposal.IsApprovedBy(sender) {
+		return &Response{
+			Code: code.MultisigProposalAlreadyApproved,
+			Log:  "Multisig proposal is already approved by the sender",
+			Info: EncodeError(code.NewMultisigProposalAlreadyApproved(strconv.FormatUint(data.ID, 10), sender.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data ApproveMultisigProposalData) String() string {
+	return fmt.Sprintf("APPROVE MULTISIG PROPOSAL id:%d", data.ID)
+}
+
+func (data ApproveMultisigProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data ApproveMultisigProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Proposals.Approve(data.ID, sender)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = append([]abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+		}, executeApprovedMultisigProposal(deliverState, deliverState.Proposals.GetProposal(data.ID), rewardPool, currentBlock)...)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// ExecuteMultisigProposalData executes the approved proposal again if its transaction failed on the last approval,
+// the transaction fails if the proposed transaction can't be executed now
+type ExecuteMultisigProposalData struct {
+	ID uint64
+}
+
+func (data ExecuteMultisigProposalData) TxType() TxType {
+	return TypeExecuteMultisigProposal
+}
+
+func (data ExecuteMultisigProposalData) Gas() int64 {
+	return gasExecuteMultisigProposal
+}
+
+func (data ExecuteMultisigProposalData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) *Response {
+	proposal, response := checkMultisigProposalMember(tx, context, data.ID)
+	if response != nil {
+		return response
+	}
+
+	weight, threshold := multisigProposalWeight(context, proposal)
+	if weight < threshold {
+		return &Response{
+			Code: code.NotEnoughMultisigVotes,
+			Log:  fmt.Sprintf("Not enough multisig votes. Needed %d, has %d", threshold, weight),
+			Info: EncodeError(code.NewNotEnoughMultisigVotes(strconv.Itoa(int(threshold)), strconv.Itoa(int(weight)))),
+		}
+	}
+
+	if response := runMultisigProposal(context, proposal, big.NewInt(0), currentBlock); response.Code != code.OK {
+		response.Log = fmt.Sprintf("Proposed transaction failed: %s", response.Log)
+		return &response
+	}
+
+	return nil
+}
+
+func (data ExecuteMultisigProposalData) String() string {
+	return fmt.Sprintf("EXECUTE MULTISIG PROPOSAL id:%d", data.ID)
+}
+
+func (data ExecuteMultisigProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data ExecuteMultisigProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = append([]abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+		}, executeApprovedMultisigProposal(deliverState, deliverState.Proposals.GetProposal(data.ID), rewardPool, currentBlock)...)
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// CancelMultisigProposalData removes the proposal, only its proposer can cancel it
+type CancelMultisigProposalData struct {
+	ID uint64
+}
+
+func (data CancelMultisigProposalData) TxType() TxType {
+	return TypeCancelMultisigProposal
+}
+
+func (data CancelMultisigProposalData) Gas() int64 {
+	return gasCancelMultisigProposal
+}
+
+func (data CancelMultisigProposalData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	proposal := context.Proposals().GetProposal(data.ID)
+	if proposal == nil {
+		return &Response{
+			Code: code.MultisigProposalNotExists,
+			Log:  fmt.Sprintf("Multisig proposal %d not exists", data.ID),
+			Info: EncodeError(code.NewMultisigProposalNotExists(strconv.FormatUint(data.ID, 10))),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if proposal.Proposer != sender {
+		return &Response{
+			Code: code.IsNotProposerOfMultisigProposal,
+			Log:  "Sender is not the proposer of the multisig proposal",
+			Info: EncodeError(code.NewIsNotProposerOfMultisigProposal(strconv.FormatUint(data.ID, 10), sender.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CancelMultisigProposalData) String() string {
+	return fmt.Sprintf("CANCEL MULTISIG PROPOSAL id:%d", data.ID)
+}
+
+func (data CancelMultisigProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data CancelMultisigProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Proposals.Delete(data.ID)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.proposal_id"), Value: []byte(strconv.FormatUint(data.ID, 10)), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// decodeMultisigProposal returns the decoded data of the proposed transaction,
+// transactions which can't be executed on behalf of the multisig without signatures are not allowed
+func decodeMultisigProposal(txType TxType, data []byte) (Data, error) {
+	switch txType {
+	case TypeRedeemCheck, TypeRedeemChecks, TypeBatch, TypeCreateMultisigProposal, TypeApproveMultisigProposal, TypeExecuteMultisigProposal, TypeCancelMultisigProposal:
+		return nil, fmt.Errorf("transaction of type %s can't be proposed", txType)
+	}
+
+	d, ok := GetDataV340(txType)
+	if !ok {
+		return nil, fmt.Errorf("unknown transaction type %s", txType)
+	}
+	if err := rlp.DecodeBytes(data, d); err != nil {
+		return nil, fmt.Errorf("proposed transaction: %s", err)
+	}
+	return d, nil
+}
+
+// checkMultisigProposalMember returns the proposal if it exists and the sender is a member of its multisig
+func checkMultisigProposalMember(tx *Transaction, context *state.CheckState, id uint64) (*proposals.Model, *Response) {
+	proposal := context.Proposals().GetProposal(id)
+	if proposal == nil {
+		return nil, &Response{
+			Code: code.MultisigProposalNotExists,
+			Log:  fmt.Sprintf("Multisig proposal %d not exists", id),
+			Info: EncodeError(code.NewMultisigProposalNotExists(strconv.FormatUint(id, 10))),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	multisig := context.Accounts().GetAccount(proposal.Multisig).Multisig()
+	if multisig.GetWeight(sender) == 0 {
+		return nil, &Response{
+			Code: code.IsNotMultisigMember,
+			Log:  "Sender is not a member of the multisig",
+			Info: EncodeError(code.NewIsNotMultisigMember(proposal.Multisig.String(), sender.String())),
+		}
+	}
+
+	return proposal, nil
+}
+
+// multisigProposalWeight returns the total weight of approvals of the proposal and the threshold of its multisig,
+// approvals are weighted by the current members of the multisig
+func multisigProposalWeight(context *state.CheckState, proposal *proposals.Model) (weight uint32, threshold uint32) {
+	multisig := context.Accounts().GetAccount(proposal.Multisig).Multisig()
+	for _, address := range proposal.Approvals {
+		weight += multisig.GetWeight(address)
+	}
+	return weight, multisig.Threshold
+}
+
+// runMultisigProposal runs the proposed transaction on behalf of the multisig with the next nonce of the multisig,
+// the commission of the transaction is paid by the multisig as if the transaction was signed by its members
+func runMultisigProposal(context state.Interface, proposal *proposals.Model, rewardPool *big.Int, currentBlock uint64) Response {
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	data, err := decodeMultisigProposal(TxType(proposal.Type), proposal.Data)
+	if err != nil {
+		return Response{
+			Code: code.WrongMultisigProposal,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewCustomCode(code.WrongMultisigProposal)),
+		}
+	}
+
+	multisig := proposal.Multisig
+	tx := &Transaction{
+		Nonce:         checkState.Accounts().GetNonce(multisig) + 1,
+		ChainID:       types.CurrentChainID,
+		GasPrice:      1,
+		GasCoin:       proposal.GasCoin,
+		Type:          TxType(proposal.Type),
+		Data:          proposal.Data,
+		SignatureType: SigTypeMulti,
+		decodedData:   data,
+		multisig:      &SignatureMulti{Multisig: multisig},
+		sender:        &multisig,
+	}
+
+	commissions := checkState.Commission().GetCommissions()
+	price := tx.MulGasPrice(tx.Price(commissions))
+	if price.Sign() != 0 {
+		if !commissions.Coin.IsBaseCoin() {
+			var resp *Response
+			resp, price, _ = CheckSwap(checkState.Swap().GetSwapper(commissions.Coin, types.GetBaseCoinID()), checkState.Coins().GetCoin(commissions.Coin), checkState.Coins().GetCoin(0), price, big.NewInt(0), false)
+			if resp != nil {
+				return *resp
+			}
+		}
+		if price == nil || price.Sign() != 1 {
+			return Response{
+				Code: code.CommissionCoinNotSufficient,
+				Log:  fmt.Sprint("Not possible to pay commission"),
+				Info: EncodeError(code.NewCommissionCoinNotSufficient("", "")),
+			}
+		}
+	}
+
+	return data.Run(tx, context, rewardPool, currentBlock, price)
+}
+
+// executeApprovedMultisigProposal executes the proposal if the total weight of its approvals reaches the threshold
+// and removes it on success. The proposal stays if the proposed transaction fails, so it can be executed later.
+// It returns tags of the proposal with tags of the executed transaction.
+func executeApprovedMultisigProposal(deliverState *state.State, proposal *proposals.Model, rewardPool *big.Int, currentBlock uint64) []abcTypes.EventAttribute {
+	tags := []abcTypes.EventAttribute{
+		{Key: []byte("tx.proposal_id"), Value: []byte(strconv.FormatUint(proposal.ID, 10)), Index: true},
+		{Key: []byte("tx.multisig"), Value: []byte(hex.EncodeToString(proposal.Multisig[:])), Index: true},
+	}
+
+	if weight, threshold := multisigProposalWeight(state.NewCheckState(deliverState), proposal); weight < threshold {
+		return append(tags, abcTypes.EventAttribute{Key: []byte("tx.proposal_executed"), Value: []byte("false"), Index: true})
+	}
+
+	response := runMultisigProposal(deliverState, proposal, rewardPool, currentBlock)
+	if response.Code != code.OK {
+		return append(tags,
+			abcTypes.EventAttribute{Key: []byte("tx.proposal_executed"), Value: []byte("false"), Index: true},
+			abcTypes.EventAttribute{Key: []byte("tx.proposal_code"), Value: []byte(strconv.Itoa(int(response.Code)))},
+			abcTypes.EventAttribute{Key: []byte("tx.proposal_log"), Value: []byte(response.Log)},
+		)
+	}
+
+	deliverState.Proposals.Delete(proposal.ID)
+
+	tags = append(tags, abcTypes.EventAttribute{Key: []byte("tx.proposal_executed"), Value: []byte("true"), Index: true})
+	for _, tag := range response.Tags {
+		if bytes.HasPrefix(tag.Key, []byte("tx.commission_")) {
+			tag.Key = append([]byte("tx.proposal_"), bytes.TrimPrefix(tag.Key, []byte("tx."))...)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
@@ -0,0 +1,194 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func TestMultisigProposalTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey1, _ := crypto.GenerateKey()
+	addr1 := crypto.PubkeyToAddress(privateKey1.PublicKey)
+	privateKey2, _ := crypto.GenerateKey()
+	addr2 := crypto.PubkeyToAddress(privateKey2.PublicKey)
+	privateKey3, _ := crypto.GenerateKey()
+	addr3 := crypto.PubkeyToAddress(privateKey3.PublicKey)
+	for _, addr := range []types.Address{addr1, addr2, addr3} {
+		cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+	}
+
+	multisig := types.Address{1, 2, 3}
+	cState.Accounts.CreateMultisig([]uint32{1, 1, 1}, []types.Address{addr1, addr2, addr3}, 2, multisig)
+	cState.Accounts.AddBalance(multisig, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	sendData, err := rlp.EncodeToBytes(SendData{Coin: types.GetBaseCoinID(), To: types.Address{4}, Value: value})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx := signedTestTx(t, privateKey1, 1, TypeCreateMultisigProposal, CreateMultisigProposalData{
+		Multisig: multisig,
+		GasCoin:  types.GetBaseCoinID(),
+		Type:     TypeSend,
+		Data:     sendData,
+	})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if proposal := cState.Proposals.GetProposal(1); proposal == nil || proposal.Proposer != addr1 || !proposal.IsApprovedBy(addr1) {
+		t.Fatalf("Proposal is not correct: %+v", proposal)
+	}
+
+	encodedTx = signedTestTx(t, privateKey1, 2, TypeApproveMultisigProposal, ApproveMultisigProposalData{ID: 1})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.MultisigProposalAlreadyApproved {
+		t.Fatalf("Response code is not %d. Error: %s", code.MultisigProposalAlreadyApproved, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, privateKey2, 1, TypeExecuteMultisigProposal, ExecuteMultisigProposalData{ID: 1})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.NotEnoughMultisigVotes {
+		t.Fatalf("Response code is not %d. Error: %s", code.NotEnoughMultisigVotes, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, privateKey2, 1, TypeApproveMultisigProposal, ApproveMultisigProposalData{ID: 1})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if proposal := cState.Proposals.GetProposal(1); proposal != nil {
+		t.Fatalf("Executed proposal exists: %+v", proposal)
+	}
+	if balance := cState.Accounts.GetBalance(types.Address{4}, types.GetBaseCoinID()); balance.Cmp(value) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", value, balance)
+	}
+	if nonce := cState.Accounts.GetNonce(multisig); nonce != 1 {
+		t.Fatalf("Multisig nonce is not correct: %d", nonce)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMultisigProposalTxRetryAndCancel(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey1, _ := crypto.GenerateKey()
+	addr1 := crypto.PubkeyToAddress(privateKey1.PublicKey)
+	privateKey2, _ := crypto.GenerateKey()
+	addr2 := crypto.PubkeyToAddress(privateKey2.PublicKey)
+	outsiderKey, _ := crypto.GenerateKey()
+	for _, addr := range []types.Address{addr1, addr2, crypto.PubkeyToAddress(outsiderKey.PublicKey)} {
+		cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+	}
+
+	multisig := types.Address{1, 2, 3}
+	cState.Accounts.CreateMultisig([]uint32{1, 1}, []types.Address{addr1, addr2}, 2, multisig)
+
+	value := helpers.BipToPip(big.NewInt(100))
+	sendData, err := rlp.EncodeToBytes(SendData{Coin: types.GetBaseCoinID(), To: types.Address{4}, Value: value})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx := signedTestTx(t, outsiderKey, 1, TypeCreateMultisigProposal, CreateMultisigProposalData{
+		Multisig: multisig,
+		GasCoin:  types.GetBaseCoinID(),
+		Type:     TypeSend,
+		Data:     sendData,
+	})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotMultisigMember {
+		t.Fatalf("Response code is not %d. Error: %s", code.IsNotMultisigMember, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, privateKey1, 1, TypeCreateMultisigProposal, CreateMultisigProposalData{
+		Multisig: multisig,
+		GasCoin:  types.GetBaseCoinID(),
+		Type:     TypeBatch,
+		Data:     sendData,
+	})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongMultisigProposal {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongMultisigProposal, response.Log)
+	}
+
+	for i, id := range []uint64{1, 2} {
+		encodedTx = signedTestTx(t, privateKey1, uint64(i+1), TypeCreateMultisigProposal, CreateMultisigProposalData{
+			Multisig: multisig,
+			GasCoin:  types.GetBaseCoinID(),
+			Type:     TypeSend,
+			Data:     sendData,
+		})
+		response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+		if response.Code != code.OK {
+			t.Fatalf("Response code is not 0. Error: %s", response.Log)
+		}
+		if proposal := cState.Proposals.GetProposal(id); proposal == nil {
+			t.Fatalf("Proposal %d not exists", id)
+		}
+	}
+
+	// the multisig has no funds, so the approved proposal stays
+	encodedTx = signedTestTx(t, privateKey2, 1, TypeApproveMultisigProposal, ApproveMultisigProposalData{ID: 1})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+	if proposal := cState.Proposals.GetProposal(1); proposal == nil || len(proposal.Approvals) != 2 {
+		t.Fatalf("Proposal is not correct: %+v", proposal)
+	}
+
+	encodedTx = signedTestTx(t, privateKey2, 2, TypeExecuteMultisigProposal, ExecuteMultisigProposalData{ID: 1})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 3, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error: %s", code.InsufficientFunds, response.Log)
+	}
+
+	cState.Accounts.AddBalance(multisig, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+	encodedTx = signedTestTx(t, privateKey2, 2, TypeExecuteMultisigProposal, ExecuteMultisigProposalData{ID: 1})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 3, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+	if proposal := cState.Proposals.GetProposal(1); proposal != nil {
+		t.Fatalf("Executed proposal exists: %+v", proposal)
+	}
+	if balance := cState.Accounts.GetBalance(types.Address{4}, types.GetBaseCoinID()); balance.Cmp(value) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", value, balance)
+	}
+
+	encodedTx = signedTestTx(t, privateKey2, 3, TypeCancelMultisigProposal, CancelMultisigProposalData{ID: 2})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 4, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotProposerOfMultisigProposal {
+		t.Fatalf("Response code is not %d. Error: %s", code.IsNotProposerOfMultisigProposal, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, privateKey1, 3, TypeCancelMultisigProposal, CancelMultisigProposalData{ID: 2})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 4, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+	if proposal := cState.Proposals.GetProposal(2); proposal != nil {
+		t.Fatalf("Canceled proposal exists: %+v", proposal)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
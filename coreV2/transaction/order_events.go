@@ -0,0 +1,141 @@
+package transaction
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// addOrderEvents records the lifecycle of limit orders changed by the delivered transaction:
+// the created or canceled order and every filled part of orders, including fills by the commission swap.
+func addOrderEvents(deliverState *state.State, tx *Transaction, rawTx []byte, response Response) {
+	events := deliverState.Bus().Events()
+	if events == nil {
+		return
+	}
+
+	txHash := strings.Title(fmt.Sprintf("Mt%x", tmTypes.Tx(rawTx).Hash()))
+	sender, _ := tx.Sender()
+
+	if response.Code == code.OK {
+		switch data := tx.decodedData.(type) {
+		case *AddLimitOrderData:
+			if id, ok := tagUint(response.Tags, "tx.order_id"); ok {
+				events.AddEvent(orderCreatedEvent(id, sender, data, txHash))
+			}
+		case *BatchData:
+			// tags of batched transactions are kept in their order, so the n-th order id belongs to the n-th limit order
+			ids := tagUints(response.Tags, "tx.order_id")
+			txs, _ := data.DecodedTxs()
+			for _, d := range txs {
+				order, ok := d.(*AddLimitOrderData)
+				if !ok || len(ids) == 0 {
+					continue
+				}
+				events.AddEvent(orderCreatedEvent(ids[0], sender, order, txHash))
+				ids = ids[1:]
+			}
+		case *RemoveLimitOrderData:
+			coin, _ := tagUint(response.Tags, "tx.coin_id")
+			events.AddEvent(&eventsdb.OrderCanceledEvent{
+				ID:      uint64(data.ID),
+				Address: sender,
+				Coin:    coin,
+				Amount:  stringToBigInt(tagValue(response.Tags, "tx.return")).String(),
+				TxHash:  txHash,
+			})
+		}
+	}
+
+	changes := PoolChanges(response.Tags)
+	last := map[uint32]int{}
+	n := 0
+	for _, change := range changes {
+		for _, order := range change.Orders {
+			last[order.OrderID] = n
+			n++
+		}
+	}
+
+	checkState := state.NewCheckState(deliverState)
+	n = 0
+	for _, change := range changes {
+		for _, order := range change.Orders {
+			var filled bool
+			if last[order.OrderID] == n {
+				limit := checkState.Swap().GetOrder(order.OrderID)
+				filled = limit == nil || limit.WantBuy.Sign() == 0 || limit.WantSell.Sign() == 0
+			}
+			n++
+
+			if filled {
+				events.AddEvent(&eventsdb.OrderFilledEvent{
+					ID:       uint64(order.OrderID),
+					Address:  order.Seller,
+					CoinSell: uint64(change.CoinOut),
+					Sold:     order.Sell.String(),
+					CoinBuy:  uint64(change.CoinIn),
+					Bought:   order.Buy.String(),
+					TxHash:   txHash,
+				})
+				continue
+			}
+			events.AddEvent(&eventsdb.OrderPartiallyFilledEvent{
+				ID:       uint64(order.OrderID),
+				Address:  order.Seller,
+				CoinSell: uint64(change.CoinOut),
+				Sold:     order.Sell.String(),
+				CoinBuy:  uint64(change.CoinIn),
+				Bought:   order.Buy.String(),
+				TxHash:   txHash,
+			})
+		}
+	}
+}
+
+func orderCreatedEvent(id uint64, sender types.Address, data *AddLimitOrderData, txHash string) *eventsdb.OrderCreatedEvent {
+	return &eventsdb.OrderCreatedEvent{
+		ID:        id,
+		Address:   sender,
+		CoinSell:  uint64(data.CoinToSell),
+		ValueSell: data.ValueToSell.String(),
+		CoinBuy:   uint64(data.CoinToBuy),
+		ValueBuy:  data.ValueToBuy.String(),
+		TxHash:    txHash,
+	}
+}
+
+func tagValue(tags []abcTypes.EventAttribute, key string) string {
+	for _, tag := range tags {
+		if string(tag.Key) == key {
+			return string(tag.Value)
+		}
+	}
+	return ""
+}
+
+func tagUint(tags []abcTypes.EventAttribute, key string) (uint64, bool) {
+	value, err := strconv.ParseUint(tagValue(tags, key), 10, 64)
+	return value, err == nil
+}
+
+// tagUints returns values of all tags with the key in their order
+func tagUints(tags []abcTypes.EventAttribute, key string) []uint64 {
+	var values []uint64
+	for _, tag := range tags {
+		if string(tag.Key) != key {
+			continue
+		}
+		if value, err := strconv.ParseUint(string(tag.Value), 10, 64); err == nil {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,43 @@
+package transaction
+
+import (
+	"crypto/sha256"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// ReplaceByFeeBump is the minimal increase of the gas price in percent for a transaction
+// to replace the last pending one of the sender with the same nonce, zero disables replacements
+var ReplaceByFeeBump uint32 = 10
+
+// PendingTx is the checked transaction pending in the mempool of the node
+type PendingTx struct {
+	Hash     [sha256.Size]byte
+	Sender   types.Address
+	Nonce    uint64
+	GasPrice uint32
+}
+
+// pendingKey is the key of the pending transaction of the sender with the nonce in the map of the current mempool,
+// the sender itself is the key of the last nonce of its pending transactions
+type pendingKey struct {
+	sender types.Address
+	nonce  uint64
+}
+
+// ReplacementGasPrice returns the minimal gas price of the transaction to replace the pending one,
+// false if replacements are disabled
+func (tx *PendingTx) ReplacementGasPrice() (uint64, bool) {
+	if ReplaceByFeeBump == 0 {
+		return 0, false
+	}
+	gasPrice := uint64(tx.GasPrice) * uint64(100+ReplaceByFeeBump)
+	minGasPrice := gasPrice / 100
+	if gasPrice%100 != 0 {
+		minGasPrice++
+	}
+	if minGasPrice <= uint64(tx.GasPrice) {
+		minGasPrice = uint64(tx.GasPrice) + 1
+	}
+	return minGasPrice, true
+}
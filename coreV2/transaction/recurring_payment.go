@@ -0,0 +1,260 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CreateRecurringPaymentData registers the standing order of the sender to transfer the value to the recipient
+// every Period blocks Times times starting Period blocks after the current one. Payments are made at the end of blocks
+// from the spendable balance of the sender, a payment is skipped if the balance is not enough.
+// Commissions of all payments are paid upfront and are not returned on cancellation.
+type CreateRecurringPaymentData struct {
+	To     types.Address
+	Coin   types.CoinID
+	Value  *big.Int
+	Period uint64
+	Times  uint64
+}
+
+func (data CreateRecurringPaymentData) TxType() TxType {
+	return TypeCreateRecurringPayment
+}
+
+func (data CreateRecurringPaymentData) Gas() int64 {
+	return gasCreateRecurringPayment
+}
+
+func (data CreateRecurringPaymentData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 || data.Period == 0 || data.Times == 0 || data.Period > (math.MaxUint64-currentBlock)/data.Times {
+		return &Response{
+			Code: code.WrongRecurringPayment,
+			Log:  "Value, period and times of the recurring payment should be positive",
+			Info: EncodeError(code.NewCustomCode(code.WrongRecurringPayment)),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateRecurringPaymentData) String() string {
+	return fmt.Sprintf("CREATE RECURRING PAYMENT to:%s coin:%s value:%s period:%d times:%d",
+		data.To.String(), data.Coin.String(), data.Value.String(), data.Period, data.Times)
+}
+
+func (data CreateRecurringPaymentData) CommissionData(price *commission.Price) *big.Int {
+	return big.NewInt(0).Mul(price.Send, big.NewInt(0).SetUint64(data.Times))
+}
+
+func (data CreateRecurringPaymentData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		id := deliverState.Recurring.Create(sender, data.To, data.Coin, data.Value, data.Period, data.Times, currentBlock+data.Period)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.To[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+			{Key: []byte("tx.recurring_payment_id"), Value: []byte(strconv.FormatUint(id, 10)), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// CancelRecurringPaymentData removes the standing order of the sender with its remaining payments
+type CancelRecurringPaymentData struct {
+	ID uint64
+}
+
+func (data CancelRecurringPaymentData) TxType() TxType {
+	return TypeCancelRecurringPayment
+}
+
+func (data CancelRecurringPaymentData) Gas() int64 {
+	return gasCancelRecurringPayment
+}
+
+func (data CancelRecurringPaymentData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	payment := context.Recurring().GetPayment(data.ID)
+	if payment == nil {
+		return &Response{
+			Code: code.RecurringPaymentNotExists,
+			Log:  fmt.Sprintf("Recurring payment %d not exists", data.ID),
+			Info: EncodeError(code.NewRecurringPaymentNotExists(strconv.FormatUint(data.ID, 10))),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if payment.Payer != sender {
+		return &Response{
+			Code: code.IsNotPayerOfRecurringPayment,
+			Log:  "Sender is not the payer of the recurring payment",
+			Info: EncodeError(code.NewIsNotPayerOfRecurringPayment(strconv.FormatUint(data.ID, 10), payment.Payer.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data CancelRecurringPaymentData) String() string {
+	return fmt.Sprintf("CANCEL RECURRING PAYMENT id:%d", data.ID)
+}
+
+func (data CancelRecurringPaymentData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data CancelRecurringPaymentData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Recurring.Cancel(data.ID)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.recurring_payment_id"), Value: []byte(strconv.FormatUint(data.ID, 10)), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
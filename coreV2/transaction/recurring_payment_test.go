@@ -0,0 +1,91 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestRecurringPaymentTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	encodedTx := signedTestTx(t, privateKey, 1, TypeCreateRecurringPayment, CreateRecurringPaymentData{
+		To:     types.Address{1},
+		Coin:   types.GetBaseCoinID(),
+		Value:  helpers.BipToPip(big.NewInt(10)),
+		Period: 5,
+		Times:  3,
+	})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	payment := cState.Recurring.GetPayment(1)
+	if payment == nil || payment.Payer != addr || payment.NextHeight != 6 || payment.Remaining != 3 {
+		t.Fatalf("Payment is not correct: %+v", payment)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, _ := crypto.GenerateKey()
+	cState.Accounts.AddBalance(crypto.PubkeyToAddress(otherKey.PublicKey), types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+	encodedTx = signedTestTx(t, otherKey, 1, TypeCancelRecurringPayment, CancelRecurringPaymentData{ID: 1})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotPayerOfRecurringPayment {
+		t.Fatalf("Response code is not %d. Error: %s", code.IsNotPayerOfRecurringPayment, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, privateKey, 2, TypeCancelRecurringPayment, CancelRecurringPaymentData{ID: 1})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if payment := cState.Recurring.GetPayment(1); payment != nil {
+		t.Fatalf("Canceled payment exists: %+v", payment)
+	}
+
+	encodedTx = signedTestTx(t, privateKey, 3, TypeCancelRecurringPayment, CancelRecurringPaymentData{ID: 1})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 3, &sync.Map{}, 0, false)
+	if response.Code != code.RecurringPaymentNotExists {
+		t.Fatalf("Response code is not %d. Error: %s", code.RecurringPaymentNotExists, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecurringPaymentTxWrongPayment(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	encodedTx := signedTestTx(t, privateKey, 1, TypeCreateRecurringPayment, CreateRecurringPaymentData{
+		To:     types.Address{1},
+		Coin:   types.GetBaseCoinID(),
+		Value:  helpers.BipToPip(big.NewInt(10)),
+		Period: 0,
+		Times:  3,
+	})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongRecurringPayment {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongRecurringPayment, response.Log)
+	}
+}
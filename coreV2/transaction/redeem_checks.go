@@ -0,0 +1,448 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+	"golang.org/x/crypto/sha3"
+)
+
+const maxRedeemChecks = 20
+
+// RedeemChecksData redeems several checks of any issuers at once. Unlike RedeemCheckData, the single commission
+// of the transaction is paid by the sender in the gas coin of the transaction, coins of redeemed checks included.
+type RedeemChecksData struct {
+	Checks []RedeemCheckData
+}
+
+func (data RedeemChecksData) TxType() TxType {
+	return TypeRedeemChecks
+}
+
+func (data RedeemChecksData) Gas() int64 {
+	return gasRedeemCheck * int64(len(data.Checks))
+}
+
+// redeemedCheck is the decoded check of RedeemChecksData and its issuer
+type redeemedCheck struct {
+	check  *check.Check
+	issuer types.Address
+}
+
+func (data RedeemChecksData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) ([]redeemedCheck, *Response) {
+	if len(data.Checks) == 0 || len(data.Checks) > maxRedeemChecks {
+		return nil, &Response{
+			Code: code.WrongChecksCount,
+			Log:  fmt.Sprintf("Transaction should contain from 1 to %d checks", maxRedeemChecks),
+			Info: EncodeError(code.NewCustomCode(code.WrongChecksCount)),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	checks := make([]redeemedCheck, 0, len(data.Checks))
+	used := map[types.Hash]struct{}{}
+	for i, item := range data.Checks {
+		decodedCheck, issuer, response := item.decode(sender, context, currentBlock)
+		if response == nil {
+			if _, ok := used[decodedCheck.Hash()]; ok {
+				response = &Response{
+					Code: code.CheckUsed,
+					Log:  "Check already redeemed",
+					Info: EncodeError(code.NewCheckUsed()),
+				}
+			}
+		}
+		if response != nil {
+			response.Log = fmt.Sprintf("Check %d: %s", i, response.Log)
+			return nil, response
+		}
+
+		used[decodedCheck.Hash()] = struct{}{}
+		checks = append(checks, redeemedCheck{check: decodedCheck, issuer: issuer})
+	}
+
+	return checks, nil
+}
+
+func (data RedeemChecksData) String() string {
+	return fmt.Sprintf("REDEEM CHECKS checks:%d", len(data.Checks))
+}
+
+func (data RedeemChecksData) CommissionData(price *commission.Price) *big.Int {
+	sum := big.NewInt(0).Mul(price.RedeemCheck, big.NewInt(int64(len(data.Checks))))
+	sum.Mul(sum, big.NewInt(100-batchCommissionDiscount))
+	return sum.Div(sum, big.NewInt(100))
+}
+
+func (data RedeemChecksData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	checks, response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	// issuers can issue several checks of the same coin
+	needed := map[types.Address]map[types.CoinID]*big.Int{}
+	for _, item := range checks {
+		if needed[item.issuer] == nil {
+			needed[item.issuer] = map[types.CoinID]*big.Int{}
+		}
+		if needed[item.issuer][item.check.Coin] == nil {
+			needed[item.issuer][item.check.Coin] = big.NewInt(0)
+		}
+		value := needed[item.issuer][item.check.Coin].Add(needed[item.issuer][item.check.Coin], item.check.Value)
+
+		if checkState.Accounts().GetBalance(item.issuer, item.check.Coin).Cmp(value) < 0 {
+			coin := checkState.Coins().GetCoin(item.check.Coin)
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s. Wanted %s %s", item.issuer.String(), value.String(), coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(item.issuer.String(), value.String(), coin.GetFullSymbol(), coin.ID().String())),
+			}
+		}
+	}
+
+	balance := checkState.Accounts().GetBalance(sender, tx.GasCoin)
+	for _, item := range checks {
+		if item.check.Coin == tx.GasCoin && item.issuer != sender {
+			balance.Add(balance, item.check.Value)
+		}
+	}
+	if balance.Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		for _, item := range checks {
+			deliverState.Checks.UseCheck(item.check)
+			deliverState.Accounts.SubBalance(item.issuer, item.check.Coin, item.check.Value)
+			deliverState.Accounts.AddBalance(sender, item.check.Coin, item.check.Value)
+		}
+
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(sender[:])), Index: true},
+		}
+		for _, item := range checks {
+			tags = append(tags,
+				abcTypes.EventAttribute{Key: []byte("tx.check_issuer"), Value: []byte(hex.EncodeToString(item.issuer[:])), Index: true},
+				abcTypes.EventAttribute{Key: []byte("tx.coin_id"), Value: []byte(item.check.Coin.String()), Index: true},
+			)
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+// decode returns the check redeemable by the sender now and its issuer, funds of the issuer are not checked
+func (data RedeemCheckData) decode(sender types.Address, context *state.CheckState, currentBlock uint64) (*check.Check, types.Address, *Response) {
+	decodedCheck, err := check.DecodeFromBytes(data.RawCheck)
+	if err != nil {
+		return nil, types.Address{}, &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if decodedCheck.ChainID != types.CurrentChainID {
+		return nil, types.Address{}, &Response{
+			Code: code.WrongChainID,
+			Log:  "Wrong chain id",
+			Info: EncodeError(code.NewWrongChainID(fmt.Sprintf("%d", types.CurrentChainID), fmt.Sprintf("%d", decodedCheck.ChainID))),
+		}
+	}
+
+	if len(decodedCheck.Nonce) > 16 {
+		return nil, types.Address{}, &Response{
+			Code: code.TooLongNonce,
+			Log:  "Nonce is too big. Should be up to 16 bytes.",
+			Info: EncodeError(code.NewTooLongNonce(strconv.Itoa(len(decodedCheck.Nonce)), "16")),
+		}
+	}
+
+	issuer, err := decodedCheck.Sender()
+	if err != nil {
+		return nil, types.Address{}, &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !context.Coins().Exists(decodedCheck.Coin) {
+		return nil, types.Address{}, &Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", decodedCheck.Coin.String())),
+		}
+	}
+
+	if decodedCheck.DueBlock < currentBlock {
+		return nil, types.Address{}, &Response{
+			Code: code.CheckExpired,
+			Log:  "Check expired",
+			Info: EncodeError(code.MewCheckExpired(fmt.Sprintf("%d", decodedCheck.DueBlock), fmt.Sprintf("%d", currentBlock))),
+		}
+	}
+
+	if context.Checks().IsCheckUsed(decodedCheck) {
+		return nil, types.Address{}, &Response{
+			Code: code.CheckUsed,
+			Log:  "Check already redeemed",
+			Info: EncodeError(code.NewCheckUsed()),
+		}
+	}
+
+	lockPublicKey, err := decodedCheck.LockPubKey()
+	if err != nil {
+		return nil, types.Address{}, &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	var senderAddressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		sender,
+	})
+	hw.Sum(senderAddressHash[:0])
+
+	pub, err := crypto.Ecrecover(senderAddressHash[:], data.Proof[:])
+	if err != nil {
+		return nil, types.Address{}, &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !bytes.Equal(lockPublicKey, pub) {
+		return nil, types.Address{}, &Response{
+			Code: code.CheckInvalidLock,
+			Log:  "Invalid proof",
+			Info: EncodeError(code.NewCheckInvalidLock()),
+		}
+	}
+
+	return decodedCheck, issuer, nil
+}
+
+// CancelCheckData marks the check of the sender as used before its due block, so it can't be redeemed anymore
+type CancelCheckData struct {
+	RawCheck []byte
+}
+
+func (data CancelCheckData) TxType() TxType {
+	return TypeCancelCheck
+}
+
+func (data CancelCheckData) Gas() int64 {
+	return gasCancelCheck
+}
+
+func (data CancelCheckData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) (*check.Check, *Response) {
+	decodedCheck, err := check.DecodeFromBytes(data.RawCheck)
+	if err != nil {
+		return nil, &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if decodedCheck.ChainID != types.CurrentChainID {
+		return nil, &Response{
+			Code: code.WrongChainID,
+			Log:  "Wrong chain id",
+			Info: EncodeError(code.NewWrongChainID(fmt.Sprintf("%d", types.CurrentChainID), fmt.Sprintf("%d", decodedCheck.ChainID))),
+		}
+	}
+
+	issuer, err := decodedCheck.Sender()
+	if err != nil {
+		return nil, &Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if issuer != sender {
+		return nil, &Response{
+			Code: code.IsNotCheckIssuer,
+			Log:  "Sender is not the issuer of the check",
+			Info: EncodeError(code.NewIsNotCheckIssuer(issuer.String(), sender.String())),
+		}
+	}
+
+	if decodedCheck.DueBlock < currentBlock {
+		return nil, &Response{
+			Code: code.CheckExpired,
+			Log:  "Check expired",
+			Info: EncodeError(code.MewCheckExpired(fmt.Sprintf("%d", decodedCheck.DueBlock), fmt.Sprintf("%d", currentBlock))),
+		}
+	}
+
+	if context.Checks().IsCheckUsed(decodedCheck) {
+		return nil, &Response{
+			Code: code.CheckUsed,
+			Log:  "Check already redeemed",
+			Info: EncodeError(code.NewCheckUsed()),
+		}
+	}
+
+	return decodedCheck, nil
+}
+
+func (data CancelCheckData) String() string {
+	return fmt.Sprintf("CANCEL CHECK check:%x", data.RawCheck)
+}
+
+func (data CancelCheckData) CommissionData(price *commission.Price) *big.Int {
+	return price.Send
+}
+
+func (data CancelCheckData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	decodedCheck, response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		checkHash := decodedCheck.Hash()
+		deliverState.Checks.UseCheckHash(checkHash)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(checkHash[:])), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
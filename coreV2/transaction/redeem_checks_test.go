@@ -0,0 +1,187 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+func testRedeemCheckData(t *testing.T, issuerPrivateKey *ecdsa.PrivateKey, receiver types.Address, nonce []byte, value *big.Int) RedeemCheckData {
+	passphraseHash := sha256.Sum256([]byte("password"))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := c.Check{
+		Nonce:    nonce,
+		ChainID:  types.CurrentChainID,
+		DueBlock: 10,
+		Coin:     types.GetBaseCoinID(),
+		Value:    value,
+		GasCoin:  types.GetBaseCoinID(),
+	}
+
+	lock, err := crypto.Sign(check.HashWithoutLock().Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := check.Sign(issuerPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	rawCheck, err := rlp.EncodeToBytes(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var receiverAddressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		receiver,
+	})
+	hw.Sum(receiverAddressHash[:0])
+
+	sig, err := crypto.Sign(receiverAddressHash.Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := [65]byte{}
+	copy(proof[:], sig)
+
+	return RedeemCheckData{
+		RawCheck: rawCheck,
+		Proof:    proof,
+	}
+}
+
+func TestRedeemChecksTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuer1PrivateKey, _ := crypto.GenerateKey()
+	issuer1Addr := crypto.PubkeyToAddress(issuer1PrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuer1Addr, coin, helpers.BipToPip(big.NewInt(100)))
+
+	issuer2PrivateKey, _ := crypto.GenerateKey()
+	issuer2Addr := crypto.PubkeyToAddress(issuer2PrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuer2Addr, coin, helpers.BipToPip(big.NewInt(100)))
+
+	receiverPrivateKey, _ := crypto.GenerateKey()
+	receiverAddr := crypto.PubkeyToAddress(receiverPrivateKey.PublicKey)
+
+	checkValue := helpers.BipToPip(big.NewInt(10))
+	data := RedeemChecksData{
+		Checks: []RedeemCheckData{
+			testRedeemCheckData(t, issuer1PrivateKey, receiverAddr, []byte{1}, checkValue),
+			testRedeemCheckData(t, issuer2PrivateKey, receiverAddr, []byte{2}, checkValue),
+		},
+	}
+
+	response := NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, receiverPrivateKey, 1, TypeRedeemChecks, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	commission := big.NewInt(0).Mul(big.NewInt(0).Add(commissionPrice.RedeemCheck, commissionPrice.RedeemCheck), big.NewInt(100-batchCommissionDiscount))
+	commission.Div(commission, big.NewInt(100))
+	expected := big.NewInt(0).Sub(big.NewInt(0).Add(checkValue, checkValue), commission)
+	if balance := cState.Accounts.GetBalance(receiverAddr, coin); balance.Cmp(expected) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", receiverAddr.String(), expected, balance)
+	}
+
+	for _, issuer := range []types.Address{issuer1Addr, issuer2Addr} {
+		expected := helpers.BipToPip(big.NewInt(90))
+		if balance := cState.Accounts.GetBalance(issuer, coin); balance.Cmp(expected) != 0 {
+			t.Fatalf("Target %s balance is not correct. Expected %s, got %s", issuer.String(), expected, balance)
+		}
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, receiverPrivateKey, 2, TypeRedeemChecks, RedeemChecksData{Checks: data.Checks[1:]}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.CheckUsed {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckUsed, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRedeemChecksTxToDuplicatedCheck(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+	issuerAddr := crypto.PubkeyToAddress(issuerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuerAddr, coin, helpers.BipToPip(big.NewInt(100)))
+
+	receiverPrivateKey, _ := crypto.GenerateKey()
+	receiverAddr := crypto.PubkeyToAddress(receiverPrivateKey.PublicKey)
+
+	check := testRedeemCheckData(t, issuerPrivateKey, receiverAddr, []byte{1}, helpers.BipToPip(big.NewInt(10)))
+	data := RedeemChecksData{Checks: []RedeemCheckData{check, check}}
+
+	response := NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, receiverPrivateKey, 1, TypeRedeemChecks, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.CheckUsed {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckUsed, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, receiverPrivateKey, 1, TypeRedeemChecks, RedeemChecksData{}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongChecksCount {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongChecksCount, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCancelCheckTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerPrivateKey, _ := crypto.GenerateKey()
+	issuerAddr := crypto.PubkeyToAddress(issuerPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(issuerAddr, coin, helpers.BipToPip(big.NewInt(100)))
+
+	receiverPrivateKey, _ := crypto.GenerateKey()
+	receiverAddr := crypto.PubkeyToAddress(receiverPrivateKey.PublicKey)
+	cState.Accounts.AddBalance(receiverAddr, coin, helpers.BipToPip(big.NewInt(100)))
+
+	check := testRedeemCheckData(t, issuerPrivateKey, receiverAddr, []byte{1}, helpers.BipToPip(big.NewInt(10)))
+
+	response := NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, receiverPrivateKey, 1, TypeCancelCheck, CancelCheckData{RawCheck: check.RawCheck}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotCheckIssuer {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotCheckIssuer, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, issuerPrivateKey, 1, TypeCancelCheck, CancelCheckData{RawCheck: check.RawCheck}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, receiverPrivateKey, 1, TypeRedeemChecks, RedeemChecksData{Checks: []RedeemCheckData{check}}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.CheckUsed {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckUsed, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
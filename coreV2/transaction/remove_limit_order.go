@@ -151,6 +151,8 @@ func (data RemoveLimitOrderData) Run(tx *Transaction, context state.Interface, r
 			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
 			{Key: []byte("tx.order_id"), Value: []byte(strconv.Itoa(int(data.ID))), Index: true},
 			{Key: []byte("tx.pair_id"), Value: []byte(strconv.Itoa(int(swapper.GetID()))), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(coin.String())},
+			{Key: []byte("tx.return"), Value: []byte(volume.String())},
 		}
 	}
 
@@ -0,0 +1,126 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// checkFeePayer returns the fee payer of the sponsored transaction, who can't be its sender
+func checkFeePayer(tx *Transaction, sender types.Address) (types.Address, *Response) {
+	feePayer, err := tx.FeePayer()
+	if err != nil {
+		return types.Address{}, &Response{
+			Code: code.WrongFeePayer,
+			Log:  fmt.Sprintf("Incorrect fee payer signature: %s", err),
+			Info: EncodeError(code.NewCustomCode(code.WrongFeePayer)),
+		}
+	}
+
+	if feePayer == sender {
+		return types.Address{}, &Response{
+			Code: code.WrongFeePayer,
+			Log:  "Fee payer should differ from the sender",
+			Info: EncodeError(code.NewCustomCode(code.WrongFeePayer)),
+		}
+	}
+
+	// the commission of the check is paid by its issuer
+	if tx.Type == TypeRedeemCheck {
+		return types.Address{}, &Response{
+			Code: code.WrongFeePayer,
+			Log:  "Check redemption can't be sponsored",
+			Info: EncodeError(code.NewCustomCode(code.WrongFeePayer)),
+		}
+	}
+
+	return feePayer, nil
+}
+
+// runSponsored charges the commission of the transaction to the fee payer and runs the transaction of the sender
+// without commission. The transaction is checked before the commission is charged, so the fee payer pays only
+// for transactions which are going to succeed.
+func runSponsored(tx *Transaction, feePayer types.Address, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(feePayer, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for fee payer account: %s. Wanted %s %s", feePayer.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(feePayer.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	response := tx.decodedData.Run(tx, checkState, rewardPool, currentBlock, big.NewInt(0))
+	deliverState, isDeliver := context.(*state.State)
+	if response.Code != code.OK || !isDeliver {
+		return response
+	}
+
+	var tagsCom *tagPoolChange
+	if isGasCommissionFromPoolSwap {
+		var (
+			poolIDCom  uint32
+			detailsCom *swap.ChangeDetailsWithOrders
+			ownersCom  []*swap.OrderDetail
+		)
+		commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+		tagsCom = &tagPoolChange{
+			PoolID:   poolIDCom,
+			CoinIn:   tx.CommissionCoin(),
+			ValueIn:  commission.String(),
+			CoinOut:  types.GetBaseCoinID(),
+			ValueOut: commissionInBaseCoin.String(),
+			Orders:   detailsCom,
+		}
+		for _, value := range ownersCom {
+			deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+		}
+	} else if !tx.GasCoin.IsBaseCoin() {
+		deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+		deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+	}
+	deliverState.Accounts.SubBalance(feePayer, tx.GasCoin, commission)
+	rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+	response = tx.decodedData.Run(tx, deliverState, rewardPool, currentBlock, big.NewInt(0))
+	if response.Code != code.OK {
+		response.feeCharged = true
+		return response
+	}
+
+	tags := []abcTypes.EventAttribute{
+		{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+		{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+		{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+		{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+		{Key: []byte("tx.fee_payer"), Value: []byte(hex.EncodeToString(feePayer[:])), Index: true},
+	}
+	for _, tag := range response.Tags {
+		if !bytes.HasPrefix(tag.Key, []byte("tx.commission_")) {
+			tags = append(tags, tag)
+		}
+	}
+	response.Tags = tags
+
+	return response
+}
@@ -0,0 +1,132 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func sponsoredTestTx(t *testing.T, privateKey, feePayerKey *ecdsa.PrivateKey, nonce uint64, txType TxType, data interface{}) []byte {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSponsored,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	if feePayerKey != nil {
+		if err := tx.SignFeePayer(feePayerKey); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encodedTx
+}
+
+func TestSponsoredTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := createTestCoin(cState)
+	cState.Accounts.SubBalance(types.Address{}, coin, helpers.BipToPip(big.NewInt(100)))
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(100)))
+
+	feePayerKey, _ := crypto.GenerateKey()
+	feePayer := crypto.PubkeyToAddress(feePayerKey.PublicKey)
+	feePayerBalance := helpers.BipToPip(big.NewInt(1000000))
+	cState.Accounts.AddBalance(feePayer, types.GetBaseCoinID(), feePayerBalance)
+
+	to := types.Address{1}
+	value := helpers.BipToPip(big.NewInt(10))
+	encodedTx := sponsoredTestTx(t, privateKey, feePayerKey, 1, TypeSend, SendData{Coin: coin, To: to, Value: value})
+
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, coin); balance.Cmp(helpers.BipToPip(big.NewInt(90))) != 0 {
+		t.Errorf("Sender balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(90)), balance)
+	}
+	if balance := cState.Accounts.GetBalance(addr, types.GetBaseCoinID()); balance.Sign() != 0 {
+		t.Errorf("Sender paid the commission: %s", balance)
+	}
+	if balance := cState.Accounts.GetBalance(to, coin); balance.Cmp(value) != 0 {
+		t.Errorf("Recipient balance is not correct. Expected %s, got %s", value, balance)
+	}
+	if balance := cState.Accounts.GetBalance(feePayer, types.GetBaseCoinID()); balance.Cmp(feePayerBalance) != -1 {
+		t.Errorf("Fee payer didn't pay the commission, balance %s", balance)
+	}
+	if nonce := cState.Accounts.GetNonce(addr); nonce != 1 {
+		t.Errorf("Sender nonce is not correct. Expected 1, got %d", nonce)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSponsoredTxWrongFeePayer(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(100)))
+
+	feePayerKey, _ := crypto.GenerateKey()
+
+	data := SendData{Coin: types.GetBaseCoinID(), To: types.Address{1}, Value: helpers.BipToPip(big.NewInt(10))}
+
+	encodedTx := sponsoredTestTx(t, privateKey, nil, 1, TypeSend, data)
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongFeePayer {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongFeePayer, response.Log)
+	}
+
+	encodedTx = sponsoredTestTx(t, privateKey, privateKey, 1, TypeSend, data)
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongFeePayer {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongFeePayer, response.Log)
+	}
+
+	// the fee payer without coins
+	encodedTx = sponsoredTestTx(t, privateKey, feePayerKey, 1, TypeSend, data)
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error: %s", code.InsufficientFunds, response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(addr, types.GetBaseCoinID()); balance.Cmp(helpers.BipToPip(big.NewInt(100))) != 0 {
+		t.Errorf("Sender balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(100)), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
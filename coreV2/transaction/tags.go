@@ -0,0 +1,252 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// PoolChange is an exchange through the pool reported in the transaction tags
+type PoolChange struct {
+	PoolID   uint32
+	CoinIn   types.CoinID
+	ValueIn  *big.Int
+	CoinOut  types.CoinID
+	ValueOut *big.Int
+	Orders   []FilledOrder
+}
+
+// FilledOrder is a part of the limit order filled by the exchange through the pool
+type FilledOrder struct {
+	PoolID  uint32
+	OrderID uint32
+	Seller  types.Address
+	Buy     *big.Int
+	Sell    *big.Int
+}
+
+// tagPoolChangeJSON is the decoded value of tagPoolChange
+type tagPoolChangeJSON struct {
+	PoolID   uint32       `json:"pool_id"`
+	CoinIn   types.CoinID `json:"coin_in"`
+	ValueIn  string       `json:"value_in"`
+	CoinOut  types.CoinID `json:"coin_out"`
+	ValueOut string       `json:"value_out"`
+	Details  *struct {
+		Orders []struct {
+			Buy    string `json:"buy"`
+			Sell   string `json:"sell"`
+			Seller string `json:"seller"`
+			ID     uint32 `json:"id"`
+		} `json:"orders"`
+	} `json:"details"`
+}
+
+func (tag *tagPoolChangeJSON) poolChange() PoolChange {
+	change := PoolChange{
+		PoolID:   tag.PoolID,
+		CoinIn:   tag.CoinIn,
+		ValueIn:  stringToBigInt(tag.ValueIn),
+		CoinOut:  tag.CoinOut,
+		ValueOut: stringToBigInt(tag.ValueOut),
+	}
+	if tag.Details == nil {
+		return change
+	}
+
+	for _, order := range tag.Details.Orders {
+		change.Orders = append(change.Orders, FilledOrder{
+			PoolID:  tag.PoolID,
+			OrderID: order.ID,
+			Seller:  types.HexToAddress(order.Seller),
+			Buy:     stringToBigInt(order.Buy),
+			Sell:    stringToBigInt(order.Sell),
+		})
+	}
+	return change
+}
+
+func stringToBigInt(s string) *big.Int {
+	value, ok := big.NewInt(0).SetString(s, 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return value
+}
+
+// PoolChanges returns exchanges through the pools reported in the transaction tags,
+// including the exchange of the commission coin.
+func PoolChanges(tags []abcTypes.EventAttribute) []PoolChange {
+	var changes []PoolChange
+	for _, tag := range tags {
+		switch string(tag.Key) {
+		case "tx.pools":
+			var pools []*tagPoolChangeJSON
+			if err := json.Unmarshal(tag.Value, &pools); err != nil {
+				continue
+			}
+			for _, pool := range pools {
+				if pool != nil {
+					changes = append(changes, pool.poolChange())
+				}
+			}
+		case "tx.commission_details":
+			pool := &tagPoolChangeJSON{}
+			if err := json.Unmarshal(tag.Value, pool); err != nil {
+				continue
+			}
+			changes = append(changes, pool.poolChange())
+		}
+	}
+	return changes
+}
+
+// FilledOrders returns parts of the limit orders filled by the transaction from its tags
+func FilledOrders(tags []abcTypes.EventAttribute) []FilledOrder {
+	var orders []FilledOrder
+	for _, change := range PoolChanges(tags) {
+		orders = append(orders, change.Orders...)
+	}
+	return orders
+}
+
+// HTLCPayout returns the payee, the coin and the value paid out by the claim or the refund of the HTLC from its tags
+func HTLCPayout(tags []abcTypes.EventAttribute) (payee types.Address, coin types.CoinID, value *big.Int, ok bool) {
+	decoded, err := hex.DecodeString(tagValue(tags, "tx.to"))
+	if err != nil || len(decoded) != types.AddressLength {
+		return types.Address{}, 0, nil, false
+	}
+	id, ok := tagUint(tags, "tx.coin_id")
+	if !ok {
+		return types.Address{}, 0, nil, false
+	}
+	value, ok = big.NewInt(0).SetString(tagValue(tags, "tx.value"), 10)
+	if !ok {
+		return types.Address{}, 0, nil, false
+	}
+	return types.BytesToAddress(decoded), types.CoinID(id), value, true
+}
+
+// InvolvedAddresses returns addresses involved into the transaction without duplicates: the sender, the fee payer, multisig signers,
+// recipients including ones of batched transactions, vestings and payees of HTLCs, issuers of redeemed checks, addresses set by the transaction, the multisig of the proposal
+// and sellers of the filled limit orders.
+func InvolvedAddresses(tx *Transaction, tags []abcTypes.EventAttribute) []types.Address {
+	var addresses []types.Address
+	used := map[types.Address]struct{}{}
+	add := func(address types.Address) {
+		if _, ok := used[address]; ok {
+			return
+		}
+		used[address] = struct{}{}
+		addresses = append(addresses, address)
+	}
+
+	if sender, err := tx.Sender(); err == nil {
+		add(sender)
+	}
+
+	if tx.SignatureType == SigTypeSponsored && tx.sponsored != nil {
+		if feePayer, err := tx.FeePayer(); err == nil {
+			add(feePayer)
+		}
+	}
+
+	if tx.SignatureType == SigTypeMulti && tx.multisig != nil {
+		txHash := tx.Hash()
+		for _, sig := range tx.multisig.Signatures {
+			if signer, err := RecoverPlain(txHash, sig.R, sig.S, sig.V); err == nil {
+				add(signer)
+			}
+		}
+	}
+
+	addDataAddresses(tx.decodedData, add)
+
+	switch tx.decodedData.(type) {
+	case *ClaimHTLCData, *RefundHTLCData:
+		if payee, _, _, ok := HTLCPayout(tags); ok {
+			add(payee)
+		}
+	}
+
+	for _, tag := range tags {
+		if string(tag.Key) != "tx.created_multisig" && string(tag.Key) != "tx.multisig" {
+			continue
+		}
+		if decoded, err := hex.DecodeString(string(tag.Value)); err == nil && len(decoded) == types.AddressLength {
+			add(types.BytesToAddress(decoded))
+		}
+	}
+
+	for _, order := range FilledOrders(tags) {
+		add(order.Seller)
+	}
+
+	return addresses
+}
+
+// addDataAddresses adds addresses set by the data of the transaction, transactions of the batch are unpacked
+func addDataAddresses(data Data, add func(address types.Address)) {
+	switch data := data.(type) {
+	case *BatchData:
+		txs, _ := data.DecodedTxs()
+		for _, d := range txs {
+			addDataAddresses(d, add)
+		}
+	case *SendData:
+		add(data.To)
+	case *MultisendData:
+		for _, item := range data.List {
+			add(item.To)
+		}
+	case *RedeemCheckData:
+		if decodedCheck, err := check.DecodeFromBytes(data.RawCheck); err == nil {
+			if issuer, err := decodedCheck.Sender(); err == nil {
+				add(issuer)
+			}
+		}
+	case *DeclareCandidacyData:
+		add(data.Address)
+	case *EditCandidateData:
+		add(data.RewardAddress)
+		add(data.OwnerAddress)
+		add(data.ControlAddress)
+	case *CreateMultisigData:
+		for _, address := range data.Addresses {
+			add(address)
+		}
+	case *EditMultisigData:
+		for _, address := range data.Addresses {
+			add(address)
+		}
+	case *EditCoinOwnerData:
+		add(data.NewOwner)
+	case *ApproveData:
+		add(data.Spender)
+	case *RevokeData:
+		add(data.Spender)
+	case *TransferFromData:
+		add(data.From)
+		add(data.To)
+	case *CreateRecurringPaymentData:
+		add(data.To)
+	case *VestingData:
+		add(data.To)
+	case *CreateHTLCData:
+		add(data.Recipient)
+	case *CreateMultisigProposalData:
+		add(data.Multisig)
+	case *RedeemChecksData:
+		for _, item := range data.Checks {
+			if decodedCheck, err := check.DecodeFromBytes(item.RawCheck); err == nil {
+				if issuer, err := decodedCheck.Sender(); err == nil {
+					add(issuer)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestInvolvedAddresses(t *testing.T) {
+	t.Parallel()
+	privateKey, addr := getAccount()
+	coin := types.GetBaseCoinID()
+	to := types.Address{1}
+	seller := types.HexToAddress("Mx04bea23efb744dc93b4fda4c20bf4a21c6e195f1")
+
+	data := MultisendData{
+		List: []MultisendDataItem{
+			{Coin: coin, To: to, Value: big.NewInt(1)},
+			{Coin: coin, To: addr, Value: big.NewInt(1)},
+		},
+	}
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		GasCoin:       coin,
+		ChainID:       types.CurrentChainID,
+		Type:          TypeMultisend,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+		decodedData:   &data,
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	tags := []abcTypes.EventAttribute{
+		{Key: []byte("tx.commission_details"), Value: []byte(`{"pool_id":1,"coin_in":1,"value_in":"10","coin_out":0,"value_out":"5","details":{"orders":[{"buy":"2","sell":"1","seller":"` + seller.String() + `","id":7}]}}`)},
+	}
+
+	addresses := InvolvedAddresses(&tx, tags)
+	if len(addresses) != 3 || addresses[0] != addr || addresses[1] != to || addresses[2] != seller {
+		t.Fatalf("unexpected addresses %v", addresses)
+	}
+
+	orders := FilledOrders(tags)
+	if len(orders) != 1 || orders[0].OrderID != 7 || orders[0].PoolID != 1 || orders[0].Buy.String() != "2" {
+		t.Fatalf("unexpected orders %#v", orders)
+	}
+}
+
+func TestInvolvedAddressesBatch(t *testing.T) {
+	t.Parallel()
+	privateKey, addr := getAccount()
+	coin := types.GetBaseCoinID()
+
+	data := batchData(t,
+		&SendData{Coin: coin, To: types.Address{1}, Value: big.NewInt(1)},
+		&AddLimitOrderData{CoinToSell: coin, ValueToSell: big.NewInt(1), CoinToBuy: 1, ValueToBuy: big.NewInt(1)},
+		&SendData{Coin: coin, To: types.Address{2}, Value: big.NewInt(1)},
+	)
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		GasCoin:       coin,
+		ChainID:       types.CurrentChainID,
+		Type:          TypeBatch,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+		decodedData:   &data,
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	addresses := InvolvedAddresses(&tx, nil)
+	if len(addresses) != 3 || addresses[0] != addr || addresses[1] != (types.Address{1}) || addresses[2] != (types.Address{2}) {
+		t.Fatalf("unexpected addresses %v", addresses)
+	}
+}
+
+func TestInvolvedAddressesHTLC(t *testing.T) {
+	t.Parallel()
+	privateKey, addr := getAccount()
+	coin := types.GetBaseCoinID()
+	recipient, payee := types.Address{1}, types.Address{2}
+
+	newTx := func(txType TxType, data Data) *Transaction {
+		encodedData, err := rlp.EncodeToBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := &Transaction{
+			Nonce:         1,
+			GasPrice:      1,
+			GasCoin:       coin,
+			ChainID:       types.CurrentChainID,
+			Type:          txType,
+			Data:          encodedData,
+			SignatureType: SigTypeSingle,
+			decodedData:   data,
+		}
+		if err := tx.Sign(privateKey); err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	addresses := InvolvedAddresses(newTx(TypeCreateHTLC, &CreateHTLCData{Recipient: recipient, Coin: coin, Value: big.NewInt(1), Timeout: 10}), nil)
+	if len(addresses) != 2 || addresses[0] != addr || addresses[1] != recipient {
+		t.Fatalf("unexpected addresses %v", addresses)
+	}
+
+	tags := []abcTypes.EventAttribute{
+		{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(payee[:]))},
+		{Key: []byte("tx.coin_id"), Value: []byte("0")},
+		{Key: []byte("tx.value"), Value: []byte("10")},
+	}
+	addresses = InvolvedAddresses(newTx(TypeRefundHTLC, &RefundHTLCData{}), tags)
+	if len(addresses) != 2 || addresses[0] != addr || addresses[1] != payee {
+		t.Fatalf("unexpected addresses %v", addresses)
+	}
+
+	if to, c, value, ok := HTLCPayout(tags); !ok || to != payee || c != coin || value.String() != "10" {
+		t.Fatalf("unexpected payout %s %s %s", to, c, value)
+	}
+}
+
+func TestInvolvedAddressesVesting(t *testing.T) {
+	t.Parallel()
+	privateKey, addr := getAccount()
+	coin := types.GetBaseCoinID()
+	recipient := types.Address{1}
+
+	data := &VestingData{To: recipient, Coin: coin, Value: big.NewInt(1), CliffBlock: 10, EndBlock: 20, Steps: 2}
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := &Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		GasCoin:       coin,
+		ChainID:       types.CurrentChainID,
+		Type:          TypeVesting,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+		decodedData:   data,
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	addresses := InvolvedAddresses(tx, nil)
+	if len(addresses) != 2 || addresses[0] != addr || addresses[1] != recipient {
+		t.Fatalf("unexpected addresses %v", addresses)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/code"
@@ -66,6 +67,24 @@ const (
 	TypeRemoveLimitOrder        TxType = 0x24
 	TypeLockStake               TxType = 0x25
 	TypeLock                    TxType = 0x26
+	TypeBatch                   TxType = 0x27
+	TypeCreateHTLC              TxType = 0x28
+	TypeClaimHTLC               TxType = 0x29
+	TypeRefundHTLC              TxType = 0x2A
+	TypeVesting                 TxType = 0x2B
+	TypeApprove                 TxType = 0x2C
+	TypeRevoke                  TxType = 0x2D
+	TypeTransferFrom            TxType = 0x2E
+	TypeCreateRecurringPayment  TxType = 0x2F
+	TypeCancelRecurringPayment  TxType = 0x30
+	TypeCreateMultisigProposal  TxType = 0x31
+	TypeApproveMultisigProposal TxType = 0x32
+	TypeExecuteMultisigProposal TxType = 0x33
+	TypeCancelMultisigProposal  TxType = 0x34
+	TypeRedeemChecks            TxType = 0x35
+	TypeCancelCheck             TxType = 0x36
+	TypeProposeParams           TxType = 0x37
+	TypeVoteParams              TxType = 0x38
 )
 
 const (
@@ -100,6 +119,7 @@ const (
 	gasBurnToken = 1
 
 	gasRedeemCheck = 20
+	gasCancelCheck = 2
 
 	gasDeclareCandidacy = 10
 	gasDelegate         = 6
@@ -107,6 +127,22 @@ const (
 	gasMoveStake        = 6
 	gasLockStake        = 2
 	gasLock             = 2
+	gasBatch            = 2
+	gasCreateHTLC       = 2
+	gasClaimHTLC        = 2
+	gasRefundHTLC       = 2
+	gasVesting          = 2
+	gasApprove          = 2
+	gasRevoke           = 2
+	gasTransferFrom     = 2
+
+	gasCreateRecurringPayment = 2
+	gasCancelRecurringPayment = 2
+
+	gasCreateMultisigProposal  = 2
+	gasApproveMultisigProposal = 2
+	gasExecuteMultisigProposal = 2
+	gasCancelMultisigProposal  = 2
 
 	gasSetCandidateOnline      = 1
 	gasSetCandidateOffline     = 1
@@ -120,6 +156,8 @@ const (
 	gasSetHaltBlock   = 5
 	gasVoteCommission = 5
 	gasVoteUpdate     = 5
+	gasProposeParams  = 5
+	gasVoteParams     = 5
 )
 
 type SigType byte
@@ -127,6 +165,8 @@ type SigType byte
 const (
 	SigTypeSingle SigType = 0x01
 	SigTypeMulti  SigType = 0x02
+	// SigTypeSponsored is signed by the sender and by the fee payer, who pays the commission of the transaction
+	SigTypeSponsored SigType = 0x03
 )
 
 var (
@@ -144,10 +184,14 @@ type Transaction struct {
 	ServiceData   []byte
 	SignatureType SigType
 	SignatureData []byte
+	// ValidUntil is the last block the transaction can be included in, zero means the transaction never expires.
+	// The transaction with it is encoded as the version 2 list with the additional last field, see EncodeRLP
+	ValidUntil uint64
 
 	decodedData Data
 	sig         *Signature
 	multisig    *SignatureMulti
+	sponsored   *SignatureSponsored
 	sender      *types.Address
 }
 
@@ -162,8 +206,31 @@ type SignatureMulti struct {
 	Signatures []Signature
 }
 
+// SignatureSponsored contains the signature of the sender over the transaction hash
+// and the signature of the fee payer over FeePayerHash
+type SignatureSponsored struct {
+	Sender   Signature
+	FeePayer Signature
+}
+
 type RawData []byte
 
+// transactionRLP is the encoding of the transaction: the version 1 list of ten fields
+// or the version 2 list with ValidUntil in the extension
+type transactionRLP struct {
+	Nonce         uint64
+	ChainID       types.ChainID
+	GasPrice      uint32
+	GasCoin       types.CoinID
+	Type          TxType
+	Data          RawData
+	Payload       []byte
+	ServiceData   []byte
+	SignatureType SigType
+	SignatureData []byte
+	Extension     []uint64 `rlp:"tail"`
+}
+
 type totalSpends []totalSpend
 
 func (tss *totalSpends) Add(coin types.CoinID, value *big.Int) {
@@ -202,6 +269,61 @@ type Data interface {
 	Gas() int64
 }
 
+func (tx Transaction) EncodeRLP(w io.Writer) error {
+	enc := transactionRLP{
+		Nonce:         tx.Nonce,
+		ChainID:       tx.ChainID,
+		GasPrice:      tx.GasPrice,
+		GasCoin:       tx.GasCoin,
+		Type:          tx.Type,
+		Data:          tx.Data,
+		Payload:       tx.Payload,
+		ServiceData:   tx.ServiceData,
+		SignatureType: tx.SignatureType,
+		SignatureData: tx.SignatureData,
+	}
+	if tx.ValidUntil != 0 {
+		enc.Extension = []uint64{tx.ValidUntil}
+	}
+
+	return rlp.Encode(w, &enc)
+}
+
+func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
+	var dec transactionRLP
+	if err := s.Decode(&dec); err != nil {
+		return err
+	}
+
+	var validUntil uint64
+	switch len(dec.Extension) {
+	case 0:
+	case 1:
+		validUntil = dec.Extension[0]
+		if validUntil == 0 {
+			return errors.New("valid until block of the transaction should be positive")
+		}
+	default:
+		return errors.New("unknown transaction encoding version")
+	}
+
+	*tx = Transaction{
+		Nonce:         dec.Nonce,
+		ChainID:       dec.ChainID,
+		GasPrice:      dec.GasPrice,
+		GasCoin:       dec.GasCoin,
+		Type:          dec.Type,
+		Data:          dec.Data,
+		Payload:       dec.Payload,
+		ServiceData:   dec.ServiceData,
+		SignatureType: dec.SignatureType,
+		SignatureData: dec.SignatureData,
+		ValidUntil:    validUntil,
+	}
+
+	return nil
+}
+
 func (tx *Transaction) Serialize() ([]byte, error) {
 	return rlp.EncodeToBytes(tx)
 }
@@ -217,6 +339,9 @@ func (tx *Transaction) Gas() int64 {
 	if tx.SignatureType == SigTypeMulti {
 		base += int64(len(tx.multisig.Signatures)) * gasSign
 	}
+	if tx.SignatureType == SigTypeSponsored {
+		base += gasSign
+	}
 	return base + tx.decodedData.Gas()
 }
 
@@ -271,6 +396,28 @@ func (tx *Transaction) SetSignature(sig []byte) {
 				panic(err)
 			}
 
+			tx.SignatureData = data
+		}
+	case SigTypeSponsored:
+		{
+			if tx.sponsored == nil {
+				tx.sponsored = newSignatureSponsored()
+			}
+
+			tx.sponsored.Sender = Signature{
+				V: new(big.Int).SetBytes([]byte{sig[64] + 27}),
+				R: new(big.Int).SetBytes(sig[:32]),
+				S: new(big.Int).SetBytes(sig[32:64]),
+			}
+			tx.sig = &tx.sponsored.Sender
+			tx.sender = nil
+
+			data, err := rlp.EncodeToBytes(tx.sponsored)
+
+			if err != nil {
+				panic(err)
+			}
+
 			tx.SignatureData = data
 		}
 	case SigTypeMulti:
@@ -299,6 +446,77 @@ func (tx *Transaction) SetSignature(sig []byte) {
 	}
 }
 
+// SignFeePayer adds the signature of the fee payer to the transaction signed by the sender
+func (tx *Transaction) SignFeePayer(prv *ecdsa.PrivateKey) error {
+	h, err := tx.FeePayerHash()
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.Sign(h[:], prv)
+	if err != nil {
+		return err
+	}
+
+	tx.SetFeePayerSignature(sig)
+
+	return nil
+}
+
+func (tx *Transaction) SetFeePayerSignature(sig []byte) {
+	if tx.sponsored == nil {
+		tx.sponsored = newSignatureSponsored()
+	}
+
+	tx.sponsored.FeePayer = Signature{
+		V: new(big.Int).SetBytes([]byte{sig[64] + 27}),
+		R: new(big.Int).SetBytes(sig[:32]),
+		S: new(big.Int).SetBytes(sig[32:64]),
+	}
+
+	data, err := rlp.EncodeToBytes(tx.sponsored)
+
+	if err != nil {
+		panic(err)
+	}
+
+	tx.SignatureData = data
+}
+
+// FeePayerHash returns the hash signed by the fee payer, it includes the sender,
+// so the signature of the fee payer can't be used as the signature of the sender
+func (tx *Transaction) FeePayerHash() (types.Hash, error) {
+	sender, err := tx.Sender()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return rlpHash([]interface{}{
+		tx.Hash(),
+		sender,
+	}), nil
+}
+
+// FeePayer returns the address which pays the commission of the transaction
+func (tx *Transaction) FeePayer() (types.Address, error) {
+	if tx.SignatureType != SigTypeSponsored {
+		return tx.Sender()
+	}
+
+	h, err := tx.FeePayerHash()
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	return RecoverPlain(h, tx.sponsored.FeePayer.R, tx.sponsored.FeePayer.S, tx.sponsored.FeePayer.V)
+}
+
+func newSignatureSponsored() *SignatureSponsored {
+	return &SignatureSponsored{
+		Sender:   Signature{V: big.NewInt(0), R: big.NewInt(0), S: big.NewInt(0)},
+		FeePayer: Signature{V: big.NewInt(0), R: big.NewInt(0), S: big.NewInt(0)},
+	}
+}
+
 func (tx *Transaction) MustSender() types.Address {
 	sender, err := tx.Sender()
 	if err != nil {
@@ -312,7 +530,7 @@ func (tx *Transaction) Sender() (types.Address, error) {
 	}
 
 	switch tx.SignatureType {
-	case SigTypeSingle:
+	case SigTypeSingle, SigTypeSponsored:
 		sender, err := RecoverPlain(tx.Hash(), tx.sig.R, tx.sig.S, tx.sig.V)
 		if err != nil {
 			return types.Address{}, err
@@ -328,7 +546,7 @@ func (tx *Transaction) Sender() (types.Address, error) {
 }
 
 func (tx *Transaction) Hash() types.Hash {
-	return rlpHash([]interface{}{
+	fields := []interface{}{
 		tx.Nonce,
 		tx.ChainID,
 		tx.GasPrice,
@@ -338,7 +556,12 @@ func (tx *Transaction) Hash() types.Hash {
 		tx.Payload,
 		tx.ServiceData,
 		tx.SignatureType,
-	})
+	}
+	if tx.ValidUntil != 0 {
+		fields = append(fields, tx.ValidUntil)
+	}
+
+	return rlpHash(fields)
 }
 
 func (tx *Transaction) SetDecodedData(data Data) {
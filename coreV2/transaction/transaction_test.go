@@ -5,6 +5,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	"github.com/MinterTeam/minter-go-node/crypto"
 	"github.com/MinterTeam/minter-go-node/helpers"
@@ -214,3 +215,119 @@ func TestCommissionFromPool(t *testing.T) {
 		}
 	}
 }
+
+func TestTransactionValidUntil(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	encodedData, err := rlp.EncodeToBytes(SendData{Coin: types.GetBaseCoinID(), To: types.Address{1}, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          TypeSend,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+	hashV1 := tx.Hash()
+
+	tx.ValidUntil = 10
+	if tx.Hash() == hashV1 {
+		t.Fatal("Valid until block is not signed")
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decodedTx, err := NewExecutorV340(GetDataV340).DecodeFromBytes(encodedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decodedTx.ValidUntil != 10 {
+		t.Fatalf("Valid until block is not decoded, got %d", decodedTx.ValidUntil)
+	}
+	if sender, _ := decodedTx.Sender(); sender != addr {
+		t.Fatalf("Sender is not correct, got %s", sender.String())
+	}
+
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 11, &sync.Map{}, 0, false)
+	if response.Code != code.TxExpired {
+		t.Fatalf("Response code is not %d. Error: %s", code.TxExpired, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 10, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	// the explicit zero is not a canonical encoding
+	encodedTx, err = rlp.EncodeToBytes(transactionRLP{Nonce: 2, Data: encodedData, Type: TypeSend, SignatureType: SigTypeSingle, Extension: []uint64{0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewExecutorV340(GetDataV340).DecodeFromBytesWithoutSig(encodedTx); err == nil {
+		t.Fatal("Transaction with zero valid until block is decoded")
+	}
+}
+
+func TestExecutorV3RejectsTransactionsOfV340(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	feePayerKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	sendData := SendData{Coin: types.GetBaseCoinID(), To: types.Address{1}, Value: big.NewInt(1)}
+	encodedData, err := rlp.EncodeToBytes(sendData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          TypeSend,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+		ValidUntil:    10,
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	validUntilTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, rawTx := range map[string][]byte{
+		"valid until": validUntilTx,
+		"sponsored":   sponsoredTestTx(t, privateKey, feePayerKey, 1, TypeSend, sendData),
+		"htlc":        signedTestTx(t, privateKey, 1, TypeCreateHTLC, CreateHTLCData{Recipient: types.Address{1}, Coin: types.GetBaseCoinID(), Value: big.NewInt(1), Timeout: 10}),
+	} {
+		response := NewExecutorV3(GetDataV3).RunTx(cState, rawTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+		if response.Code != code.DecodeError {
+			t.Errorf("%s: response code is not %d. Error: %s", name, code.DecodeError, response.Log)
+		}
+	}
+}
@@ -128,7 +128,7 @@ func (data UnbondData) Run(tx *Transaction, context state.Interface, rewardPool
 	var tags []abcTypes.EventAttribute
 	if deliverState, ok := context.(*state.State); ok {
 		// now + 30 days
-		unbondAtBlock := currentBlock + types.GetUnbondPeriod()
+		unbondAtBlock := currentBlock + checkState.Params().GetUnbondPeriod()
 
 		var tagsCom *tagPoolChange
 		if isGasCommissionFromPoolSwap {
@@ -120,7 +120,7 @@ func (data UnbondDataV260) Run(tx *Transaction, context state.Interface, rewardP
 	var tags []abcTypes.EventAttribute
 	if deliverState, ok := context.(*state.State); ok {
 		// now + 30 days
-		unbondAtBlock := currentBlock + types.GetUnbondPeriod()
+		unbondAtBlock := currentBlock + checkState.Params().GetUnbondPeriod()
 
 		var tagsCom *tagPoolChange
 		if isGasCommissionFromPoolSwap {
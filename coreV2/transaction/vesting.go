@@ -0,0 +1,190 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/vesting"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// VestingData sends the value to the recipient locked by the vesting schedule starting at the current block:
+// nothing is spendable before CliffBlock, then the spendable part grows linearly until EndBlock,
+// per block or by Steps equal parts if Steps is not zero
+type VestingData struct {
+	To         types.Address
+	Coin       types.CoinID
+	Value      *big.Int
+	CliffBlock uint64
+	EndBlock   uint64
+	Steps      uint64
+}
+
+func (data VestingData) TxType() TxType {
+	return TypeVesting
+}
+
+func (data VestingData) Gas() int64 {
+	return gasVesting
+}
+
+func (data VestingData) schedule(currentBlock uint64) *vesting.Schedule {
+	return &vesting.Schedule{
+		Coin:        data.Coin,
+		Value:       data.Value,
+		StartHeight: currentBlock,
+		CliffHeight: data.CliffBlock,
+		EndHeight:   data.EndBlock,
+		Steps:       data.Steps,
+	}
+}
+
+func (data VestingData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) *Response {
+	if data.EndBlock <= currentBlock {
+		return &Response{
+			Code: code.WrongDueHeight,
+			Log:  fmt.Sprintf("Current height is higher than the end of vesting"),
+			Info: EncodeError(code.NewCustomCode(code.WrongDueHeight)),
+		}
+	}
+
+	if !data.schedule(currentBlock).IsValid() {
+		return &Response{
+			Code: code.WrongVestingSchedule,
+			Log:  fmt.Sprintf("Wrong vesting schedule: value should be positive, cliff block between the current and the end block and steps not more than blocks until the end"),
+			Info: EncodeError(code.NewWrongVestingSchedule(strconv.FormatUint(data.CliffBlock, 10), strconv.FormatUint(data.EndBlock, 10), strconv.FormatUint(data.Steps, 10))),
+		}
+	}
+
+	// schedules are kept for each vested address, so the recipient can't be flooded by dust schedules
+	unfinished := 0
+	for _, schedule := range context.Vestings().GetSchedules(data.To) {
+		if !schedule.Finished(currentBlock) {
+			unfinished++
+		}
+	}
+	if unfinished >= vesting.MaxSchedules {
+		return &Response{
+			Code: code.TooManyVestingSchedules,
+			Log:  fmt.Sprintf("Recipient %s has too many unfinished vesting schedules, max %d", data.To.String(), vesting.MaxSchedules),
+			Info: EncodeError(code.NewTooManyVestingSchedules(data.To.String(), strconv.Itoa(vesting.MaxSchedules))),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  fmt.Sprintf("Coin %s not exists", data.Coin),
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data VestingData) String() string {
+	return fmt.Sprintf("VESTING to:%s coin:%s value:%s cliff:%d end:%d steps:%d",
+		data.To.String(), data.Coin.String(), data.Value.String(), data.CliffBlock, data.EndBlock, data.Steps)
+}
+
+func (data VestingData) CommissionData(price *commission.Price) *big.Int {
+	return price.Lock
+}
+
+func (data VestingData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	needValue := big.NewInt(0).Set(commission)
+	if tx.GasCoin == data.Coin {
+		needValue.Add(data.Value, needValue)
+	} else {
+		if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(data.Value) < 0 {
+			coin := checkState.Coins().GetCoin(data.Coin)
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), data.Value.String(), coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), data.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+			}
+		}
+	}
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(needValue) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), needValue.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), needValue.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, data.Coin, data.Value)
+
+		deliverState.Accounts.AddBalance(data.To, data.Coin, data.Value)
+		deliverState.Vestings.AddSchedule(data.To, data.Coin, data.Value, currentBlock, data.CliffBlock, data.EndBlock, data.Steps)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(data.To[:])), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,154 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/vesting"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestVestingTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	recipientKey, _ := crypto.GenerateKey()
+	recipient := crypto.PubkeyToAddress(recipientKey.PublicKey)
+	cState.Accounts.AddBalance(recipient, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(10)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	encodedTx := signedTestTx(t, privateKey, 1, TypeVesting, VestingData{
+		To:         recipient,
+		Coin:       types.GetBaseCoinID(),
+		Value:      value,
+		CliffBlock: 3,
+		EndBlock:   5,
+	})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(recipient, types.GetBaseCoinID()); balance.Cmp(helpers.BipToPip(big.NewInt(110))) != 0 {
+		t.Fatalf("Recipient balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(110)), balance)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	if balance := state.NewCheckState(cState).Accounts().GetBalance(recipient, types.GetBaseCoinID()); balance.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Fatalf("Spendable balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(10)), balance)
+	}
+
+	if balances := state.NewCheckState(cState).Accounts().GetBalances(recipient); len(balances) != 1 || balances[0].Value.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Fatalf("Spendable balances are not correct: %+v", balances)
+	}
+
+	if balances := state.NewCheckState(cState).AccountsWithLocked().GetBalances(recipient); len(balances) != 1 || balances[0].Value.Cmp(helpers.BipToPip(big.NewInt(110))) != 0 {
+		t.Fatalf("Balances are not correct: %+v", balances)
+	}
+
+	sendValue := helpers.BipToPip(big.NewInt(40))
+	encodedTx = signedTestTx(t, recipientKey, 1, TypeSend, SendData{Coin: types.GetBaseCoinID(), To: addr, Value: sendValue})
+	response = NewExecutorV340(GetDataV340).RunTx(state.NewCheckState(cState), encodedTx, big.NewInt(0), 2, &sync.Map{}, 0, false)
+	if response.Code != code.InsufficientFunds {
+		t.Fatalf("Response code is not %d. Error: %s", code.InsufficientFunds, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	// a half of the value is vested after the cliff
+	if balance := state.NewCheckState(cState).Accounts().GetBalance(recipient, types.GetBaseCoinID()); balance.Cmp(helpers.BipToPip(big.NewInt(60))) != 0 {
+		t.Fatalf("Spendable balance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(60)), balance)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 3, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVestingTxWrongSchedule(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	encodedTx := signedTestTx(t, privateKey, 1, TypeVesting, VestingData{
+		To:         types.Address{1},
+		Coin:       types.GetBaseCoinID(),
+		Value:      helpers.BipToPip(big.NewInt(100)),
+		CliffBlock: 20,
+		EndBlock:   10,
+	})
+	response := NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongVestingSchedule {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongVestingSchedule, response.Log)
+	}
+
+	encodedTx = signedTestTx(t, privateKey, 1, TypeVesting, VestingData{
+		To:       types.Address{1},
+		Coin:     types.GetBaseCoinID(),
+		Value:    helpers.BipToPip(big.NewInt(100)),
+		EndBlock: 1,
+	})
+	response = NewExecutorV340(GetDataV340).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongDueHeight {
+		t.Fatalf("Response code is not %d. Error: %s", code.WrongDueHeight, response.Log)
+	}
+}
+
+func TestVestingTxTooManySchedules(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	recipient := types.Address{1}
+	for i := 0; i < vesting.MaxSchedules; i++ {
+		cState.Vestings.AddSchedule(recipient, types.GetBaseCoinID(), big.NewInt(1), 1, 5, 10, 0)
+	}
+
+	data := VestingData{
+		To:         recipient,
+		Coin:       types.GetBaseCoinID(),
+		Value:      big.NewInt(1),
+		CliffBlock: 5,
+		EndBlock:   10,
+	}
+	response := NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey, 1, TypeVesting, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.TooManyVestingSchedules {
+		t.Fatalf("Response code is not %d. Error: %s", code.TooManyVestingSchedules, response.Log)
+	}
+
+	// finished schedules are not counted
+	data.CliffBlock, data.EndBlock = 15, 20
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey, 1, TypeVesting, data), big.NewInt(0), 10, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error: %s", response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+}
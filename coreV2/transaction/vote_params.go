@@ -0,0 +1,214 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// ProposeParamsData proposes the new value of the network parameter voted at the height, the proposal counts the vote of the validator.
+// The value is applied at the end of the block of the Height once validators with more than 2/3 of voting power vote for it.
+type ProposeParamsData struct {
+	PubKey types.Pubkey
+	Height uint64
+	Name   string
+	Value  string
+}
+
+func (data ProposeParamsData) Gas() int64 {
+	return gasProposeParams
+}
+
+func (data ProposeParamsData) TxType() TxType {
+	return TypeProposeParams
+}
+
+func (data ProposeParamsData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data ProposeParamsData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	if err := params.Validate(data.Name, data.Value); err != nil {
+		return &Response{
+			Code: code.WrongParam,
+			Log:  err.Error(),
+			Info: EncodeError(code.NewWrongParam(data.Name, data.Value)),
+		}
+	}
+
+	if context.Params().GetProposal(data.Height, data.Name, data.Value) != nil {
+		return &Response{
+			Code: code.ParamsProposalExists,
+			Log:  "Proposal of such value of the parameter at the height already exists",
+			Info: EncodeError(code.NewParamsProposalExists(strconv.FormatUint(data.Height, 10), data.Name, data.Value)),
+		}
+	}
+
+	return checkParamsVote(data, tx, context, block, data.Height, data.Name)
+}
+
+func (data ProposeParamsData) String() string {
+	return fmt.Sprintf("PROPOSE PARAMS %s: %s on height: %d", data.Name, data.Value, data.Height)
+}
+
+func (data ProposeParamsData) CommissionData(price *commission.Price) *big.Int {
+	return price.VoteUpdate
+}
+
+func (data ProposeParamsData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return runParamsVote(tx, context, rewardPool, currentBlock, price, data.basicCheck, data.PubKey, data.Height, data.Name, data.Value)
+}
+
+// VoteParamsData votes for the proposed value of the network parameter, a validator votes for one value of the parameter at the height
+type VoteParamsData struct {
+	PubKey types.Pubkey
+	Height uint64
+	Name   string
+	Value  string
+}
+
+func (data VoteParamsData) Gas() int64 {
+	return gasVoteParams
+}
+
+func (data VoteParamsData) TxType() TxType {
+	return TypeVoteParams
+}
+
+func (data VoteParamsData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data VoteParamsData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	if context.Params().GetProposal(data.Height, data.Name, data.Value) == nil {
+		return &Response{
+			Code: code.ParamsProposalNotExists,
+			Log:  "Proposal of such value of the parameter at the height not found",
+			Info: EncodeError(code.NewParamsProposalNotExists(strconv.FormatUint(data.Height, 10), data.Name, data.Value)),
+		}
+	}
+
+	return checkParamsVote(data, tx, context, block, data.Height, data.Name)
+}
+
+func (data VoteParamsData) String() string {
+	return fmt.Sprintf("VOTE PARAMS %s: %s on height: %d", data.Name, data.Value, data.Height)
+}
+
+func (data VoteParamsData) CommissionData(price *commission.Price) *big.Int {
+	return price.VoteUpdate
+}
+
+func (data VoteParamsData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return runParamsVote(tx, context, rewardPool, currentBlock, price, data.basicCheck, data.PubKey, data.Height, data.Name, data.Value)
+}
+
+// checkParamsVote checks the height of voting and the vote of the candidate sent by its owner
+func checkParamsVote(data CandidateTx, tx *Transaction, context *state.CheckState, block uint64, height uint64, name string) *Response {
+	if height < block {
+		return &Response{
+			Code: code.VoteExpired,
+			Log:  "vote is produced for the past state",
+			Info: EncodeError(code.NewVoteExpired(strconv.Itoa(int(block)), strconv.Itoa(int(height)))),
+		}
+	}
+
+	if context.Params().IsVoteExists(height, name, data.GetPubKey()) {
+		return &Response{
+			Code: code.VoteAlreadyExists,
+			Log:  "Params vote with such public key, parameter and height already exists",
+			Info: EncodeError(code.NewVoteAlreadyExists(strconv.FormatUint(height, 10), data.GetPubKey().String())),
+		}
+	}
+
+	return checkCandidateOwnership(data, tx, context)
+}
+
+func runParamsVote(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int,
+	basicCheck func(tx *Transaction, context *state.CheckState, block uint64) *Response,
+	pubKey types.Pubkey, height uint64, name string, paramValue string) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+
+		deliverState.Params.AddVote(height, name, paramValue, pubKey)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.public_key"), Value: []byte(hex.EncodeToString(pubKey[:])), Index: true},
+			{Key: []byte("tx.params_name"), Value: []byte(name), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
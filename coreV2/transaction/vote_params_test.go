@@ -0,0 +1,108 @@
+package transaction
+
+import (
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestProposeAndVoteParamsTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey1, _ := crypto.GenerateKey()
+	addr1 := crypto.PubkeyToAddress(privateKey1.PublicKey)
+	pubkey1 := types.Pubkey{}
+	rand.Read(pubkey1[:])
+	cState.Candidates.Create(addr1, addr1, addr1, pubkey1, 10, 0, 0)
+	cState.Accounts.AddBalance(addr1, coin, helpers.BipToPip(big.NewInt(10)))
+
+	privateKey2, _ := crypto.GenerateKey()
+	addr2 := crypto.PubkeyToAddress(privateKey2.PublicKey)
+	pubkey2 := types.Pubkey{}
+	rand.Read(pubkey2[:])
+	cState.Candidates.Create(addr2, addr2, addr2, pubkey2, 10, 0, 0)
+	cState.Accounts.AddBalance(addr2, coin, helpers.BipToPip(big.NewInt(10)))
+
+	proposal := ProposeParamsData{PubKey: pubkey1, Height: 100, Name: params.MaxGas, Value: "50000"}
+	vote := VoteParamsData{PubKey: pubkey2, Height: 100, Name: params.MaxGas, Value: "50000"}
+
+	response := NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey2, 1, TypeVoteParams, vote), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.ParamsProposalNotExists {
+		t.Fatalf("Response code is not %d. Error %s", code.ParamsProposalNotExists, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey1, 1, TypeProposeParams, ProposeParamsData{PubKey: pubkey1, Height: 100, Name: "unknown", Value: "1"}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.WrongParam {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongParam, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey2, 1, TypeProposeParams, proposal), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.IsNotOwnerOfCandidate {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCandidate, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey1, 1, TypeProposeParams, proposal), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey1, 2, TypeProposeParams, proposal), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.ParamsProposalExists {
+		t.Fatalf("Response code is not %d. Error %s", code.ParamsProposalExists, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey2, 1, TypeVoteParams, vote), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	response = NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey2, 2, TypeProposeParams, ProposeParamsData{PubKey: pubkey2, Height: 100, Name: params.MaxGas, Value: "60000"}), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.VoteAlreadyExists {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteAlreadyExists, response.Log)
+	}
+
+	if p := cState.Params.GetProposal(100, params.MaxGas, "50000"); p == nil || len(p.Votes) != 2 {
+		t.Fatalf("Proposal is not correct: %+v", p)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnbondTxWithChangedUnbondPeriod(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	pubkey := types.Pubkey{}
+	rand.Read(pubkey[:])
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10, 0, 0)
+	cState.Accounts.AddBalance(addr, coin, helpers.BipToPip(big.NewInt(10)))
+
+	value := helpers.BipToPip(big.NewInt(100))
+	cState.Candidates.Delegate(addr, pubkey, coin, value, big.NewInt(0))
+	cState.Candidates.RecalculateStakes(109000)
+	cState.Params.SetValue(params.UnbondPeriod, big.NewInt(10))
+
+	data := UnbondDataV3{PubKey: pubkey, Coin: coin, Value: value}
+	response := NewExecutorV340(GetDataV340).RunTx(cState, signedTestTx(t, privateKey, 1, TypeUnbond, data), big.NewInt(0), 1, &sync.Map{}, 0, false)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not %d. Error %s", code.OK, response.Log)
+	}
+
+	if funds := cState.FrozenFunds.GetFrozenFunds(11); funds == nil || len(funds.List) != 1 || funds.List[0].Value.Cmp(value) != 0 {
+		t.Fatalf("Frozen funds are not correct: %+v", funds)
+	}
+}
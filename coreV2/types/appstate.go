@@ -20,10 +20,19 @@ type AppState struct {
 	Accounts            []Account          `json:"accounts,omitempty"`
 	Coins               []Coin             `json:"coins,omitempty"`
 	FrozenFunds         []FrozenFund       `json:"frozen_funds,omitempty"`
+	HTLCs               []HTLC             `json:"htlcs,omitempty"`
+	Vestings            []Vesting          `json:"vestings,omitempty"`
+	Allowances          []Allowance        `json:"allowances,omitempty"`
+	RecurringPayments   []RecurringPayment `json:"recurring_payments,omitempty"`
+	NextRecurringID     uint64             `json:"next_recurring_id,omitempty"`
+	MultisigProposals   []MultisigProposal `json:"multisig_proposals,omitempty"`
+	NextProposalID      uint64             `json:"next_proposal_id,omitempty"`
 	HaltBlocks          []HaltBlock        `json:"halt_blocks,omitempty"`
 	Commission          Commission         `json:"commission,omitempty"`
 	CommissionVotes     []CommissionVote   `json:"commission_votes,omitempty"`
 	UpdateVotes         []UpdateVote       `json:"update_votes,omitempty"`
+	Params              []Param            `json:"params,omitempty"`
+	ParamsVotes         []ParamsVote       `json:"params_votes,omitempty"`
 	UsedChecks          []UsedCheck        `json:"used_checks,omitempty"`
 	MaxGas              uint64             `json:"max_gas"`
 	TotalSlashed        string             `json:"total_slashed"`
@@ -203,6 +212,12 @@ func (s *AppState) Verify() error {
 
 		}
 
+		for _, htlc := range s.HTLCs {
+			if htlc.Coin == coin.ID {
+				volume.Add(volume, helpers.StringToBigInt(htlc.Value))
+			}
+		}
+
 		if coin.Crr == 0 {
 			if volume.Cmp(helpers.StringToBigInt(coin.Volume)) != 0 {
 				return fmt.Errorf("wrong token %s (%d) volume (%s)", coin.Symbol.String(), coin.ID, big.NewInt(0).Sub(volume, helpers.StringToBigInt(coin.Volume)))
@@ -286,6 +301,171 @@ func (s *AppState) Verify() error {
 		}
 	}
 
+	htlcs := map[Hash]struct{}{}
+	for _, htlc := range s.HTLCs {
+		if !helpers.IsValidBigInt(htlc.Value) {
+			return fmt.Errorf("wrong htlc value: %s", htlc.Value)
+		}
+
+		if _, exists := htlcs[htlc.HashLock]; exists {
+			return fmt.Errorf("duplicated htlc %s", htlc.HashLock.String())
+		}
+		htlcs[htlc.HashLock] = struct{}{}
+
+		// check not existing coins
+		coinID := CoinID(htlc.Coin)
+		if !coinID.IsBaseCoin() {
+			foundCoin := false
+			for _, coin := range s.Coins {
+				if CoinID(coin.ID) == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
+	for _, vesting := range s.Vestings {
+		if !helpers.IsValidBigInt(vesting.Value) || helpers.StringToBigInt(vesting.Value).Sign() != 1 {
+			return fmt.Errorf("wrong vesting value: %s", vesting.Value)
+		}
+
+		if vesting.StartHeight >= vesting.EndHeight || vesting.CliffHeight < vesting.StartHeight || vesting.CliffHeight > vesting.EndHeight || vesting.Steps > vesting.EndHeight-vesting.StartHeight {
+			return fmt.Errorf("wrong vesting schedule of %s", vesting.Address.String())
+		}
+
+		// check not existing coins
+		coinID := CoinID(vesting.Coin)
+		if !coinID.IsBaseCoin() {
+			foundCoin := false
+			for _, coin := range s.Coins {
+				if CoinID(coin.ID) == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
+	allowances := map[string]struct{}{}
+	for _, allowance := range s.Allowances {
+		if !helpers.IsValidBigInt(allowance.Value) || helpers.StringToBigInt(allowance.Value).Sign() != 1 {
+			return fmt.Errorf("wrong allowance value: %s", allowance.Value)
+		}
+
+		if allowance.Owner == allowance.Spender {
+			return fmt.Errorf("allowance of %s to itself", allowance.Owner.String())
+		}
+
+		// check for allowances duplication
+		key := fmt.Sprintf("%s%s%d", allowance.Owner.String(), allowance.Spender.String(), allowance.Coin)
+		if _, exists := allowances[key]; exists {
+			return fmt.Errorf("duplicated allowance of %s to %s", allowance.Owner.String(), allowance.Spender.String())
+		}
+		allowances[key] = struct{}{}
+
+		// check not existing coins
+		coinID := CoinID(allowance.Coin)
+		if !coinID.IsBaseCoin() {
+			foundCoin := false
+			for _, coin := range s.Coins {
+				if CoinID(coin.ID) == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
+	recurringPayments := map[uint64]struct{}{}
+	for _, payment := range s.RecurringPayments {
+		if !helpers.IsValidBigInt(payment.Value) || helpers.StringToBigInt(payment.Value).Sign() != 1 {
+			return fmt.Errorf("wrong recurring payment value: %s", payment.Value)
+		}
+
+		if payment.Period == 0 || payment.Remaining == 0 {
+			return fmt.Errorf("wrong recurring payment %d", payment.ID)
+		}
+
+		// check for recurring payments duplication
+		if _, exists := recurringPayments[payment.ID]; exists {
+			return fmt.Errorf("duplicated recurring payment %d", payment.ID)
+		}
+		recurringPayments[payment.ID] = struct{}{}
+
+		if payment.ID == 0 || payment.ID >= s.NextRecurringID {
+			return fmt.Errorf("recurring payment ID %d is not less than next ID %d", payment.ID, s.NextRecurringID)
+		}
+
+		// check not existing coins
+		coinID := CoinID(payment.Coin)
+		if !coinID.IsBaseCoin() {
+			foundCoin := false
+			for _, coin := range s.Coins {
+				if CoinID(coin.ID) == coinID {
+					foundCoin = true
+					break
+				}
+			}
+
+			if !foundCoin {
+				return fmt.Errorf("coin %s not found", coinID)
+			}
+		}
+	}
+
+	proposals := map[uint64]struct{}{}
+	for _, proposal := range s.MultisigProposals {
+		// check for proposals duplication
+		if _, exists := proposals[proposal.ID]; exists {
+			return fmt.Errorf("duplicated multisig proposal %d", proposal.ID)
+		}
+		proposals[proposal.ID] = struct{}{}
+
+		if proposal.ID == 0 || proposal.ID >= s.NextProposalID {
+			return fmt.Errorf("multisig proposal ID %d is not less than next ID %d", proposal.ID, s.NextProposalID)
+		}
+
+		if _, err := hex.DecodeString(proposal.Data); err != nil {
+			return fmt.Errorf("wrong data of multisig proposal %d: %s", proposal.ID, err)
+		}
+
+		if len(proposal.Approvals) == 0 {
+			return fmt.Errorf("multisig proposal %d is not approved by its proposer", proposal.ID)
+		}
+	}
+
+	params := map[string]struct{}{}
+	for _, param := range s.Params {
+		// check for params duplication
+		if _, exists := params[param.Name]; exists {
+			return fmt.Errorf("duplicated param %s", param.Name)
+		}
+		params[param.Name] = struct{}{}
+
+		if value, ok := big.NewInt(0).SetString(param.Value, 10); !ok || value.Sign() != 1 {
+			return fmt.Errorf("wrong value of param %s", param.Name)
+		}
+	}
+
+	for _, vote := range s.ParamsVotes {
+		if len(vote.Votes) == 0 {
+			return fmt.Errorf("params vote for %s at height %d has no votes", vote.Name, vote.Height)
+		}
+	}
+
 	// check used checks length
 	for _, check := range s.UsedChecks {
 		b, err := hex.DecodeString(string(check))
@@ -382,6 +562,58 @@ type FrozenFund struct {
 	MoveToCandidateID uint64  `json:"move_to_candidate_id,omitempty"`
 }
 
+type HTLC struct {
+	HashLock  Hash    `json:"hash_lock"`
+	Sender    Address `json:"sender"`
+	Recipient Address `json:"recipient"`
+	Coin      uint64  `json:"coin"`
+	Value     string  `json:"value"`
+	Timeout   uint64  `json:"timeout"`
+}
+
+// Vesting locks the part of the account balance, see vesting.Schedule
+type Vesting struct {
+	Address     Address `json:"address"`
+	Coin        uint64  `json:"coin"`
+	Value       string  `json:"value"`
+	StartHeight uint64  `json:"start_height"`
+	CliffHeight uint64  `json:"cliff_height"`
+	EndHeight   uint64  `json:"end_height"`
+	Steps       uint64  `json:"steps,omitempty"`
+}
+
+// Allowance is the value of the coin which the spender can transfer from the balance of the owner
+type Allowance struct {
+	Owner   Address `json:"owner"`
+	Spender Address `json:"spender"`
+	Coin    uint64  `json:"coin"`
+	Value   string  `json:"value"`
+}
+
+// RecurringPayment is the standing order of the payer, see recurring.Model
+type RecurringPayment struct {
+	ID         uint64  `json:"id"`
+	Payer      Address `json:"payer"`
+	Recipient  Address `json:"recipient"`
+	Coin       uint64  `json:"coin"`
+	Value      string  `json:"value"`
+	Period     uint64  `json:"period"`
+	Remaining  uint64  `json:"remaining"`
+	NextHeight uint64  `json:"next_height"`
+}
+
+// MultisigProposal is the transaction proposed on behalf of the multisig, Data is hex encoded
+type MultisigProposal struct {
+	ID        uint64    `json:"id"`
+	Multisig  Address   `json:"multisig"`
+	Proposer  Address   `json:"proposer"`
+	GasCoin   uint64    `json:"gas_coin"`
+	Type      uint64    `json:"type"`
+	Data      string    `json:"data"`
+	Approvals []Address `json:"approvals"`
+	Height    uint64    `json:"height"`
+}
+
 type UsedCheck string
 
 type Account struct {
@@ -419,6 +651,20 @@ type UpdateVote struct {
 	Version string   `json:"version"`
 }
 
+// Param is the value of the network parameter changed by validators
+type Param struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ParamsVote is the proposed value of the network parameter with votes of validators at the height
+type ParamsVote struct {
+	Height uint64   `json:"height"`
+	Name   string   `json:"name"`
+	Value  string   `json:"value"`
+	Votes  []Pubkey `json:"votes"`
+}
+
 type Commission struct {
 	Coin                    uint64 `json:"coin"`
 	PayloadByte             string `json:"payload_byte"`
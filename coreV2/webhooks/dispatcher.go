@@ -0,0 +1,376 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/validators"
+	db "github.com/tendermint/tm-db"
+)
+
+const (
+	webhookPrefix = 'w'
+	queuePrefix   = 'q'
+	seqKey        = "s"
+)
+
+const (
+	// MaxAttempts is the number of attempts to deliver the notifications before they are dropped
+	MaxAttempts = 12
+
+	minRetryDelay   = 5 * time.Second
+	maxRetryDelay   = time.Hour
+	deliveryTimeout = 10 * time.Second
+	pollInterval    = time.Second
+)
+
+// Headers of deliveries
+const (
+	HeaderWebhook   = "X-Minter-Webhook"
+	HeaderDelivery  = "X-Minter-Delivery"
+	HeaderSignature = "X-Minter-Signature"
+)
+
+// ErrNotFound is returned if the webhook does not exist
+var ErrNotFound = errors.New("webhook not found")
+
+// Payload is the body of the delivery
+type Payload struct {
+	Delivery      uint64          `json:"delivery"`
+	Webhook       string          `json:"webhook"`
+	Height        uint64          `json:"height"`
+	Notifications []*Notification `json:"notifications"`
+}
+
+// delivery is the payload waiting in the queue
+type delivery struct {
+	WebhookID   string    `json:"webhook_id"`
+	Body        []byte    `json:"body"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// Dispatcher stores webhooks and delivers notifications collected during the block to them.
+// Deliveries are queued on disk at Commit and retried with exponential backoff until MaxAttempts.
+type Dispatcher struct {
+	lock    sync.Mutex
+	db      db.DB
+	client  *http.Client
+	seq     uint64
+	pending []*Notification
+	wake    chan struct{}
+}
+
+// NewDispatcher creates the dispatcher storing webhooks and the queue in given DB
+func NewDispatcher(db db.DB) *Dispatcher {
+	d := &Dispatcher{
+		db:     db,
+		client: &http.Client{Timeout: deliveryTimeout},
+		wake:   make(chan struct{}, 1),
+	}
+	seq, err := db.Get([]byte(seqKey))
+	if err != nil {
+		panic(err)
+	}
+	if len(seq) == 8 {
+		d.seq = binary.BigEndian.Uint64(seq)
+	}
+	return d
+}
+
+// AddWebhook validates and stores the webhook, its ID is generated
+func (d *Dispatcher) AddWebhook(hook *Webhook) error {
+	u, err := url.Parse(hook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url %q", hook.URL)
+	}
+	if hook.Secret == "" {
+		return errors.New("secret is empty")
+	}
+	for _, kind := range hook.Kinds {
+		if !containsString(Kinds(), kind) {
+			return fmt.Errorf("unknown kind %q", kind)
+		}
+	}
+	if hook.MissedBlocks < 0 || hook.MissedBlocks > validators.ValidatorMaxAbsentWindow {
+		return fmt.Errorf("missed blocks should be between 0 and %d", validators.ValidatorMaxAbsentWindow)
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	hook.ID = hex.EncodeToString(id)
+
+	value, err := json.Marshal(hook)
+	if err != nil {
+		return err
+	}
+	return d.db.SetSync(webhookKey(hook.ID), value)
+}
+
+// RemoveWebhook deletes the webhook, its queued deliveries are dropped
+func (d *Dispatcher) RemoveWebhook(id string) error {
+	has, err := d.db.Has(webhookKey(id))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return ErrNotFound
+	}
+	return d.db.DeleteSync(webhookKey(id))
+}
+
+// Webhooks returns stored webhooks
+func (d *Dispatcher) Webhooks() ([]*Webhook, error) {
+	iterator, err := db.IteratePrefix(d.db, []byte{webhookPrefix})
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	var hooks []*Webhook
+	for ; iterator.Valid(); iterator.Next() {
+		hook := &Webhook{}
+		if err := json.Unmarshal(iterator.Value(), hook); err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, iterator.Error()
+}
+
+// QueueSize returns the number of deliveries waiting in the queue by webhook id
+func (d *Dispatcher) QueueSize() (map[string]int, error) {
+	iterator, err := db.IteratePrefix(d.db, []byte{queuePrefix})
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	size := map[string]int{}
+	for ; iterator.Valid(); iterator.Next() {
+		item := &delivery{}
+		if err := json.Unmarshal(iterator.Value(), item); err != nil {
+			return nil, err
+		}
+		size[item.WebhookID]++
+	}
+	return size, iterator.Error()
+}
+
+// Notify adds the notification of the current block
+func (d *Dispatcher) Notify(n *Notification) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.pending = append(d.pending, n)
+}
+
+// Commit queues notifications of the block for matching webhooks, one delivery per webhook
+func (d *Dispatcher) Commit(height uint64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	pending := d.pending
+	d.pending = nil
+	if len(pending) == 0 {
+		return nil
+	}
+
+	hooks, err := d.Webhooks()
+	if err != nil {
+		return err
+	}
+
+	batch := d.db.NewBatch()
+	defer batch.Close()
+
+	seq := d.seq
+	for _, hook := range hooks {
+		var matched []*Notification
+		for _, n := range pending {
+			if hook.matches(n) {
+				matched = append(matched, n)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		seq++
+		body, err := json.Marshal(&Payload{Delivery: seq, Webhook: hook.ID, Height: height, Notifications: matched})
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(&delivery{WebhookID: hook.ID, Body: body})
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(queueKey(seq), value); err != nil {
+			return err
+		}
+	}
+	if seq == d.seq {
+		return nil
+	}
+
+	if err := batch.Set([]byte(seqKey), uint64ToBytes(seq)); err != nil {
+		return err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+	d.seq = seq
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run delivers queued notifications until the context is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		d.deliverDue(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// deliverDue sends deliveries whose next attempt is not after now
+func (d *Dispatcher) deliverDue(ctx context.Context, now time.Time) {
+	iterator, err := db.IteratePrefix(d.db, []byte{queuePrefix})
+	if err != nil {
+		return
+	}
+	type queued struct {
+		key  []byte
+		item *delivery
+	}
+	var due []queued
+	for ; iterator.Valid(); iterator.Next() {
+		item := &delivery{}
+		if err := json.Unmarshal(iterator.Value(), item); err != nil {
+			continue
+		}
+		if item.NextAttempt.After(now) {
+			continue
+		}
+		due = append(due, queued{key: append([]byte{}, iterator.Key()...), item: item})
+	}
+	iterator.Close()
+
+	for _, q := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		d.attempt(ctx, q.key, q.item, now)
+	}
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, key []byte, item *delivery, now time.Time) {
+	value, err := d.db.Get(webhookKey(item.WebhookID))
+	if err != nil {
+		return
+	}
+	hook := &Webhook{}
+	if value == nil || json.Unmarshal(value, hook) != nil {
+		_ = d.db.Delete(key)
+		return
+	}
+
+	err = d.send(ctx, hook, binary.BigEndian.Uint64(key[1:]), item.Body)
+	if err == nil {
+		_ = d.db.Delete(key)
+		return
+	}
+
+	item.LastError = err.Error()
+	item.Attempts++
+	if item.Attempts >= MaxAttempts {
+		_ = d.db.Delete(key)
+		return
+	}
+	item.NextAttempt = now.Add(retryDelay(item.Attempts))
+	if value, err := json.Marshal(item); err == nil {
+		_ = d.db.Set(key, value)
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, hook *Webhook, seq uint64, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhook, hook.ID)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(seq, 10))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(hook.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body with the secret, it is sent in HeaderSignature
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay returns the delay after the failed attempt
+func retryDelay(attempts int) time.Duration {
+	delay := minRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+func webhookKey(id string) []byte {
+	return append([]byte{webhookPrefix}, id...)
+}
+
+func queueKey(seq uint64) []byte {
+	return append([]byte{queuePrefix}, uint64ToBytes(seq)...)
+}
+
+func uint64ToBytes(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
@@ -0,0 +1,118 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestDispatcher_Deliver(t *testing.T) {
+	var (
+		payloads []*Payload
+		fail     = true
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Header.Get(HeaderSignature) != "sha256="+Sign("secret", body) {
+			t.Errorf("invalid signature %s", r.Header.Get(HeaderSignature))
+		}
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		payload := &Payload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			t.Error(err)
+		}
+		payloads = append(payloads, payload)
+	}))
+	defer server.Close()
+
+	memDB := db.NewMemDB()
+	dispatcher := NewDispatcher(memDB)
+
+	watched := types.Address{1}
+	hook := &Webhook{URL: server.URL, Secret: "secret", Kinds: []string{KindReceived, KindMissedBlocks}, Addresses: []types.Address{watched}}
+	if err := dispatcher.AddWebhook(hook); err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.AddWebhook(&Webhook{URL: "ftp://example.com", Secret: "secret"}); err == nil {
+		t.Fatal("expected error of invalid url")
+	}
+	if err := dispatcher.AddWebhook(&Webhook{URL: server.URL, Secret: "secret", MissedBlocks: 25}); err == nil {
+		t.Fatal("expected error of missed blocks out of the window")
+	}
+
+	other := types.Address{2}
+	pubKey := types.Pubkey{3}
+	dispatcher.Notify(&Notification{Kind: KindReceived, Height: 1, Address: &watched, Data: map[string]string{"value": "10"}})
+	dispatcher.Notify(&Notification{Kind: KindReceived, Height: 1, Address: &other})
+	dispatcher.Notify(&Notification{Kind: KindSent, Height: 1, Address: &watched})
+	dispatcher.Notify(&Notification{Kind: KindMissedBlocks, Height: 1, PubKey: &pubKey, MissedBlocks: DefaultMissedBlocks - 1})
+	dispatcher.Notify(&Notification{Kind: KindMissedBlocks, Height: 1, PubKey: &pubKey, MissedBlocks: DefaultMissedBlocks, PreviousMissedBlocks: DefaultMissedBlocks - 1})
+	dispatcher.Notify(&Notification{Kind: KindMissedBlocks, Height: 1, PubKey: &pubKey, MissedBlocks: DefaultMissedBlocks, PreviousMissedBlocks: DefaultMissedBlocks})
+	dispatcher.Notify(&Notification{Kind: KindMissedBlocks, Height: 1, PubKey: &pubKey, MissedBlocks: DefaultMissedBlocks + 1, PreviousMissedBlocks: DefaultMissedBlocks})
+	if err := dispatcher.Commit(1); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	dispatcher.deliverDue(context.Background(), now)
+	if size, err := dispatcher.QueueSize(); err != nil || size[hook.ID] != 1 {
+		t.Fatalf("failed delivery should stay in the queue, got %v, %v", size, err)
+	}
+
+	fail = false
+	dispatcher.deliverDue(context.Background(), now)
+	if len(payloads) != 0 {
+		t.Fatal("delivery should be retried after the delay")
+	}
+
+	// the queue and the sequence survive a restart
+	dispatcher = NewDispatcher(memDB)
+	dispatcher.deliverDue(context.Background(), now.Add(minRetryDelay))
+	if len(payloads) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(payloads))
+	}
+	payload := payloads[0]
+	if payload.Delivery != 1 || payload.Webhook != hook.ID || payload.Height != 1 || len(payload.Notifications) != 2 {
+		t.Fatalf("unexpected payload %#v", payload)
+	}
+	if n := payload.Notifications[0]; n.Kind != KindReceived || *n.Address != watched || n.Data["value"] != "10" {
+		t.Fatalf("unexpected notification %#v", n)
+	}
+	if n := payload.Notifications[1]; n.Kind != KindMissedBlocks || n.MissedBlocks != DefaultMissedBlocks {
+		t.Fatalf("unexpected notification %#v", n)
+	}
+	if size, _ := dispatcher.QueueSize(); len(size) != 0 {
+		t.Fatalf("queue should be empty, got %v", size)
+	}
+
+	dispatcher.Notify(&Notification{Kind: KindReceived, Height: 2, Address: &watched})
+	if err := dispatcher.Commit(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := dispatcher.RemoveWebhook(hook.ID); err != nil {
+		t.Fatal(err)
+	}
+	dispatcher.deliverDue(context.Background(), now)
+	if len(payloads) != 1 {
+		t.Fatal("deliveries of the removed webhook should be dropped")
+	}
+	if err := dispatcher.RemoveWebhook(hook.ID); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	if retryDelay(1) != minRetryDelay || retryDelay(2) != 2*minRetryDelay || retryDelay(MaxAttempts) != maxRetryDelay {
+		t.Fatal("unexpected retry delays")
+	}
+}
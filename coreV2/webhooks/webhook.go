@@ -0,0 +1,105 @@
+package webhooks
+
+import (
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Kinds of notifications
+const (
+	KindReceived     = "address.received"
+	KindSent         = "address.sent"
+	KindStake        = "stake.changed"
+	KindMissedBlocks = "validator.missed_blocks"
+	KindJailed       = "candidate.jailed"
+	KindOrderFilled  = "order.filled"
+	KindHaltVotes    = "votes.halt_reached"
+	KindUpdateVotes  = "votes.update_reached"
+)
+
+// Kinds returns all kinds of notifications
+func Kinds() []string {
+	return []string{KindReceived, KindSent, KindStake, KindMissedBlocks, KindJailed, KindOrderFilled, KindHaltVotes, KindUpdateVotes}
+}
+
+// DefaultMissedBlocks is the number of missed blocks of the validator to notify about if the webhook does not set it,
+// the webhook can't set more than validators.ValidatorMaxAbsentWindow
+const DefaultMissedBlocks = 6
+
+// Webhook is the URL receiving notifications signed with the secret.
+// Empty Kinds matches every kind, Addresses and PubKeys filter notifications about addresses and validators,
+// empty lists match any address or public key.
+type Webhook struct {
+	ID           string          `json:"id"`
+	URL          string          `json:"url"`
+	Secret       string          `json:"secret"`
+	Kinds        []string        `json:"kinds,omitempty"`
+	Addresses    []types.Address `json:"addresses,omitempty"`
+	PubKeys      []types.Pubkey  `json:"pub_keys,omitempty"`
+	MissedBlocks int             `json:"missed_blocks,omitempty"`
+}
+
+// Notification is the change observed by the node at the height
+type Notification struct {
+	Kind         string            `json:"kind"`
+	Height       uint64            `json:"height"`
+	Address      *types.Address    `json:"address,omitempty"`
+	PubKey       *types.Pubkey     `json:"pub_key,omitempty"`
+	TxHash       string            `json:"tx_hash,omitempty"`
+	MissedBlocks int               `json:"missed_blocks,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+
+	// PreviousMissedBlocks is the number of missed blocks of the validator before the block, not delivered
+	PreviousMissedBlocks int `json:"-"`
+}
+
+func (w *Webhook) matches(n *Notification) bool {
+	if len(w.Kinds) != 0 && !containsString(w.Kinds, n.Kind) {
+		return false
+	}
+
+	if n.Address != nil && len(w.Addresses) != 0 {
+		found := false
+		for _, address := range w.Addresses {
+			if address == *n.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if n.PubKey != nil && len(w.PubKeys) != 0 {
+		found := false
+		for _, pubKey := range w.PubKeys {
+			if pubKey == *n.PubKey {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if n.Kind == KindMissedBlocks {
+		threshold := w.MissedBlocks
+		if threshold == 0 {
+			threshold = DefaultMissedBlocks
+		}
+		// the count may stay at the threshold for many blocks, only the block raising it to the threshold is notified
+		return n.PreviousMissedBlocks < threshold && n.MissedBlocks >= threshold
+	}
+
+	return true
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}